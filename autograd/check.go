@@ -0,0 +1,72 @@
+package autograd
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Gradients of the single element output of f with respect to each of inputs by central differences,
+//with steps of 1e-6, relative when an element exceeds 1 in magnitude. f records its computation on the
+//given tape, from the variables holding the inputs.
+func NumericalGradients(f func(t *Tape, xs ...*Variable) *Variable, inputs ...*nd.NdArray) []*nd.NdArray {
+	eval := func() float64 {
+		t := NewTape()
+		xs := make([]*Variable, len(inputs))
+		for i, in := range inputs {
+			xs[i] = t.Constant(in)
+		}
+		out := f(t, xs...)
+		if out.Value.Size() != 1 {
+			panic("shape error")
+		}
+		return out.Value.Values()[0]
+	}
+
+	grads := make([]*nd.NdArray, len(inputs))
+	for i, in := range inputs {
+		//perturb a copy, the caller's array is left alone
+		probe := in.Clone()
+		inputs[i] = probe
+		ps := probe.Values()
+		g := nd.Zeros(in.Shape()...)
+		for k, v := range ps {
+			h := 1e-6 * math.Max(1, math.Abs(v))
+			ps[k] = v + h
+			plus := eval()
+			ps[k] = v - h
+			minus := eval()
+			ps[k] = v
+			g.Values()[k] = (plus - minus) / (2 * h)
+		}
+		grads[i] = g
+		inputs[i] = in
+	}
+
+	return grads
+}
+
+//Largest difference between the gradients of f by Backward and by NumericalGradients, relative to their
+//magnitude when it exceeds 1. Values below about 1e-6 mean the gradients agree.
+func GradCheck(f func(t *Tape, xs ...*Variable) *Variable, inputs ...*nd.NdArray) float64 {
+	t := NewTape()
+	xs := make([]*Variable, len(inputs))
+	for i, in := range inputs {
+		xs[i] = t.Variable(in)
+	}
+	f(t, xs...).Backward()
+
+	worst := 0.0
+	for i, numeric := range NumericalGradients(f, inputs...) {
+		analytic := xs[i].Grad
+		if analytic == nil {
+			analytic = nd.Zeros(inputs[i].Shape()...)
+		}
+		for k, n := range numeric.Values() {
+			a := analytic.Values()[k]
+			worst = math.Max(worst, math.Abs(a-n)/math.Max(1, math.Abs(a)+math.Abs(n)))
+		}
+	}
+
+	return worst
+}
@@ -0,0 +1,42 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestNumericalGradients(t *testing.T) {
+	x := nd.Array(1, 2, 3)
+	grads := NumericalGradients(func(t *Tape, xs ...*Variable) *Variable {
+		return xs[0].Pow(2).Sum()
+	}, x)
+	for i, g := range grads[0].Values() {
+		if math.Abs(g-2*x.Values()[i]) > 1e-6 {
+			t.Error("Expected ", 2*x.Values()[i], ", got ", g)
+		}
+	}
+	if !x.Equals(nd.Array(1, 2, 3)) {
+		t.Error("Expected the input unchanged, got ", x)
+	}
+}
+
+func TestGradCheck(t *testing.T) {
+	f := func(t *Tape, xs ...*Variable) *Variable {
+		return xs[0].Mul(xs[1]).Exp().Sum()
+	}
+	worst := GradCheck(f, nd.Array(0.1, 0.2), nd.Array(-1, 2))
+	if worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+
+	//a wrong derivative is detected
+	wrong := func(t *Tape, xs ...*Variable) *Variable {
+		return xs[0].Map(math.Sin, func(x, y float64) float64 { return -math.Cos(x) }).Sum()
+	}
+	worst = GradCheck(wrong, nd.Array(0.3, 1.1))
+	if worst < 0.1 {
+		t.Error("Expected a large difference, got ", worst)
+	}
+}
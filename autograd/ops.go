@@ -0,0 +1,225 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Elementwise sum a + b, broadcast like numpy.
+func (a *Variable) Add(b *Variable) *Variable {
+	return binary(a, b, func(x, y float64) float64 { return x + y },
+		func(x, y float64) (float64, float64) { return 1, 1 })
+}
+
+//Elementwise difference a - b, broadcast like numpy.
+func (a *Variable) Sub(b *Variable) *Variable {
+	return binary(a, b, func(x, y float64) float64 { return x - y },
+		func(x, y float64) (float64, float64) { return 1, -1 })
+}
+
+//Elementwise product a * b, broadcast like numpy.
+func (a *Variable) Mul(b *Variable) *Variable {
+	return binary(a, b, func(x, y float64) float64 { return x * y },
+		func(x, y float64) (float64, float64) { return y, x })
+}
+
+//Elementwise quotient a / b, broadcast like numpy.
+func (a *Variable) Div(b *Variable) *Variable {
+	return binary(a, b, func(x, y float64) float64 { return x / y },
+		func(x, y float64) (float64, float64) { return 1 / y, -x / (y * y) })
+}
+
+//Elementwise maximum of a and b, broadcast like numpy. The gradient goes to a on ties.
+func (a *Variable) Maximum(b *Variable) *Variable {
+	return binary(a, b, math.Max, func(x, y float64) (float64, float64) {
+		if x >= y {
+			return 1, 0
+		}
+		return 0, 1
+	})
+}
+
+//Matrix product of 1d or 2d variables, like nd.MatMul: 1d operands are taken as a row on the left
+//and a column on the right, and that dimension is removed from the result.
+func (a *Variable) Dot(b *Variable) *Variable {
+	if a.Value.NDims() > 2 || b.Value.NDims() > 2 {
+		panic("shape error")
+	}
+	as, bs := a.Shape(), b.Shape()
+	//the operands as matrices
+	am, bm := a.Value, b.Value
+	if len(as) == 1 {
+		am = am.Reshape(1, as[0])
+	}
+	if len(bs) == 1 {
+		bm = bm.Reshape(bs[0], 1)
+	}
+	value := nd.MatMul(a.Value, b.Value)
+	m, n := am.Shape()[0], bm.Shape()[1]
+
+	return record(value, func(grad []float64) {
+		g := nd.Array(grad...).Reshape(m, n)
+		if a.requires {
+			for i, v := range nd.MatMul(g, bm.T()).Values() {
+				a.grad[i] += v
+			}
+		}
+		if b.requires {
+			for i, v := range nd.MatMul(am.T(), g).Values() {
+				b.grad[i] += v
+			}
+		}
+	}, a, b)
+}
+
+//Elementwise -a.
+func (a *Variable) Neg() *Variable {
+	return a.Map(func(x float64) float64 { return -x }, func(x, y float64) float64 { return -1 })
+}
+
+//Elementwise exponential.
+func (a *Variable) Exp() *Variable {
+	return a.Map(math.Exp, func(x, y float64) float64 { return y })
+}
+
+//Elementwise natural logarithm.
+func (a *Variable) Log() *Variable {
+	return a.Map(math.Log, func(x, y float64) float64 { return 1 / x })
+}
+
+//Elementwise power a^p.
+func (a *Variable) Pow(p float64) *Variable {
+	return a.Map(func(x float64) float64 { return math.Pow(x, p) },
+		func(x, y float64) float64 { return p * math.Pow(x, p-1) })
+}
+
+//Elementwise hyperbolic tangent.
+func (a *Variable) Tanh() *Variable {
+	return a.Map(math.Tanh, func(x, y float64) float64 { return 1 - y*y })
+}
+
+//Elementwise logistic function 1 / (1 + exp(-a)).
+func (a *Variable) Sigmoid() *Variable {
+	return a.Map(func(x float64) float64 {
+		if x >= 0 {
+			return 1 / (1 + math.Exp(-x))
+		}
+		e := math.Exp(x)
+		return e / (1 + e)
+	}, func(x, y float64) float64 { return y * (1 - y) })
+}
+
+//Elementwise max(a, 0).
+func (a *Variable) ReLU() *Variable {
+	return a.Map(func(x float64) float64 { return math.Max(x, 0) }, func(x, y float64) float64 {
+		if x > 0 {
+			return 1
+		}
+		return 0
+	})
+}
+
+//Elementwise f, with its derivative df at x where f(x) = y.
+func (a *Variable) Map(f func(x float64) float64, df func(x, y float64) float64) *Variable {
+	value := a.Value.Map(f)
+	xs, ys := a.Value.Values(), value.Values()
+
+	return record(value, func(grad []float64) {
+		for i, g := range grad {
+			a.grad[i] += g * df(xs[i], ys[i])
+		}
+	}, a)
+}
+
+//the elementwise f of a and b broadcast against each other, df gives the partial derivatives.
+func binary(a, b *Variable, f func(x, y float64) float64, df func(x, y float64) (float64, float64)) *Variable {
+	shape, ia, ib := broadcast(a.Shape(), b.Shape())
+	as, bs := a.Value.Values(), b.Value.Values()
+	value := nd.Zeros(shape...)
+	vs := value.Values()
+	for k := range vs {
+		vs[k] = f(as[ia[k]], bs[ib[k]])
+	}
+
+	return record(value, func(grad []float64) {
+		for k, g := range grad {
+			da, db := df(as[ia[k]], bs[ib[k]])
+			if a.requires {
+				a.grad[ia[k]] += g * da
+			}
+			if b.requires {
+				b.grad[ib[k]] += g * db
+			}
+		}
+	}, a, b)
+}
+
+//The shape of a and b broadcast against each other, and for each element of the result
+//the positions of the elements of a and b it comes from.
+func broadcast(a, b []int) ([]int, []int, []int) {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	pa, pb := pad(a, n), pad(b, n)
+	shape := make([]int, n)
+	for d := range shape {
+		switch {
+		case pa[d] == pb[d] || pb[d] == 1:
+			shape[d] = pa[d]
+		case pa[d] == 1:
+			shape[d] = pb[d]
+		default:
+			panic(fmt.Errorf("shapes: %v and %v do not broadcast", a, b))
+		}
+	}
+
+	size := util.ProductOfIntSlice(shape)
+	ia, ib := make([]int, size), make([]int, size)
+	//the strides of a and b along the result, 0 where they are broadcast
+	sa, sb := broadcastStrides(pa), broadcastStrides(pb)
+	pos := make([]int, n)
+	for k := 0; k < size; k++ {
+		for d, p := range pos {
+			ia[k] += p * sa[d]
+			ib[k] += p * sb[d]
+		}
+		for d := n - 1; d >= 0; d-- {
+			pos[d]++
+			if pos[d] < shape[d] {
+				break
+			}
+			pos[d] = 0
+		}
+	}
+
+	return shape, ia, ib
+}
+
+//row major strides of shape, 0 for dimensions of length 1.
+func broadcastStrides(shape []int) []int {
+	strides := make([]int, len(shape))
+	s := 1
+	for d := len(shape) - 1; d >= 0; d-- {
+		if shape[d] != 1 {
+			strides[d] = s
+		}
+		s *= shape[d]
+	}
+
+	return strides
+}
+
+//prepend ones to shape until it has n dimensions.
+func pad(shape []int, n int) []int {
+	padded := make([]int, n)
+	for i := range padded {
+		padded[i] = 1
+	}
+	copy(padded[n-len(shape):], shape)
+
+	return padded
+}
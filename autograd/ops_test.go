@@ -0,0 +1,132 @@
+package autograd
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestBinary(t *testing.T) {
+	ops := map[string]func(a, b *Variable) *Variable{
+		"add":     (*Variable).Add,
+		"sub":     (*Variable).Sub,
+		"mul":     (*Variable).Mul,
+		"div":     (*Variable).Div,
+		"maximum": (*Variable).Maximum,
+	}
+	a := nd.Array(0.5, -1, 2, 1.5, 3, -0.5).Reshape(2, 3)
+	b := nd.Array(1.2, 0.7, -2.5, 2, -1.4, 0.9).Reshape(2, 3)
+	for name, op := range ops {
+		worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+			return op(xs[0], xs[1]).Pow(2).Sum()
+		}, a, b)
+		if worst > 1e-6 {
+			t.Error("Expected gradients of ", name, " to agree, got ", worst)
+		}
+	}
+}
+
+func TestBroadcast(t *testing.T) {
+	tape := NewTape()
+	a := tape.Variable(nd.Array(1, 2, 3, 4, 5, 6).Reshape(2, 3))
+	row := tape.Variable(nd.Array(10, 20, 30))
+	col := tape.Variable(nd.Array(1, 2).Reshape(2, 1))
+	y := a.Add(row).Mul(col)
+	if !y.Value.Equals(nd.Array(11, 22, 33, 28, 50, 72).Reshape(2, 3)) {
+		t.Error("Expected [[11, 22, 33], [28, 50, 72]], got ", y.Value)
+	}
+	y.Sum().Backward()
+	if !row.Grad.Equals(nd.Array(3, 3, 3)) {
+		t.Error("Expected [3, 3, 3], got ", row.Grad)
+	}
+	if !col.Grad.Equals(nd.Array(66, 75).Reshape(2, 1)) {
+		t.Error("Expected [[66], [75]], got ", col.Grad)
+	}
+	if !a.Grad.Equals(nd.Array(1, 1, 1, 2, 2, 2).Reshape(2, 3)) {
+		t.Error("Expected [[1, 1, 1], [2, 2, 2]], got ", a.Grad)
+	}
+
+	worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+		return xs[0].Div(xs[1]).Sub(xs[2]).Pow(2).Mean()
+	}, nd.Array(1, 2, 3, 4, 5, 6).Reshape(2, 3), nd.Array(1.5, -2, 0.5), nd.Array(0.3, -0.7).Reshape(2, 1))
+	if worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+}
+
+func TestBroadcastShapes(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for shapes that do not broadcast")
+		}
+	}()
+	tape := NewTape()
+	tape.Variable(nd.Zeros(2, 3)).Add(tape.Variable(nd.Zeros(2)))
+}
+
+func TestDot(t *testing.T) {
+	tape := NewTape()
+	a := tape.Variable(nd.Array(1, 2, 3, 4, 5, 6).Reshape(2, 3))
+	x := tape.Variable(nd.Array(1, 0, -1))
+	y := a.Dot(x)
+	if !y.Value.Equals(nd.Array(-2, -2)) {
+		t.Error("Expected [-2, -2], got ", y.Value)
+	}
+	y.Sum().Backward()
+	if !x.Grad.Equals(nd.Array(5, 7, 9)) {
+		t.Error("Expected [5, 7, 9], got ", x.Grad)
+	}
+	if !a.Grad.Equals(nd.Array(1, 0, -1, 1, 0, -1).Reshape(2, 3)) {
+		t.Error("Expected [[1, 0, -1], [1, 0, -1]], got ", a.Grad)
+	}
+
+	shapes := [][2][]int{{{2, 3}, {3, 4}}, {{3}, {3, 2}}, {{3}, {3}}}
+	for _, s := range shapes {
+		worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+			return xs[0].Dot(xs[1]).Tanh().Sum()
+		}, sample(s[0]...), sample(s[1]...))
+		if worst > 1e-6 {
+			t.Error("Expected gradients to agree for ", s, ", got ", worst)
+		}
+	}
+}
+
+func TestUnary(t *testing.T) {
+	ops := map[string]func(a *Variable) *Variable{
+		"neg":     (*Variable).Neg,
+		"exp":     (*Variable).Exp,
+		"log":     (*Variable).Log,
+		"tanh":    (*Variable).Tanh,
+		"sigmoid": (*Variable).Sigmoid,
+		"relu":    (*Variable).ReLU,
+		"pow":     func(a *Variable) *Variable { return a.Pow(2.5) },
+	}
+	x := nd.Array(0.3, 1.7, 2.2, 0.9)
+	for name, op := range ops {
+		worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+			return op(xs[0]).Mul(xs[0]).Sum()
+		}, x)
+		if worst > 1e-6 {
+			t.Error("Expected gradients of ", name, " to agree, got ", worst)
+		}
+	}
+
+	tape := NewTape()
+	r := tape.Variable(nd.Array(-1, 2)).ReLU()
+	if !r.Value.Equals(nd.Array(0, 2)) {
+		t.Error("Expected [0, 2], got ", r.Value)
+	}
+	s := tape.Variable(nd.Array(-800, 0, 800)).Sigmoid()
+	if !s.Value.Equals(nd.Array(0, 0.5, 1)) {
+		t.Error("Expected [0, 0.5, 1], got ", s.Value)
+	}
+}
+
+//array of shape with distinct values in (-1, 1).
+func sample(shape ...int) *nd.NdArray {
+	a := nd.Zeros(shape...)
+	for i := range a.Values() {
+		a.Values()[i] = float64((i*7)%11)/6 - 0.9
+	}
+	return a
+}
@@ -0,0 +1,171 @@
+package autograd
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Sum over axes, or over all elements when none is given. The axes are removed from the shape,
+//an empty shape becomes [1].
+func (a *Variable) Sum(axes ...int) *Variable {
+	shape, group := reduction(a.Shape(), axes)
+	value := nd.Zeros(shape...)
+	vs := value.Values()
+	for i, x := range a.Value.Values() {
+		vs[group[i]] += x
+	}
+
+	return record(value, func(grad []float64) {
+		for i, k := range group {
+			a.grad[i] += grad[k]
+		}
+	}, a)
+}
+
+//Mean over axes, or over all elements when none is given, with the shape of Sum.
+func (a *Variable) Mean(axes ...int) *Variable {
+	shape, _ := reduction(a.Shape(), axes)
+	count := float64(a.Value.Size() / util.ProductOfIntSlice(shape))
+
+	return a.Sum(axes...).Map(func(x float64) float64 { return x / count },
+		func(x, y float64) float64 { return 1 / count })
+}
+
+//Maximum over axes, or over all elements when none is given, with the shape of Sum.
+//The gradient goes to the first maximal element of each group.
+func (a *Variable) Max(axes ...int) *Variable {
+	shape, group := reduction(a.Shape(), axes)
+	value := nd.Zeros(shape...)
+	vs := value.Values()
+	best := make([]int, len(vs))
+	for k := range best {
+		best[k] = -1
+	}
+	xs := a.Value.Values()
+	for i, x := range xs {
+		k := group[i]
+		if best[k] < 0 || x > xs[best[k]] {
+			best[k] = i
+		}
+	}
+	for k, i := range best {
+		vs[k] = xs[i]
+	}
+
+	return record(value, func(grad []float64) {
+		for k, i := range best {
+			a.grad[i] += grad[k]
+		}
+	}, a)
+}
+
+//Log of the sum of the exponentials over axes, or over all elements when none is given, with the shape
+//of Sum. The maximum of each group is subtracted before the exponentials, so that they cannot overflow.
+func (a *Variable) LogSumExp(axes ...int) *Variable {
+	shape, group := reduction(a.Shape(), axes)
+	xs := a.Value.Values()
+	peak := make([]float64, util.ProductOfIntSlice(shape))
+	for k := range peak {
+		peak[k] = math.Inf(-1)
+	}
+	for i, x := range xs {
+		peak[group[i]] = math.Max(peak[group[i]], x)
+	}
+	sums := make([]float64, len(peak))
+	for i, x := range xs {
+		sums[group[i]] += math.Exp(x - peak[group[i]])
+	}
+	value := nd.Zeros(shape...)
+	vs := value.Values()
+	for k := range vs {
+		vs[k] = peak[k] + math.Log(sums[k])
+	}
+
+	//the gradient is the softmax of each group
+	return record(value, func(grad []float64) {
+		for i, x := range xs {
+			k := group[i]
+			a.grad[i] += grad[k] * math.Exp(x-vs[k])
+		}
+	}, a)
+}
+
+//The same elements in shape, which has the size of a.
+func (a *Variable) Reshape(shape ...int) *Variable {
+	value := a.Value.Clone().Reshape(shape...)
+	return record(value, func(grad []float64) {
+		for i, g := range grad {
+			a.grad[i] += g
+		}
+	}, a)
+}
+
+//Permute the dimensions like nd.Transpose, the ith axis of the result is axes[i] of a.
+//With no axes given, the order of the dimensions is reversed.
+func (a *Variable) Transpose(axes ...int) *Variable {
+	n := a.Value.NDims()
+	if len(axes) == 0 {
+		axes = make([]int, n)
+		for i := range axes {
+			axes[i] = n - 1 - i
+		}
+	}
+	value := a.Value.Transpose(axes...)
+	inverse := make([]int, n)
+	for i, ax := range axes {
+		inverse[ax] = i
+	}
+	shape := value.Shape()
+
+	return record(value, func(grad []float64) {
+		back := nd.Array(grad...).Reshape(shape...).Transpose(inverse...)
+		for i, g := range back.Values() {
+			a.grad[i] += g
+		}
+	}, a)
+}
+
+//The shape after removing axes from shape, all of them when axes is empty, and for each element
+//the position of the result it goes to.
+func reduction(shape []int, axes []int) ([]int, []int) {
+	removed := make([]bool, len(shape))
+	for _, ax := range axes {
+		if ax < 0 || ax >= len(shape) || removed[ax] {
+			panic(fmt.Errorf("axes: %v invalid for shape %v", axes, shape))
+		}
+		removed[ax] = true
+	}
+	var rest []int
+	for d, s := range shape {
+		if len(axes) > 0 && !removed[d] {
+			rest = append(rest, s)
+		}
+	}
+
+	group := make([]int, util.ProductOfIntSlice(shape))
+	pos := make([]int, len(shape))
+	for i := range group {
+		k := 0
+		for d, p := range pos {
+			if len(axes) > 0 && !removed[d] {
+				k = k*shape[d] + p
+			}
+		}
+		group[i] = k
+		for d := len(shape) - 1; d >= 0; d-- {
+			pos[d]++
+			if pos[d] < shape[d] {
+				break
+			}
+			pos[d] = 0
+		}
+	}
+	if len(rest) == 0 {
+		rest = []int{1}
+	}
+
+	return rest, group
+}
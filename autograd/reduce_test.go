@@ -0,0 +1,82 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestSum(t *testing.T) {
+	tape := NewTape()
+	a := tape.Variable(nd.Arange(6).Reshape(2, 3))
+	rows := a.Sum(1)
+	if !rows.Value.Equals(nd.Array(3, 12)) {
+		t.Error("Expected [3, 12], got ", rows.Value)
+	}
+	cols := a.Sum(0)
+	if !cols.Value.Equals(nd.Array(3, 5, 7)) {
+		t.Error("Expected [3, 5, 7], got ", cols.Value)
+	}
+	all := a.Sum(0, 1)
+	if !util.EqualOfIntSlice(all.Shape(), []int{1}) || all.Value.Values()[0] != 15 {
+		t.Error("Expected [15], got ", all.Value)
+	}
+
+	rows.Mul(tape.Constant(nd.Array(1, 2))).Sum().Backward()
+	if !a.Grad.Equals(nd.Array(1, 1, 1, 2, 2, 2).Reshape(2, 3)) {
+		t.Error("Expected [[1, 1, 1], [2, 2, 2]], got ", a.Grad)
+	}
+}
+
+func TestReductions(t *testing.T) {
+	x := sample(2, 3, 4)
+	cases := map[string]func(a *Variable) *Variable{
+		"sum":       func(a *Variable) *Variable { return a.Sum(0, 2) },
+		"mean":      func(a *Variable) *Variable { return a.Mean(1) },
+		"max":       func(a *Variable) *Variable { return a.Max(2) },
+		"logsumexp": func(a *Variable) *Variable { return a.LogSumExp(1, 2) },
+		"reshape":   func(a *Variable) *Variable { return a.Reshape(6, 4) },
+		"transpose": func(a *Variable) *Variable { return a.Transpose(1, 2, 0) },
+		"reverse":   func(a *Variable) *Variable { return a.Transpose() },
+	}
+	for name, op := range cases {
+		worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+			y := op(xs[0])
+			return y.Mul(t.Constant(sample(y.Shape()...).Map(math.Exp))).Sum()
+		}, x)
+		if worst > 1e-6 {
+			t.Error("Expected gradients of ", name, " to agree, got ", worst)
+		}
+	}
+}
+
+func TestLogSumExp(t *testing.T) {
+	tape := NewTape()
+	a := tape.Variable(nd.Array(1000, 1000, -1000, 0).Reshape(2, 2))
+	y := a.LogSumExp(1)
+	want := []float64{1000 + math.Log(2), math.Log(1 + math.Exp(-1000))}
+	for i, v := range y.Value.Values() {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Error("Expected ", want[i], ", got ", v)
+		}
+	}
+	y.Sum().Backward()
+	if !a.Grad.Equals(nd.Array(0.5, 0.5, 0, 1).Reshape(2, 2)) {
+		t.Error("Expected the softmax [[0.5, 0.5], [0, 1]], got ", a.Grad)
+	}
+}
+
+func TestTranspose(t *testing.T) {
+	tape := NewTape()
+	a := tape.Variable(nd.Arange(6).Reshape(2, 3))
+	b := a.Transpose()
+	if !b.Value.Equals(nd.Array(0, 3, 1, 4, 2, 5).Reshape(3, 2)) {
+		t.Error("Expected [[0, 3], [1, 4], [2, 5]], got ", b.Value)
+	}
+	b.Mul(tape.Constant(nd.Arange(6).Reshape(3, 2))).Sum().Backward()
+	if !a.Grad.Equals(nd.Array(0, 2, 4, 1, 3, 5).Reshape(2, 3)) {
+		t.Error("Expected [[0, 2, 4], [1, 3, 5]], got ", a.Grad)
+	}
+}
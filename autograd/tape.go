@@ -0,0 +1,141 @@
+//Package autograd computes gradients of functions of *nd.NdArray by reverse mode automatic differentiation.
+//The operations on Variables are recorded on a Tape, and Backward walks the tape in reverse order, applying
+//the chain rule. Binary operations broadcast like numpy, and the gradients of broadcast operands are summed
+//back to their shapes.
+package autograd
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Record of the operations of a computation, in the order they ran.
+type Tape struct {
+	nodes []*Variable
+}
+
+//A value in a computation recorded on a tape.
+type Variable struct {
+	Value *nd.NdArray
+	//gradient of the output of the last Backward with respect to Value, nil for constants
+	Grad *nd.NdArray
+
+	tape     *Tape
+	grad     []float64
+	requires bool
+	//adds the contributions of grad, the gradient of this variable, to those of the inputs
+	backward func(grad []float64)
+}
+
+//An empty tape.
+func NewTape() *Tape {
+	return &Tape{}
+}
+
+//Input of the computation whose gradient is wanted. The variable holds value, which must not change
+//until Backward has run.
+func (t *Tape) Variable(value *nd.NdArray) *Variable {
+	v := &Variable{Value: value, tape: t, requires: true}
+	t.nodes = append(t.nodes, v)
+
+	return v
+}
+
+//Input of the computation without gradient.
+func (t *Tape) Constant(value *nd.NdArray) *Variable {
+	v := &Variable{Value: value, tape: t}
+	t.nodes = append(t.nodes, v)
+
+	return v
+}
+
+//The tape v is recorded on, to create constants for operations with v.
+func (v *Variable) Tape() *Tape {
+	return v.tape
+}
+
+//Shape of Value.
+func (v *Variable) Shape() []int {
+	return v.Value.Shape()
+}
+
+//Record an operation with the result value, computed from inputs on their tape. backward maps the gradient
+//with respect to value to the gradients with respect to each input, nil for no contribution. New operations,
+//like the layers of package nn, are defined this way.
+func Apply(value *nd.NdArray, backward func(grad *nd.NdArray) []*nd.NdArray, inputs ...*Variable) *Variable {
+	shape := value.Shape()
+	return record(value, func(grad []float64) {
+		grads := backward(nd.Array(grad...).Reshape(shape...))
+		if len(grads) != len(inputs) {
+			panic(fmt.Errorf("gradients: %v for %v inputs", len(grads), len(inputs)))
+		}
+		for i, in := range inputs {
+			if grads[i] == nil || !in.requires {
+				continue
+			}
+			if !util.EqualOfIntSlice(grads[i].Shape(), in.Shape()) {
+				panic(fmt.Errorf("gradient shape: %v for input shape %v", grads[i].Shape(), in.Shape()))
+			}
+			for k, g := range grads[i].Values() {
+				in.grad[k] += g
+			}
+		}
+	}, inputs...)
+}
+
+//the variable of value from inputs, with the backward step of the operation.
+func record(value *nd.NdArray, backward func(grad []float64), inputs ...*Variable) *Variable {
+	if len(inputs) == 0 {
+		panic("an operation needs inputs")
+	}
+	t := inputs[0].tape
+	v := &Variable{Value: value, tape: t, backward: backward}
+	for _, in := range inputs {
+		if in.tape != t {
+			panic("inputs on different tapes")
+		}
+		v.requires = v.requires || in.requires
+	}
+	t.nodes = append(t.nodes, v)
+
+	return v
+}
+
+//Compute the gradients of v, which must have a single element, with respect to every variable recorded
+//before it on its tape that it depends on, into their Grad. Gradients of earlier calls are replaced.
+func (v *Variable) Backward() {
+	if v.Value.Size() != 1 {
+		panic(fmt.Errorf("shape: %v, backward needs a single element", v.Shape()))
+	}
+
+	t := v.tape
+	last := -1
+	for i, node := range t.nodes {
+		node.Grad = nil
+		node.grad = nil
+		if node.requires {
+			node.grad = make([]float64, node.Value.Size())
+		}
+		if node == v {
+			last = i
+		}
+	}
+	if !v.requires {
+		return
+	}
+	v.grad[0] = 1
+
+	for i := last; i >= 0; i-- {
+		node := t.nodes[i]
+		if node.requires && node.backward != nil {
+			node.backward(node.grad)
+		}
+	}
+	for _, node := range t.nodes[:last+1] {
+		if node.requires {
+			node.Grad = nd.Array(node.grad...).Reshape(node.Shape()...)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package autograd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestBackward(t *testing.T) {
+	tape := NewTape()
+	x := tape.Variable(nd.Array(1, 2, 3))
+	c := tape.Constant(nd.Array(2, 2, 2))
+	//sum of c*x*x, the gradient is 2*c*x
+	y := c.Mul(x).Mul(x).Sum()
+	if y.Value.Values()[0] != 28 {
+		t.Error("Expected 28, got ", y.Value)
+	}
+	y.Backward()
+	if !x.Grad.Equals(nd.Array(4, 8, 12)) {
+		t.Error("Expected [4, 8, 12], got ", x.Grad)
+	}
+	if c.Grad != nil {
+		t.Error("Expected no gradient of a constant, got ", c.Grad)
+	}
+
+	//a variable used twice adds both contributions, and a second Backward replaces the gradients
+	z := x.Add(x).Sum()
+	z.Backward()
+	if !x.Grad.Equals(nd.Array(2, 2, 2)) {
+		t.Error("Expected [2, 2, 2], got ", x.Grad)
+	}
+}
+
+func TestBackwardShape(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an output with several elements")
+		}
+	}()
+	tape := NewTape()
+	tape.Variable(nd.Array(1, 2)).Exp().Backward()
+}
+
+func TestApply(t *testing.T) {
+	//cube as a new operation
+	cube := func(x *Variable) *Variable {
+		value := x.Value.Map(func(v float64) float64 { return v * v * v })
+		return Apply(value, func(grad *nd.NdArray) []*nd.NdArray {
+			d := x.Value.Map(func(v float64) float64 { return 3 * v * v })
+			for i, g := range grad.Values() {
+				d.Values()[i] *= g
+			}
+			return []*nd.NdArray{d}
+		}, x)
+	}
+	tape := NewTape()
+	x := tape.Variable(nd.Array(1, -2).Reshape(2, 1))
+	cube(x).Sum().Backward()
+	if !x.Grad.Equals(nd.Array(3, 12).Reshape(2, 1)) {
+		t.Error("Expected [[3], [12]], got ", x.Grad)
+	}
+
+	worst := GradCheck(func(t *Tape, xs ...*Variable) *Variable {
+		return cube(xs[0]).Mul(xs[1]).Sum()
+	}, nd.Array(0.5, 1.5, -1), nd.Array(2, 3, 4))
+	if worst > 1e-6 || math.IsNaN(worst) {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+}
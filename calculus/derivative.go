@@ -0,0 +1,82 @@
+package calculus
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Jacobian of f at x by central differences, of shape f(x).Shape() followed by x.Shape():
+//element [i..., j...] is the derivative of f(x)[i...] with respect to x[j...].
+//The step for each element of x is the cube root of the machine epsilon, relative when |x| > 1,
+//which balances the truncation and the rounding errors, leaving about 2/3 of the digits.
+func Jacobian(f func(*nd.NdArray) *nd.NdArray, x *nd.NdArray) *nd.NdArray {
+	y := f(x)
+	n, m := x.Size(), y.Size()
+	r := nd.Zeros(append(append([]int(nil), y.Shape()...), x.Shape()...)...)
+	rs := r.Values()
+
+	probe := x.Clone()
+	ps := probe.Values()
+	for j, v := range x.Values() {
+		h := step(v, 1.0/3)
+		ps[j] = v + h
+		plus := f(probe).Values()
+		ps[j] = v - h
+		minus := f(probe).Values()
+		ps[j] = v
+		if len(plus) != m || len(minus) != m {
+			panic("shape error")
+		}
+		for i := 0; i < m; i++ {
+			rs[i*n+j] = (plus[i] - minus[i]) / (2 * h)
+		}
+	}
+
+	return r
+}
+
+//Hessian of the scalar function f at x by central differences, of shape x.Shape() followed by x.Shape().
+//f returns an array of one element. The steps are the fourth root of the machine epsilon, relative when
+//|x| > 1, leaving about half of the digits.
+func Hessian(f func(*nd.NdArray) *nd.NdArray, x *nd.NdArray) *nd.NdArray {
+	n := x.Size()
+	r := nd.Zeros(append(append([]int(nil), x.Shape()...), x.Shape()...)...)
+	rs := r.Values()
+
+	probe := x.Clone()
+	ps := probe.Values()
+	at := func(i int, di float64, j int, dj float64) float64 {
+		ps[i] += di
+		ps[j] += dj
+		y := f(probe)
+		if y.Size() != 1 {
+			panic("shape error")
+		}
+		v := y.Values()[0]
+		copy(ps, x.Values())
+		return v
+	}
+
+	h := make([]float64, n)
+	for i, v := range x.Values() {
+		h[i] = step(v, 0.25)
+	}
+	//f(x + hi + hj) - f(x + hi - hj) - f(x - hi + hj) + f(x - hi - hj) ~ 4 hi hj d2f / dxi dxj,
+	//symmetric, so each pair is evaluated once
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			d := at(i, h[i], j, h[j]) - at(i, h[i], j, -h[j]) - at(i, -h[i], j, h[j]) + at(i, -h[i], j, -h[j])
+			d /= 4 * h[i] * h[j]
+			rs[i*n+j], rs[j*n+i] = d, d
+		}
+	}
+
+	return r
+}
+
+//eps^power times max(1, |x|), rounded so that x + h - x is exactly h.
+func step(x, power float64) float64 {
+	h := math.Pow(math.Nextafter(1, 2)-1, power) * math.Max(1, math.Abs(x))
+	return (x + h) - x
+}
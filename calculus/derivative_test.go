@@ -0,0 +1,52 @@
+package calculus
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestJacobian(t *testing.T) {
+	//f(x, y) = [x^2 y, 5x + sin y, x y]
+	f := func(v *nd.NdArray) *nd.NdArray {
+		x, y := v.Get(0), v.Get(1)
+		return nd.Array(x*x*y, 5*x+math.Sin(y), x*y)
+	}
+	j := Jacobian(f, nd.Array(1, 2))
+	want := nd.Array(4, 1, 5, math.Cos(2), 2, 1).Reshape(3, 2)
+	if !j.Equals(want) {
+		t.Error("Expected ", want, ", got ", j)
+	}
+
+	//the shapes of f(x) and x are kept
+	square := func(v *nd.NdArray) *nd.NdArray { return v.Map(func(e float64) float64 { return e * e }) }
+	j = Jacobian(square, nd.Array(1, 2, 3, 4).Reshape(2, 2))
+	if !util.EqualOfIntSlice(j.Shape(), []int{2, 2, 2, 2}) || math.Abs(j.Get(1, 0, 1, 0)-6) > 1e-6 || j.Get(0, 1, 1, 0) != 0 {
+		t.Error("Expected the diagonal 2x of shape [2, 2, 2, 2], got ", j)
+	}
+}
+
+func TestHessian(t *testing.T) {
+	//f(x, y) = x^3 + 2 x y^2 - y
+	f := func(v *nd.NdArray) *nd.NdArray {
+		x, y := v.Get(0), v.Get(1)
+		return nd.Array(x*x*x + 2*x*y*y - y)
+	}
+	h := Hessian(f, nd.Array(1, -2))
+	want := nd.Array(6, -8, -8, 4).Reshape(2, 2)
+	if !h.Equals(want) {
+		t.Error("Expected ", want, ", got ", h)
+	}
+
+	rosen := func(v *nd.NdArray) *nd.NdArray {
+		x, y := v.Get(0), v.Get(1)
+		return nd.Array(100*(y-x*x)*(y-x*x) + (1-x)*(1-x))
+	}
+	h = Hessian(rosen, nd.Array(1, 1))
+	want = nd.Array(802, -400, -400, 200).Reshape(2, 2)
+	if !h.Map(func(v float64) float64 { return v / 1000 }).Equals(want.Map(func(v float64) float64 { return v / 1000 })) {
+		t.Error("Expected ", want, ", got ", h)
+	}
+}
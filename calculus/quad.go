@@ -0,0 +1,179 @@
+package calculus
+
+import (
+	"fmt"
+	"math"
+)
+
+//Tolerances of Quad and Romberg: the absolute or the relative error estimate must fall below them.
+const (
+	absTol = 1.49e-8
+	relTol = 1.49e-8
+)
+
+//Most subintervals Quad splits the range into, and most halvings of the step in Romberg.
+const (
+	quadLimit    = 200
+	rombergLimit = 20
+)
+
+//nodes of the 15 point Kronrod rule on [-1, 1], the non negative half, the odd ones are also those
+//of the 7 point Gauss rule.
+var kronrodNodes = [8]float64{
+	0.991455371120812639206854697526329,
+	0.949107912342758524526189684047851,
+	0.864864423359769072789712788640926,
+	0.741531185599394439863864773280788,
+	0.586087235467691130294144845693013,
+	0.405845151377397166906606412076961,
+	0.207784955007898467600689403773245,
+	0,
+}
+
+var kronrodWeights = [8]float64{
+	0.022935322010529224963732008058970,
+	0.063092092629978553290700663189204,
+	0.104790010322250183839876322541518,
+	0.140653259715525918745189590510238,
+	0.169004726639267902826583426598550,
+	0.190350578064785409913256402421014,
+	0.204432940075298892414161999234649,
+	0.209482141084727828012999174891714,
+}
+
+//weights of the 7 point Gauss rule at kronrodNodes[1], [3], [5] and [7].
+var gaussWeights = [4]float64{
+	0.129484966168869693270611432679082,
+	0.279705391489276667901467771423780,
+	0.381830050505118944950369775488975,
+	0.417959183673469387755102040816327,
+}
+
+//Integral of f from a to b by adaptive Gauss-Kronrod quadrature, like scipy.integrate.quad, and an
+//estimate of its absolute error. The subinterval with the largest error is halved until the total error
+//is below 1.49e-8 absolute or relative, or there are 200 subintervals, check the error estimate then.
+//The limits may be infinite, the range is then mapped onto a finite one. f is not evaluated at the limits,
+//so integrable singularities there are allowed.
+func Quad(f func(float64) float64, a, b float64) (float64, float64) {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		panic(fmt.Errorf("limits: %v and %v must not be NaN", a, b))
+	}
+	switch {
+	case a == b:
+		return 0, 0
+	case a > b:
+		v, e := Quad(f, b, a)
+		return -v, e
+	case math.IsInf(a, -1) && math.IsInf(b, 1):
+		//x = t / (1 - t^2)
+		return adaptive(func(t float64) float64 {
+			u := 1 - t*t
+			return f(t/u) * (1 + t*t) / (u * u)
+		}, -1, 1)
+	case math.IsInf(b, 1):
+		//x = a + t / (1 - t)
+		return adaptive(func(t float64) float64 {
+			u := 1 - t
+			return f(a+t/u) / (u * u)
+		}, 0, 1)
+	case math.IsInf(a, -1):
+		//x = b - (1 - t) / t
+		return adaptive(func(t float64) float64 {
+			return f(b-(1-t)/t) / (t * t)
+		}, 0, 1)
+	}
+
+	return adaptive(f, a, b)
+}
+
+type subinterval struct {
+	a, b, value, err float64
+}
+
+func adaptive(f func(float64) float64, a, b float64) (float64, float64) {
+	parts := []subinterval{kronrod(f, a, b)}
+	value, err := parts[0].value, parts[0].err
+	for len(parts) < quadLimit && err > math.Max(absTol, relTol*math.Abs(value)) {
+		worst := 0
+		for i, p := range parts {
+			if p.err > parts[worst].err {
+				worst = i
+			}
+		}
+		p := parts[worst]
+		mid := (p.a + p.b) / 2
+		if mid <= p.a || mid >= p.b {
+			//no room left to split
+			break
+		}
+		left, right := kronrod(f, p.a, mid), kronrod(f, mid, p.b)
+		parts[worst] = left
+		parts = append(parts, right)
+
+		value, err = 0, 0
+		for _, q := range parts {
+			value += q.value
+			err += q.err
+		}
+	}
+
+	return value, err
+}
+
+//the 15 point Kronrod estimate of the integral over [a, b], with its difference to the 7 point Gauss
+//estimate as error.
+func kronrod(f func(float64) float64, a, b float64) subinterval {
+	center, half := (a+b)/2, (b-a)/2
+	fc := f(center)
+	k, g := kronrodWeights[7]*fc, gaussWeights[3]*fc
+	for i := 0; i < 7; i++ {
+		dx := half * kronrodNodes[i]
+		pair := f(center-dx) + f(center+dx)
+		k += kronrodWeights[i] * pair
+		if i%2 == 1 {
+			g += gaussWeights[i/2] * pair
+		}
+	}
+
+	return subinterval{a: a, b: b, value: k * half, err: math.Abs((k - g) * half)}
+}
+
+//Integral of f from a to b by Romberg's method, like scipy.integrate.romberg, and an estimate of its
+//absolute error. The trapezoidal rule with the step halved up to 20 times is extrapolated to step 0,
+//until two successive extrapolations differ by less than 1.49e-8 absolute or relative. f must be smooth,
+//and the limits finite.
+func Romberg(f func(float64) float64, a, b float64) (float64, float64) {
+	if math.IsInf(a, 0) || math.IsInf(b, 0) || math.IsNaN(a) || math.IsNaN(b) {
+		panic(fmt.Errorf("limits: %v and %v must be finite", a, b))
+	}
+	if a == b {
+		return 0, 0
+	}
+
+	h := b - a
+	prev := []float64{h * (f(a) + f(b)) / 2}
+	err := math.Inf(1)
+	for k := 1; k <= rombergLimit; k++ {
+		//the trapezoidal rule with 2^k intervals reuses the points of the previous one
+		h /= 2
+		sum := 0.0
+		for i := 1; i < 1<<uint(k); i += 2 {
+			sum += f(a + float64(i)*h)
+		}
+		row := make([]float64, k+1)
+		row[0] = prev[0]/2 + h*sum
+		//Richardson extrapolation, each column removes the next even power of h from the error
+		p := 1.0
+		for j := 1; j <= k; j++ {
+			p *= 4
+			row[j] = row[j-1] + (row[j-1]-prev[j-1])/(p-1)
+		}
+		err = math.Abs(row[k] - prev[k-1])
+		if k > 2 && err <= math.Max(absTol, relTol*math.Abs(row[k])) {
+			return row[k], err
+		}
+		prev = row
+	}
+
+	return prev[len(prev)-1], err
+}
@@ -0,0 +1,47 @@
+package calculus
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuad(t *testing.T) {
+	cases := []struct {
+		f    func(float64) float64
+		a, b float64
+		want float64
+	}{
+		{math.Sin, 0, math.Pi, 2},
+		{math.Exp, 1, 0, 1 - math.E},
+		//singular at 0
+		{func(x float64) float64 { return 1 / math.Sqrt(x) }, 0, 1, 2},
+		{func(x float64) float64 { return math.Exp(-x * x) }, math.Inf(-1), math.Inf(1), math.Sqrt(math.Pi)},
+		{func(x float64) float64 { return 1 / (1 + x*x) }, 0, math.Inf(1), math.Pi / 2},
+		{math.Exp, math.Inf(-1), 0, 1},
+		{math.Cos, 2, 2, 0},
+	}
+	for _, c := range cases {
+		v, e := Quad(c.f, c.a, c.b)
+		if math.Abs(v-c.want) > 1e-7 {
+			t.Error("Expected ", c.want, ", got ", v)
+		}
+		if e > 1e-7 {
+			t.Error("Expected a small error estimate, got ", e)
+		}
+	}
+}
+
+func TestRomberg(t *testing.T) {
+	v, _ := Romberg(math.Sin, 0, math.Pi)
+	if math.Abs(v-2) > 1e-8 {
+		t.Error("Expected 2, got ", v)
+	}
+	v, _ = Romberg(func(x float64) float64 { return 4 / (1 + x*x) }, 0, 1)
+	if math.Abs(v-math.Pi) > 1e-8 {
+		t.Error("Expected pi, got ", v)
+	}
+	v, _ = Romberg(math.Exp, 1, 0)
+	if math.Abs(v-(1-math.E)) > 1e-8 {
+		t.Error("Expected 1 - e, got ", v)
+	}
+}
@@ -0,0 +1,131 @@
+//Package calculus integrates and differentiates numerically: sampled curves held in *nd.NdArray
+//by the trapezoidal and Simpson's rules, functions by adaptive Gauss-Kronrod quadrature and Romberg's
+//method, and the Jacobian and Hessian of functions of arrays by finite differences.
+package calculus
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Integral of y along axis by the trapezoidal rule, like numpy.trapezoid. x holds the sample points,
+//a 1d array with one element per element of y along axis, nil for unit spacing.
+//The result has the shape of y without axis, [1] for 1d y.
+func Trapezoid(y, x *nd.NdArray, axis int) *nd.NdArray {
+	xs := samplePoints(y, x, axis)
+	return alongAxis(y, axis, 0, func(lane, out []float64) {
+		s := 0.0
+		for i := 1; i < len(lane); i++ {
+			s += (xs[i] - xs[i-1]) * (lane[i] + lane[i-1]) / 2
+		}
+		out[0] = s
+	})
+}
+
+//Same as Trapezoid, the older numpy name.
+func Trapz(y, x *nd.NdArray, axis int) *nd.NdArray {
+	return Trapezoid(y, x, axis)
+}
+
+//Integral of y along axis by Simpson's rule, like scipy.integrate.simpson, with x as in Trapezoid.
+//Each pair of intervals is integrated by the parabola through its three points, so that the rule
+//is exact for cubics on even spacing. With an odd number of intervals the last one is integrated
+//by the parabola through the last three points. Two points give the trapezoidal rule.
+func Simpson(y, x *nd.NdArray, axis int) *nd.NdArray {
+	xs := samplePoints(y, x, axis)
+	return alongAxis(y, axis, 0, func(lane, out []float64) {
+		n := len(lane)
+		if n == 2 {
+			out[0] = (xs[1] - xs[0]) * (lane[0] + lane[1]) / 2
+			return
+		}
+		s := 0.0
+		for i := 0; i+2 < n; i += 2 {
+			h0, h1 := xs[i+1]-xs[i], xs[i+2]-xs[i+1]
+			sum := h0 + h1
+			s += sum / 6 * ((2-h1/h0)*lane[i] + sum*sum/(h0*h1)*lane[i+1] + (2-h0/h1)*lane[i+2])
+		}
+		if n%2 == 0 {
+			h0, h1 := xs[n-2]-xs[n-3], xs[n-1]-xs[n-2]
+			alpha := (2*h1*h1 + 3*h0*h1) / (6 * (h0 + h1))
+			beta := (h1*h1 + 3*h0*h1) / (6 * h0)
+			eta := h1 * h1 * h1 / (6 * h0 * (h0 + h1))
+			s += alpha*lane[n-1] + beta*lane[n-2] - eta*lane[n-3]
+		}
+		out[0] = s
+	})
+}
+
+//Running integrals of y along axis by the trapezoidal rule, like scipy.integrate.cumulative_trapezoid,
+//with x as in Trapezoid. Element i along axis is the integral from the first sample to sample i + 1,
+//so the result has one element less along axis than y.
+func CumulativeTrapezoid(y, x *nd.NdArray, axis int) *nd.NdArray {
+	xs := samplePoints(y, x, axis)
+	return alongAxis(y, axis, len(xs)-1, func(lane, out []float64) {
+		s := 0.0
+		for i := 1; i < len(lane); i++ {
+			s += (xs[i] - xs[i-1]) * (lane[i] + lane[i-1]) / 2
+			out[i-1] = s
+		}
+	})
+}
+
+//the sample points along axis of y, 0, 1, ... when x is nil. At least two are needed.
+func samplePoints(y, x *nd.NdArray, axis int) []float64 {
+	shape := y.Shape()
+	if axis < 0 || axis >= len(shape) {
+		panic(fmt.Errorf("axis: %v out of %v dimensions", axis, len(shape)))
+	}
+	n := shape[axis]
+	if n < 2 {
+		panic(fmt.Errorf("samples: %v, at least 2 needed", n))
+	}
+	if x == nil {
+		return nd.Arange(n).Values()
+	}
+	if x.NDims() != 1 || x.Size() != n {
+		panic("shape error")
+	}
+
+	return x.Values()
+}
+
+//Apply f to every lane of a along axis, f writes m elements to out. The result has the shape of a
+//with m elements along axis, or without axis when m is 0, in which case f writes one element.
+func alongAxis(a *nd.NdArray, axis, m int, f func(lane, out []float64)) *nd.NdArray {
+	shape := a.Shape()
+	n := shape[axis]
+	outer := util.ProductOfIntSlice(shape[:axis])
+	inner := util.ProductOfIntSlice(shape[axis+1:])
+
+	resShape := append([]int(nil), shape...)
+	width := m
+	if m == 0 {
+		resShape = append(resShape[:axis], resShape[axis+1:]...)
+		width = 1
+	} else {
+		resShape[axis] = m
+	}
+	if len(resShape) == 0 {
+		resShape = []int{1}
+	}
+
+	r := nd.Zeros(resShape...)
+	rs, values := r.Values(), a.Values()
+	lane, out := make([]float64, n), make([]float64, width)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for j := range lane {
+				lane[j] = values[(o*n+j)*inner+i]
+			}
+			f(lane, out)
+			for j, v := range out {
+				rs[(o*width+j)*inner+i] = v
+			}
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,64 @@
+package calculus
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestTrapezoid(t *testing.T) {
+	y := nd.Array(1, 2, 4, 7)
+	if !Trapezoid(y, nil, 0).Equals(nd.Array(10)) {
+		t.Error("Expected [10], got ", Trapezoid(y, nil, 0))
+	}
+	x := nd.Array(0, 1, 3, 4)
+	if !Trapz(y, x, 0).Equals(nd.Array(13)) {
+		t.Error("Expected [13], got ", Trapz(y, x, 0))
+	}
+
+	a := nd.Array(0, 1, 2, 3, 4, 5).Reshape(2, 3)
+	if !Trapezoid(a, nil, 0).Equals(nd.Array(1.5, 2.5, 3.5)) {
+		t.Error("Expected [1.5, 2.5, 3.5], got ", Trapezoid(a, nil, 0))
+	}
+	if !Trapezoid(a, nd.Array(0, 2, 3), 1).Equals(nd.Array(2.5, 11.5)) {
+		t.Error("Expected [2.5, 11.5], got ", Trapezoid(a, nd.Array(0, 2, 3), 1))
+	}
+}
+
+func TestSimpson(t *testing.T) {
+	cubic := func(v float64) float64 { return v*v*v - 2*v + 1 }
+	//exact for cubics on even spacing with an even number of intervals: x^4 / 4 - x^2 + x on [0, 2] is 2
+	x := nd.Array(0, 0.5, 1, 1.5, 2)
+	if !Simpson(x.Map(cubic), x, 0).Equals(nd.Array(2)) {
+		t.Error("Expected [2], got ", Simpson(x.Map(cubic), x, 0))
+	}
+
+	//exact for parabolas on any spacing and number of intervals, x^2 on [0, 3] is 9
+	square := func(v float64) float64 { return v * v }
+	for _, x := range []*nd.NdArray{nd.Array(0, 0.4, 1.5, 3), nd.Array(0, 1, 1.2, 2, 3), nd.Array(0, 3)} {
+		want := 9.0
+		if x.Size() == 2 {
+			want = 13.5
+		}
+		if !Simpson(x.Map(square), x, 0).Equals(nd.Array(want)) {
+			t.Error("Expected ", want, ", got ", Simpson(x.Map(square), x, 0))
+		}
+	}
+
+	a := nd.Array(0, 1, 4, 0, 2, 8).Reshape(2, 3)
+	if !Simpson(a, nil, 1).Equals(nd.Array(8.0/3, 16.0/3)) {
+		t.Error("Expected [8/3, 16/3], got ", Simpson(a, nil, 1))
+	}
+}
+
+func TestCumulativeTrapezoid(t *testing.T) {
+	y := nd.Array(1, 2, 4, 7)
+	if !CumulativeTrapezoid(y, nil, 0).Equals(nd.Array(1.5, 4.5, 10)) {
+		t.Error("Expected [1.5, 4.5, 10], got ", CumulativeTrapezoid(y, nil, 0))
+	}
+	a := nd.Array(0, 1, 2, 3, 4, 5).Reshape(3, 2)
+	want := nd.Array(1, 2, 4, 6).Reshape(2, 2)
+	if !CumulativeTrapezoid(a, nil, 0).Equals(want) {
+		t.Error("Expected ", want, ", got ", CumulativeTrapezoid(a, nil, 0))
+	}
+}
@@ -0,0 +1,133 @@
+package interpolate
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//How RegularGridInterpolator combines the values at the grid points.
+type GridMethod int
+
+const (
+	//multilinear interpolation between the 2^d grid points around
+	Linear GridMethod = iota
+	//value at the nearest grid point, the lower one on ties
+	Nearest
+)
+
+//Interpolation on a rectilinear grid in d dimensions, like scipy.interpolate.RegularGridInterpolator.
+type RegularGridInterpolator struct {
+	points    [][]float64
+	values    []float64
+	strides   []int
+	method    GridMethod
+	fillValue float64
+}
+
+//points[i] holds the strictly increasing coordinates of the grid along dimension i, and values has
+//shape [len(points[0]), ..., len(points[d-1])]. Points outside the grid give fillValue, NaN for instance.
+func NewRegularGridInterpolator(points []*nd.NdArray, values *nd.NdArray, method GridMethod, fillValue float64) *RegularGridInterpolator {
+	if len(points) != values.NDims() {
+		panic("shape error")
+	}
+	least := 2
+	switch method {
+	case Linear:
+	case Nearest:
+		least = 1
+	default:
+		panic(fmt.Errorf("method: %v unknown", method))
+	}
+
+	g := &RegularGridInterpolator{
+		values:    append([]float64(nil), values.Values()...),
+		method:    method,
+		fillValue: fillValue,
+	}
+	for d, p := range points {
+		if p.NDims() != 1 || p.Size() != values.Shape()[d] {
+			panic("shape error")
+		}
+		g.points = append(g.points, checkKnots(p, p, least))
+	}
+	g.strides = make([]int, len(points))
+	s := 1
+	for d := len(points) - 1; d >= 0; d-- {
+		g.strides[d] = s
+		s *= values.Shape()[d]
+	}
+
+	return g
+}
+
+//Interpolated values at the points xi of shape [..., d], the result has shape [...],
+//or [1] for a single point of shape [d].
+func (g *RegularGridInterpolator) At(xi *nd.NdArray) *nd.NdArray {
+	dims := len(g.points)
+	shape := xi.Shape()
+	if shape[len(shape)-1] != dims {
+		panic(fmt.Errorf("points of shape: %v, %v coordinates expected", shape, dims))
+	}
+	shape = shape[:len(shape)-1]
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+
+	r := nd.Zeros(shape...)
+	rs, xs := r.Values(), xi.Values()
+	for k := range rs {
+		rs[k] = g.at(xs[k*dims : (k+1)*dims])
+	}
+
+	return r
+}
+
+func (g *RegularGridInterpolator) at(x []float64) float64 {
+	dims := len(g.points)
+	lo, t := make([]int, dims), make([]float64, dims)
+	for d, v := range x {
+		p := g.points[d]
+		if !(v >= p[0] && v <= p[len(p)-1]) {
+			return g.fillValue
+		}
+		if len(p) == 1 {
+			continue
+		}
+		lo[d] = interval(p, v)
+		t[d] = (v - p[lo[d]]) / (p[lo[d]+1] - p[lo[d]])
+	}
+
+	if g.method == Nearest {
+		idx := 0
+		for d := range x {
+			i := lo[d]
+			if t[d] > 0.5 {
+				i++
+			}
+			idx += i * g.strides[d]
+		}
+		return g.values[idx]
+	}
+
+	//the corner with bit d of c set is above along dimension d
+	v := 0.0
+	for c := 0; c < 1<<uint(dims); c++ {
+		w, idx := 1.0, 0
+		for d := 0; d < dims; d++ {
+			i := lo[d]
+			if c&(1<<uint(d)) != 0 {
+				w *= t[d]
+				i++
+			} else {
+				w *= 1 - t[d]
+			}
+			idx += i * g.strides[d]
+		}
+		if w != 0 {
+			v += w * g.values[idx]
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,55 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestRegularGridInterpolator(t *testing.T) {
+	xs, ys := nd.Array(0, 1, 3), nd.Array(0, 2)
+	//a bilinear function is reproduced
+	f := func(x, y float64) float64 { return 1 + 2*x - y + 0.5*x*y }
+	values := nd.Zeros(3, 2)
+	for i, x := range xs.Values() {
+		for j, y := range ys.Values() {
+			values.Set(f(x, y), i, j)
+		}
+	}
+
+	g := NewRegularGridInterpolator([]*nd.NdArray{xs, ys}, values, Linear, math.NaN())
+	pts := nd.Array(0.5, 1, 2, 0.5, 3, 2, 0, 0).Reshape(4, 2)
+	expected := nd.Array(f(0.5, 1), f(2, 0.5), f(3, 2), f(0, 0))
+	if !g.At(pts).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", g.At(pts))
+	}
+	if got := g.At(nd.Array(2, 1)); got.Size() != 1 || math.Abs(got.Get(0)-f(2, 1)) > 1e-12 {
+		t.Error("Expected [", f(2, 1), "], got ", got)
+	}
+	if !math.IsNaN(g.At(nd.Array(4, 1)).Get(0)) {
+		t.Error("Expected NaN outside the grid, got ", g.At(nd.Array(4, 1)))
+	}
+
+	near := NewRegularGridInterpolator([]*nd.NdArray{xs, ys}, values, Nearest, -1)
+	if !near.At(nd.Array(0.5, 1.2, 2.1, 0.9, 2, 1).Reshape(3, 2)).Equals(nd.Array(f(0, 2), f(3, 0), f(1, 0))) {
+		t.Error("Expected the nearest values, got ", near.At(nd.Array(0.5, 1.2, 2.1, 0.9, 2, 1).Reshape(3, 2)))
+	}
+	if near.At(nd.Array(-1, 0)).Get(0) != -1 {
+		t.Error("Expected the fill value -1, got ", near.At(nd.Array(-1, 0)))
+	}
+
+	//three dimensions, points given as [2, 2, 3]
+	cube := NewRegularGridInterpolator([]*nd.NdArray{nd.Array(0, 1), nd.Array(0, 1), nd.Array(0, 1)}, nd.Arange(8).Reshape(2, 2, 2), Linear, 0)
+	r := cube.At(nd.Ones(2, 2, 3).Map(func(float64) float64 { return 0.5 }))
+	if r.NDims() != 2 || !r.Equals(nd.Ones(2, 2).Map(func(float64) float64 { return 3.5 })) {
+		t.Error("Expected [[3.5, 3.5], [3.5, 3.5]], got ", r)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for points with the wrong number of coordinates")
+		}
+	}()
+	g.At(nd.Array(1, 2, 3))
+}
@@ -0,0 +1,68 @@
+//Package interpolate estimates values between samples: linear interpolation, piecewise cubics
+//(cubic splines and PCHIP) on 1d data, and interpolation on regular N-d grids.
+//The points to evaluate are given as *nd.NdArray of any shape.
+package interpolate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//One dimensional linear interpolation, like numpy.interp: every element of x is mapped onto the
+//broken line through the points (xp[i], fp[i]), xp increasing. Elements below xp[0] give left,
+//elements above the last xp give right, pass the first and last fp for the numpy defaults.
+func Interp(x, xp, fp *nd.NdArray, left, right float64) *nd.NdArray {
+	knots := checkKnots(xp, fp, 1)
+	fs := fp.Values()
+	n := len(knots)
+
+	return x.Map(func(v float64) float64 {
+		switch {
+		case math.IsNaN(v):
+			return v
+		case v < knots[0]:
+			return left
+		case v > knots[n-1]:
+			return right
+		case v == knots[n-1]:
+			return fs[n-1]
+		}
+		i := interval(knots, v)
+		t := (v - knots[i]) / (knots[i+1] - knots[i])
+		return fs[i] + t*(fs[i+1]-fs[i])
+	})
+}
+
+//the values of xp, which must be 1d, strictly increasing, as long as the 1d fp and hold at least count elements.
+func checkKnots(xp, fp *nd.NdArray, count int) []float64 {
+	if xp.NDims() != 1 || fp.NDims() != 1 || xp.Size() != fp.Size() {
+		panic("shape error")
+	}
+	if xp.Size() < count {
+		panic(fmt.Errorf("points: %v, at least %v needed", xp.Size(), count))
+	}
+	knots := xp.Values()
+	for i := 1; i < len(knots); i++ {
+		if !(knots[i] > knots[i-1]) {
+			panic(fmt.Errorf("points: %v must be strictly increasing", knots))
+		}
+	}
+
+	return knots
+}
+
+//index i of the interval [knots[i], knots[i+1]] holding v, the first or last for v outside the knots.
+func interval(knots []float64, v float64) int {
+	i := sort.SearchFloat64s(knots, v) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i > len(knots)-2 {
+		i = len(knots) - 2
+	}
+
+	return i
+}
@@ -0,0 +1,37 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestInterp(t *testing.T) {
+	xp, fp := nd.Array(1, 2, 3), nd.Array(3, 2, 0)
+
+	if !Interp(nd.Array(2.5), xp, fp, 3, 0).Equals(nd.Array(1)) {
+		t.Error("Expected [1], got ", Interp(nd.Array(2.5), xp, fp, 3, 0))
+	}
+	x := nd.Array(0, 1, 1.5, 2.72, 3.14, 3).Reshape(2, 3)
+	expected := nd.Array(3, 3, 2.5, 0.56, 0, 0).Reshape(2, 3)
+	if !Interp(x, xp, fp, 3, 0).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", Interp(x, xp, fp, 3, 0))
+	}
+	if !Interp(nd.Array(-1, 4), xp, fp, -99, 99).Equals(nd.Array(-99, 99)) {
+		t.Error("Expected [-99, 99], got ", Interp(nd.Array(-1, 4), xp, fp, -99, 99))
+	}
+	if !math.IsNaN(Interp(nd.Array(math.NaN()), xp, fp, 0, 0).Get(0)) {
+		t.Error("Expected NaN, got ", Interp(nd.Array(math.NaN()), xp, fp, 0, 0))
+	}
+	if !Interp(nd.Array(0, 5, 9), nd.Array(5), nd.Array(7), 1, 2).Equals(nd.Array(1, 7, 2)) {
+		t.Error("Expected [1, 7, 2], got ", Interp(nd.Array(0, 5, 9), nd.Array(5), nd.Array(7), 1, 2))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for decreasing points")
+		}
+	}()
+	Interp(x, nd.Array(3, 2, 1), fp, 0, 0)
+}
@@ -0,0 +1,178 @@
+package interpolate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The end conditions of a cubic spline.
+type SplineBoundary int
+
+const (
+	//the third derivative is continuous at the second and the next to last points
+	NotAKnot SplineBoundary = iota
+	//the second derivative is 0 at both ends
+	Natural
+	//the first derivative is 0 at both ends
+	Clamped
+)
+
+//Piecewise cubic function, C1 at least, given on each interval [x[i], x[i+1]] by its
+//values and slopes at the ends. Outside of x the first or last cubic is extended.
+type PiecewiseCubic struct {
+	x, y, slopes []float64
+}
+
+//Cubic spline through the points (x[i], y[i]), x strictly increasing: the C2 piecewise cubic
+//with the end conditions of bc, like scipy.interpolate.CubicSpline. With two points the spline
+//is the line through them, except when Clamped, and with three points NotAKnot gives the parabola
+//through them.
+func CubicSpline(x, y *nd.NdArray, bc SplineBoundary) *PiecewiseCubic {
+	xs := checkKnots(x, y, 2)
+	ys := y.Values()
+	n := len(xs)
+	h, m := differences(xs, ys)
+
+	//continuity of the second derivative at the inner points, a tridiagonal system for the slopes,
+	//row i is lower[i] s[i-1] + diag[i] s[i] + upper[i] s[i+1] = rhs[i]
+	lower, diag, upper, rhs := make([]float64, n), make([]float64, n), make([]float64, n), make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		lower[i] = h[i]
+		diag[i] = 2 * (h[i-1] + h[i])
+		upper[i] = h[i-1]
+		rhs[i] = 3 * (h[i]*m[i-1] + h[i-1]*m[i])
+	}
+
+	switch {
+	case bc == Clamped:
+		diag[0], diag[n-1] = 1, 1
+	case bc == Natural || (bc == NotAKnot && n == 2):
+		diag[0], upper[0], rhs[0] = 2, 1, 3*m[0]
+		lower[n-1], diag[n-1], rhs[n-1] = 1, 2, 3*m[n-2]
+	case bc == NotAKnot && n == 3:
+		//the parabola has the mean slope of each interval at its middle
+		diag[0], upper[0], rhs[0] = 1, 1, 2*m[0]
+		lower[2], diag[2], rhs[2] = 1, 1, 2*m[1]
+	case bc == NotAKnot:
+		d := h[0] + h[1]
+		diag[0], upper[0] = h[1], d
+		rhs[0] = ((h[0]+2*d)*h[1]*m[0] + h[0]*h[0]*m[1]) / d
+		d = h[n-3] + h[n-2]
+		lower[n-1], diag[n-1] = d, h[n-3]
+		rhs[n-1] = (h[n-2]*h[n-2]*m[n-3] + (2*d+h[n-2])*h[n-3]*m[n-2]) / d
+	default:
+		panic("unknown boundary")
+	}
+
+	slopes := nd.SolveTridiagonal(nd.Array(lower[1:]...), nd.Array(diag...), nd.Array(upper[:n-1]...), nd.Array(rhs...))
+
+	return newPiecewiseCubic(xs, ys, slopes.Values())
+}
+
+//Piecewise cubic Hermite interpolating polynomial through the points (x[i], y[i]), x strictly increasing,
+//like scipy.interpolate.PchipInterpolator. The slopes are set by the Fritsch-Carlson rules, so that the
+//result is monotonic where the data are and does not overshoot.
+func Pchip(x, y *nd.NdArray) *PiecewiseCubic {
+	xs := checkKnots(x, y, 2)
+	n := len(xs)
+	h, m := differences(xs, y.Values())
+
+	slopes := make([]float64, n)
+	if n == 2 {
+		slopes[0], slopes[1] = m[0], m[0]
+		return newPiecewiseCubic(xs, y.Values(), slopes)
+	}
+	for k := 1; k < n-1; k++ {
+		//weighted harmonic mean of the slopes around, 0 at a local extremum
+		if m[k-1]*m[k] > 0 {
+			w1, w2 := 2*h[k]+h[k-1], h[k]+2*h[k-1]
+			slopes[k] = (w1 + w2) / (w1/m[k-1] + w2/m[k])
+		}
+	}
+	slopes[0] = pchipEnd(h[0], h[1], m[0], m[1])
+	slopes[n-1] = pchipEnd(h[n-2], h[n-3], m[n-2], m[n-3])
+
+	return newPiecewiseCubic(xs, y.Values(), slopes)
+}
+
+//the points are copied, so that later changes to the arrays they come from do not matter.
+func newPiecewiseCubic(x, y, slopes []float64) *PiecewiseCubic {
+	return &PiecewiseCubic{x: append([]float64(nil), x...), y: append([]float64(nil), y...), slopes: slopes}
+}
+
+//slope at an end from the parabola through the three end points, kept from changing the monotonicity.
+func pchipEnd(h0, h1, m0, m1 float64) float64 {
+	d := ((2*h0+h1)*m0 - h0*m1) / (h0 + h1)
+	if sign(d) != sign(m0) {
+		return 0
+	}
+	if sign(m0) != sign(m1) && math.Abs(d) > 3*math.Abs(m0) {
+		return 3 * m0
+	}
+
+	return d
+}
+
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+
+	return 0
+}
+
+//Values at the elements of x.
+func (p *PiecewiseCubic) At(x *nd.NdArray) *nd.NdArray {
+	return p.Derivative(x, 0)
+}
+
+//Derivatives of order nu at the elements of x, 0 beyond the third.
+func (p *PiecewiseCubic) Derivative(x *nd.NdArray, nu int) *nd.NdArray {
+	if nu < 0 {
+		panic(fmt.Errorf("order: %v must not be negative", nu))
+	}
+	return x.Map(func(v float64) float64 {
+		if math.IsNaN(v) {
+			return v
+		}
+		i := interval(p.x, v)
+		c := p.coefficients(i)
+		t := v - p.x[i]
+		switch nu {
+		case 0:
+			return c[0] + t*(c[1]+t*(c[2]+t*c[3]))
+		case 1:
+			return c[1] + t*(2*c[2]+t*3*c[3])
+		case 2:
+			return 2*c[2] + 6*t*c[3]
+		case 3:
+			return 6 * c[3]
+		}
+		return 0
+	})
+}
+
+//coefficients of the cubic on interval i, in powers of t = x - x[i].
+func (p *PiecewiseCubic) coefficients(i int) [4]float64 {
+	h := p.x[i+1] - p.x[i]
+	m := (p.y[i+1] - p.y[i]) / h
+	d0, d1 := p.slopes[i], p.slopes[i+1]
+
+	return [4]float64{p.y[i], d0, (3*m - 2*d0 - d1) / h, (d0 + d1 - 2*m) / (h * h)}
+}
+
+//widths and slopes of the intervals.
+func differences(x, y []float64) ([]float64, []float64) {
+	h, m := make([]float64, len(x)-1), make([]float64, len(x)-1)
+	for i := range h {
+		h[i] = x[i+1] - x[i]
+		m[i] = (y[i+1] - y[i]) / h[i]
+	}
+
+	return h, m
+}
@@ -0,0 +1,76 @@
+package interpolate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestCubicSpline(t *testing.T) {
+	//cubics are reproduced by NotAKnot
+	x := nd.Array(0, 0.5, 1.5, 2, 3.5, 4)
+	cubic := func(v float64) float64 { return v*v*v - 2*v*v + 3 }
+	s := CubicSpline(x, x.Map(cubic), NotAKnot)
+	at := nd.Array(-0.5, 0.2, 1, 2.7, 4.5)
+	if !s.At(at).Equals(at.Map(cubic)) {
+		t.Error("Expected ", at.Map(cubic), ", got ", s.At(at))
+	}
+	if !s.Derivative(at, 1).Equals(at.Map(func(v float64) float64 { return 3*v*v - 4*v })) {
+		t.Error("Expected the derivative 3x^2 - 4x, got ", s.Derivative(at, 1))
+	}
+	if !s.Derivative(at, 3).Equals(nd.Ones(5).Map(func(float64) float64 { return 6 })) {
+		t.Error("Expected a third derivative of 6, got ", s.Derivative(at, 3))
+	}
+
+	//natural spline through 4 points, from the tridiagonal system for the second derivatives
+	nat := CubicSpline(nd.Array(0, 1, 2, 3), nd.Array(0, 1, 0, 1), Natural)
+	if !nat.At(nd.Array(0.5, 1.5)).Equals(nd.Array(0.75, 0.5)) {
+		t.Error("Expected [0.75, 0.5], got ", nat.At(nd.Array(0.5, 1.5)))
+	}
+	if !nat.Derivative(nd.Array(0, 3), 2).Equals(nd.Zeros(2)) {
+		t.Error("Expected second derivatives of 0 at the ends, got ", nat.Derivative(nd.Array(0, 3), 2))
+	}
+
+	clamped := CubicSpline(nd.Array(0, 1, 2, 3), nd.Array(0, 1, 0, 1), Clamped)
+	if !clamped.Derivative(nd.Array(0, 3), 1).Equals(nd.Zeros(2)) {
+		t.Error("Expected slopes of 0 at the ends, got ", clamped.Derivative(nd.Array(0, 3), 1))
+	}
+	if !clamped.At(nd.Array(0, 1, 2, 3)).Equals(nd.Array(0, 1, 0, 1)) {
+		t.Error("Expected the data, got ", clamped.At(nd.Array(0, 1, 2, 3)))
+	}
+
+	//few points
+	if !CubicSpline(nd.Array(0, 2), nd.Array(1, 5), NotAKnot).At(nd.Array(1, 3)).Equals(nd.Array(3, 7)) {
+		t.Error("Expected the line [3, 7]")
+	}
+	parabola := CubicSpline(nd.Array(0, 1, 3), nd.Array(0, 1, 9), NotAKnot)
+	if !parabola.At(nd.Array(2, -1)).Equals(nd.Array(4, 1)) {
+		t.Error("Expected the parabola [4, 1], got ", parabola.At(nd.Array(2, -1)))
+	}
+}
+
+func TestPchip(t *testing.T) {
+	x, y := nd.Array(0, 1, 2, 3, 4), nd.Array(0, 0, 1, 1, 1)
+	p := Pchip(x, y)
+
+	//monotonic data give a monotonic curve that stays within the data
+	fine := nd.Arange(401).Map(func(v float64) float64 { return v / 100 })
+	values := p.At(fine).Values()
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1]-1e-12 || values[i] < -1e-12 || values[i] > 1+1e-12 {
+			t.Error("Expected a monotonic curve in [0, 1], got ", values[i-1], values[i], " at ", fine.Get(i))
+			break
+		}
+	}
+	//flat parts stay flat, the step is symmetric
+	if !p.At(nd.Array(0.5, 1.5, 3.5)).Equals(nd.Array(0, 0.5, 1)) {
+		t.Error("Expected [0, 0.5, 1], got ", p.At(nd.Array(0.5, 1.5, 3.5)))
+	}
+
+	//slopes from the weighted harmonic mean and the end rule
+	q := Pchip(nd.Array(0, 1, 3), nd.Array(0, 2, 3))
+	if math.Abs(q.slopes[1]-9/10.5) > 1e-12 || math.Abs(q.slopes[0]-2.5) > 1e-12 || math.Abs(q.slopes[2]-0) > 1e-12 {
+		t.Error("Expected slopes [2.5, 0.857, 0], got ", q.slopes)
+	}
+}
@@ -0,0 +1,214 @@
+package nd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ledao/ndarray/util"
+)
+
+//Einstein summation over the operands, described by subscripts like "bij,bjk->bik".
+//Each operand is labeled by one letter per dimension, a label repeated in one operand
+//takes the diagonal, and labels absent from the output are summed over.
+//Without "->" the output is made of the labels appearing only once, in alphabetical order.
+//With three or more operands, pairs are contracted greedily, choosing at each step
+//the pair whose intermediate result is the smallest.
+//A scalar result is returned with shape [1].
+func Einsum(subscripts string, operands ...*NdArray) *NdArray {
+	ops, out, dims := parseEinsum(subscripts, operands)
+
+	if len(ops) == 1 {
+		return einsumContract(ops, out, dims).arr
+	}
+
+	for len(ops) > 1 {
+		bi, bj := 0, 1
+		var bestKeep []byte
+		bestSize := -1
+		for i := 0; i < len(ops); i++ {
+			for j := i + 1; j < len(ops); j++ {
+				keep := einsumKeep(ops, i, j, out)
+				size := 1
+				for _, l := range keep {
+					size *= dims[l]
+				}
+				if bestSize < 0 || size < bestSize {
+					bi, bj, bestKeep, bestSize = i, j, keep, size
+				}
+			}
+		}
+
+		if len(ops) == 2 {
+			bestKeep = out
+		}
+		contracted := einsumContract([]einsumOperand{ops[bi], ops[bj]}, bestKeep, dims)
+		rest := []einsumOperand{}
+		for k := range ops {
+			if k != bi && k != bj {
+				rest = append(rest, ops[k])
+			}
+		}
+		ops = append(rest, contracted)
+	}
+
+	return ops[0].arr
+}
+
+type einsumOperand struct {
+	labels []byte
+	arr    *NdArray
+}
+
+func parseEinsum(subscripts string, operands []*NdArray) ([]einsumOperand, []byte, map[byte]int) {
+	subscripts = strings.Replace(subscripts, " ", "", -1)
+	inputs, output := subscripts, ""
+	explicit := strings.Contains(subscripts, "->")
+	if explicit {
+		parts := strings.Split(subscripts, "->")
+		if len(parts) != 2 {
+			panic(fmt.Errorf("invalid subscripts: %v", subscripts))
+		}
+		inputs, output = parts[0], parts[1]
+	}
+
+	terms := strings.Split(inputs, ",")
+	if len(terms) != len(operands) {
+		panic(fmt.Errorf("subscripts: %v has %v operands, got %v", subscripts, len(terms), len(operands)))
+	}
+
+	dims := map[byte]int{}
+	counts := map[byte]int{}
+	ops := make([]einsumOperand, len(terms))
+	for i, term := range terms {
+		if len(term) != operands[i].NDims() {
+			panic(fmt.Errorf("subscripts: %v does not match operand %v with shape %v", term, i, operands[i].shape))
+		}
+		for d := 0; d < len(term); d++ {
+			l := term[d]
+			if !isLabel(l) {
+				panic(fmt.Errorf("invalid label: %q", l))
+			}
+			if s, ok := dims[l]; ok && s != operands[i].shape[d] {
+				panic("shape error")
+			}
+			dims[l] = operands[i].shape[d]
+			counts[l]++
+		}
+		ops[i] = einsumOperand{[]byte(term), operands[i]}
+	}
+
+	var out []byte
+	if explicit {
+		out = []byte(output)
+		for k, l := range out {
+			if _, ok := dims[l]; !ok || strings.IndexByte(output[:k], l) >= 0 {
+				panic(fmt.Errorf("invalid output label: %q", l))
+			}
+		}
+	} else {
+		for l := byte('A'); l <= 'z'; l++ {
+			if counts[l] == 1 {
+				out = append(out, l)
+			}
+		}
+	}
+
+	return ops, out, dims
+}
+
+func isLabel(l byte) bool {
+	return (l >= 'a' && l <= 'z') || (l >= 'A' && l <= 'Z')
+}
+
+//labels of ops[i] and ops[j] still needed after contracting them together.
+func einsumKeep(ops []einsumOperand, i, j int, out []byte) []byte {
+	needed := func(l byte) bool {
+		if strings.IndexByte(string(out), l) >= 0 {
+			return true
+		}
+		for k := range ops {
+			if k != i && k != j && strings.IndexByte(string(ops[k].labels), l) >= 0 {
+				return true
+			}
+		}
+		return false
+	}
+
+	keep := []byte{}
+	for _, l := range append(append([]byte{}, ops[i].labels...), ops[j].labels...) {
+		if needed(l) && strings.IndexByte(string(keep), l) < 0 {
+			keep = append(keep, l)
+		}
+	}
+
+	return keep
+}
+
+//multiply the operands elementwise and sum over every label not in out.
+func einsumContract(ops []einsumOperand, out []byte, dims map[byte]int) einsumOperand {
+	all := append([]byte{}, out...)
+	for _, op := range ops {
+		for _, l := range op.labels {
+			if strings.IndexByte(string(all), l) < 0 {
+				all = append(all, l)
+			}
+		}
+	}
+
+	shape := make([]int, len(out))
+	for i, l := range out {
+		shape[i] = dims[l]
+	}
+	size := 1
+	loop := make([]int, len(all))
+	for i, l := range all {
+		loop[i] = dims[l]
+		size *= dims[l]
+	}
+
+	//strides of every loop label in every operand, repeated labels accumulate
+	strides := make([][]int, len(ops)+1)
+	for k, op := range ops {
+		strides[k] = make([]int, len(all))
+		opStrides := stridesOf(op.arr.shape)
+		for d, l := range op.labels {
+			strides[k][strings.IndexByte(string(all), l)] += opStrides[d]
+		}
+	}
+	strides[len(ops)] = make([]int, len(all))
+	copy(strides[len(ops)], stridesOf(shape))
+
+	res := &NdArray{
+		shape: shape,
+		data:  make([]float64, util.ProductOfIntSlice(shape)),
+	}
+	pos := make([]int, len(ops)+1)
+	idx := make([]int, len(all))
+	for p := 0; p < size; p++ {
+		v := 1.0
+		for k, op := range ops {
+			v *= op.arr.data[pos[k]]
+		}
+		res.data[pos[len(ops)]] += v
+
+		for d := len(all) - 1; d >= 0; d-- {
+			idx[d]++
+			for k := range pos {
+				pos[k] += strides[k][d]
+			}
+			if idx[d] < loop[d] {
+				break
+			}
+			for k := range pos {
+				pos[k] -= idx[d] * strides[k][d]
+			}
+			idx[d] = 0
+		}
+	}
+
+	if len(res.shape) == 0 {
+		res.shape = []int{1}
+	}
+
+	return einsumOperand{out, res}
+}
@@ -0,0 +1,67 @@
+package nd
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/util"
+)
+
+func TestEinsumMatrix(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	b := Arange(12).Reshape(3, 4)
+
+	if !Einsum("ij,jk->ik", a, b).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", Einsum("ij,jk->ik", a, b))
+	}
+	if !Einsum("ij,jk", a, b).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", Einsum("ij,jk", a, b))
+	}
+	if !Einsum("ij->ji", a).Equals(a.T()) {
+		t.Error("Expected ", a.T(), ", got ", Einsum("ij->ji", a))
+	}
+	if !Einsum("ij->", a).Equals(Array(15)) {
+		t.Error("Expected [15], got ", Einsum("ij->", a))
+	}
+
+	m := Arange(9).Reshape(3, 3)
+	if !Einsum("ii", m).Equals(Array(m.Trace())) {
+		t.Error("Expected ", m.Trace(), ", got ", Einsum("ii", m))
+	}
+	if !Einsum("ii->i", m).Equals(m.Diag(0)) {
+		t.Error("Expected ", m.Diag(0), ", got ", Einsum("ii->i", m))
+	}
+}
+
+func TestEinsumBatch(t *testing.T) {
+	a := Arange(12).Reshape(2, 2, 3)
+	b := Arange(24).Reshape(2, 3, 4)
+	r := Einsum("bij,bjk->bik", a, b)
+
+	if !util.EqualOfIntSlice(r.Shape(), []int{2, 2, 4}) {
+		t.Error("Expected [2 2 4], got ", r.Shape())
+	}
+	if !r.Equals(MatMul(a, b)) {
+		t.Error("Expected ", MatMul(a, b), ", got ", r)
+	}
+}
+
+func TestEinsumChain(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	b := Arange(12).Reshape(3, 4)
+	c := Arange(8).Reshape(4, 2)
+	v := Array(1, 2)
+
+	r := Einsum("ij,jk,kl,l->i", a, b, c, v)
+	expected := a.Dot(b).Dot(c).Dot(v).Reshape(2)
+	if !r.Equals(expected) {
+		t.Error("Expected ", expected, ", got ", r)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	Einsum("ij,jk->ik", a, a)
+}
@@ -0,0 +1,189 @@
+package fft
+
+import (
+	"fmt"
+	"math/cmplx"
+	"strings"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//N dimensional array of complex128, stored in row major order like nd.NdArray.
+type ComplexArray struct {
+	shape []int
+	data  []complex128
+}
+
+//Create an array holding data with the given shape, data is not copied.
+func NewComplexArray(data []complex128, shape ...int) *ComplexArray {
+	if len(data) != util.ProductOfIntSlice(shape) {
+		panic(fmt.Errorf("shape: %v does not match %v elements", shape, len(data)))
+	}
+
+	return &ComplexArray{shape: append([]int(nil), shape...), data: data}
+}
+
+//Complex array of zeros.
+func ComplexZeros(shape ...int) *ComplexArray {
+	return NewComplexArray(make([]complex128, util.ProductOfIntSlice(shape)), shape...)
+}
+
+//Complex array with the elements of re and no imaginary part.
+func FromReal(re *nd.NdArray) *ComplexArray {
+	data := make([]complex128, re.Size())
+	for i, v := range re.Values() {
+		data[i] = complex(v, 0)
+	}
+
+	return NewComplexArray(data, re.Shape()...)
+}
+
+//Complex array with real parts re and imaginary parts im, which must have the same shape.
+func FromParts(re, im *nd.NdArray) *ComplexArray {
+	if !util.EqualOfIntSlice(re.Shape(), im.Shape()) {
+		panic("shape error")
+	}
+	data := make([]complex128, re.Size())
+	ims := im.Values()
+	for i, v := range re.Values() {
+		data[i] = complex(v, ims[i])
+	}
+
+	return NewComplexArray(data, re.Shape()...)
+}
+
+func (a *ComplexArray) Shape() []int {
+	return a.shape
+}
+
+func (a *ComplexArray) NDims() int {
+	return len(a.shape)
+}
+
+func (a *ComplexArray) Size() int {
+	return len(a.data)
+}
+
+//The underlying elements, changing them changes the array.
+func (a *ComplexArray) Values() []complex128 {
+	return a.data
+}
+
+func (a *ComplexArray) Clone() *ComplexArray {
+	return NewComplexArray(append([]complex128(nil), a.data...), a.shape...)
+}
+
+//Same elements with a new shape, the data is shared.
+func (a *ComplexArray) Reshape(shape ...int) *ComplexArray {
+	return NewComplexArray(a.data, shape...)
+}
+
+func (a *ComplexArray) index(pos []int) int {
+	if len(pos) != len(a.shape) {
+		panic(fmt.Errorf("position: %v does not match shape %v", pos, a.shape))
+	}
+	idx := 0
+	for i, p := range pos {
+		if p < 0 || p >= a.shape[i] {
+			panic(fmt.Errorf("position: %v out of shape %v", pos, a.shape))
+		}
+		idx = idx*a.shape[i] + p
+	}
+
+	return idx
+}
+
+func (a *ComplexArray) Get(pos ...int) complex128 {
+	return a.data[a.index(pos)]
+}
+
+func (a *ComplexArray) Set(v complex128, pos ...int) {
+	a.data[a.index(pos)] = v
+}
+
+//Real parts.
+func (a *ComplexArray) Real() *nd.NdArray {
+	return a.toReal(func(v complex128) float64 { return real(v) })
+}
+
+//Imaginary parts.
+func (a *ComplexArray) Imag() *nd.NdArray {
+	return a.toReal(func(v complex128) float64 { return imag(v) })
+}
+
+//Magnitudes.
+func (a *ComplexArray) Abs() *nd.NdArray {
+	return a.toReal(cmplx.Abs)
+}
+
+//Phase angles in (-Pi, Pi].
+func (a *ComplexArray) Angle() *nd.NdArray {
+	return a.toReal(cmplx.Phase)
+}
+
+func (a *ComplexArray) toReal(f func(complex128) float64) *nd.NdArray {
+	r := nd.Zeros(a.shape...)
+	rs := r.Values()
+	for i, v := range a.data {
+		rs[i] = f(v)
+	}
+
+	return r
+}
+
+//Complex conjugates.
+func (a *ComplexArray) Conj() *ComplexArray {
+	r := a.Clone()
+	for i, v := range r.data {
+		r.data[i] = cmplx.Conj(v)
+	}
+
+	return r
+}
+
+//Element wise product, a and b must have the same shape.
+func (a *ComplexArray) Mul(b *ComplexArray) *ComplexArray {
+	if !util.EqualOfIntSlice(a.shape, b.shape) {
+		panic("shape error")
+	}
+	r := a.Clone()
+	for i, v := range b.data {
+		r.data[i] *= v
+	}
+
+	return r
+}
+
+//Every element multiplied by s.
+func (a *ComplexArray) Scale(s complex128) *ComplexArray {
+	r := a.Clone()
+	for i := range r.data {
+		r.data[i] *= s
+	}
+
+	return r
+}
+
+//Whether a and b have the same shape and elements within tol of each other.
+func (a *ComplexArray) Equals(b *ComplexArray, tol float64) bool {
+	if !util.EqualOfIntSlice(a.shape, b.shape) {
+		return false
+	}
+	for i, v := range a.data {
+		if !(cmplx.Abs(v-b.data[i]) <= tol) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (a *ComplexArray) String() string {
+	eles := make([]string, len(a.data))
+	for i, v := range a.data {
+		eles[i] = fmt.Sprintf("%.6g%+.6gi", real(v), imag(v))
+	}
+
+	return fmt.Sprintf("complexarray<%v>\n([%v])", a.shape, strings.Join(eles, ", "))
+}
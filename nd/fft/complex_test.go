@@ -0,0 +1,54 @@
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestComplexArray(t *testing.T) {
+	a := FromParts(nd.Array(3, 0, -1, 1), nd.Array(4, 2, 0, 1)).Reshape(2, 2)
+
+	if a.Get(1, 0) != -1 || a.NDims() != 2 || a.Size() != 4 {
+		t.Error("Expected -1 at [1, 0], got ", a.Get(1, 0))
+	}
+	if !a.Real().Equals(nd.Array(3, 0, -1, 1).Reshape(2, 2)) {
+		t.Error("Expected [[3, 0], [-1, 1]], got ", a.Real())
+	}
+	if !a.Imag().Equals(nd.Array(4, 2, 0, 1).Reshape(2, 2)) {
+		t.Error("Expected [[4, 2], [0, 1]], got ", a.Imag())
+	}
+	if !a.Abs().Equals(nd.Array(5, 2, 1, math.Sqrt2).Reshape(2, 2)) {
+		t.Error("Expected [[5, 2], [1, 1.414]], got ", a.Abs())
+	}
+	if !a.Angle().Equals(nd.Array(math.Atan2(4, 3), math.Pi/2, math.Pi, math.Pi/4).Reshape(2, 2)) {
+		t.Error("Expected [[0.927, 1.571], [3.142, 0.785]], got ", a.Angle())
+	}
+
+	b := a.Conj()
+	if b.Get(0, 0) != 3-4i || a.Get(0, 0) != 3+4i {
+		t.Error("Expected 3-4i, got ", b.Get(0, 0))
+	}
+	if a.Mul(b).Get(0, 0) != 25 {
+		t.Error("Expected 25, got ", a.Mul(b).Get(0, 0))
+	}
+	if a.Scale(2i).Get(0, 1) != -4 {
+		t.Error("Expected -4, got ", a.Scale(2i).Get(0, 1))
+	}
+
+	a.Set(1i, 1, 1)
+	if a.Values()[3] != 1i {
+		t.Error("Expected 1i, got ", a.Values()[3])
+	}
+	if a.Equals(a.Reshape(4), 1) {
+		t.Error("Expected arrays of different shapes to differ")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a position out of the shape")
+		}
+	}()
+	a.Get(2, 0)
+}
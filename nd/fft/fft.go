@@ -0,0 +1,178 @@
+//Package fft computes discrete Fourier transforms of any length along the axes of arrays.
+//Lengths with small prime factors use mixed radix Cooley-Tukey, the others Bluestein's algorithm,
+//so every transform takes O(n log n) time. The spectra are ComplexArray, and follow the numpy
+//conventions: the forward transform is not scaled and the inverse is divided by n.
+package fft
+
+import (
+	"fmt"
+	"math/cmplx"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Transform of length n along axis. The lanes are cut or padded with zeros to n elements,
+//n <= 0 stands for the length of the axis.
+func FFT(a *ComplexArray, n, axis int) *ComplexArray {
+	n = transformLen(a.shape, n, axis)
+	plan := NewPlan(n)
+	return mapLanes(a, axis, n, func(dst, src []complex128) {
+		copy(dst, src)
+		plan.Forward(dst, dst)
+	})
+}
+
+//Inverse of FFT, divided by n.
+func IFFT(a *ComplexArray, n, axis int) *ComplexArray {
+	n = transformLen(a.shape, n, axis)
+	plan := NewPlan(n)
+	return mapLanes(a, axis, n, func(dst, src []complex128) {
+		copy(dst, src)
+		plan.Inverse(dst, dst)
+	})
+}
+
+//Transform of real a along axis. The spectrum of real data is Hermitian, so only the
+//n / 2 + 1 non negative frequencies are kept. n is as in FFT.
+func RFFT(a *nd.NdArray, n, axis int) *ComplexArray {
+	n = transformLen(a.Shape(), n, axis)
+	plan := NewPlan(n)
+	buf := make([]complex128, n)
+	return mapLanes(FromReal(a), axis, n/2+1, func(dst, src []complex128) {
+		for i := range buf {
+			buf[i] = 0
+		}
+		copy(buf, src)
+		plan.Forward(buf, buf)
+		copy(dst, buf)
+	})
+}
+
+//Inverse of RFFT, giving n real elements along axis. n <= 0 stands for 2 (m - 1) when axis has m elements.
+//The imaginary parts of the zero frequency, and of the Nyquist frequency for even n, are ignored.
+func IRFFT(a *ComplexArray, n, axis int) *nd.NdArray {
+	checkAxis(a.shape, axis)
+	m := a.shape[axis]
+	if n <= 0 {
+		n = 2 * (m - 1)
+	}
+	if n < 1 {
+		panic(fmt.Errorf("length: %v must be positive", n))
+	}
+
+	plan := NewPlan(n)
+	return mapLanes(a, axis, n, func(dst, src []complex128) {
+		copy(dst[:n/2+1], src)
+		dst[0] = complex(real(dst[0]), 0)
+		if n%2 == 0 {
+			dst[n/2] = complex(real(dst[n/2]), 0)
+		}
+		for k := 1; k < n-k; k++ {
+			dst[n-k] = cmplx.Conj(dst[k])
+		}
+		plan.Inverse(dst, dst)
+	}).Real()
+}
+
+//Two dimensional transform over the last two axes, or over the two given axes.
+func FFT2(a *ComplexArray, axes ...int) *ComplexArray {
+	return FFTN(a, axes2(a.shape, axes)...)
+}
+
+//Inverse of FFT2.
+func IFFT2(a *ComplexArray, axes ...int) *ComplexArray {
+	return IFFTN(a, axes2(a.shape, axes)...)
+}
+
+//Transform over each of axes in turn, or over all axes when none is given.
+func FFTN(a *ComplexArray, axes ...int) *ComplexArray {
+	for _, axis := range allAxes(a.shape, axes) {
+		a = FFT(a, 0, axis)
+	}
+
+	return a
+}
+
+//Inverse of FFTN.
+func IFFTN(a *ComplexArray, axes ...int) *ComplexArray {
+	for _, axis := range allAxes(a.shape, axes) {
+		a = IFFT(a, 0, axis)
+	}
+
+	return a
+}
+
+func checkAxis(shape []int, axis int) {
+	if axis < 0 || axis >= len(shape) {
+		panic(fmt.Errorf("axis: %v out of %v dimensions", axis, len(shape)))
+	}
+}
+
+func transformLen(shape []int, n, axis int) int {
+	checkAxis(shape, axis)
+	if n <= 0 {
+		n = shape[axis]
+	}
+	if n < 1 {
+		panic(fmt.Errorf("length: %v must be positive", n))
+	}
+
+	return n
+}
+
+func axes2(shape []int, axes []int) []int {
+	if len(axes) == 0 {
+		if len(shape) < 2 {
+			panic("shape error")
+		}
+		return []int{len(shape) - 2, len(shape) - 1}
+	}
+	if len(axes) != 2 {
+		panic(fmt.Errorf("axes: %v must be two", axes))
+	}
+
+	return axes
+}
+
+func allAxes(shape []int, axes []int) []int {
+	if len(axes) > 0 {
+		return axes
+	}
+	axes = make([]int, len(shape))
+	for i := range axes {
+		axes[i] = i
+	}
+
+	return axes
+}
+
+//Apply f to every lane of a along axis, f maps the shape[axis] elements of src to the m elements of dst,
+//which starts zeroed. The result has the shape of a with m elements along axis.
+func mapLanes(a *ComplexArray, axis, m int, f func(dst, src []complex128)) *ComplexArray {
+	checkAxis(a.shape, axis)
+	n := a.shape[axis]
+	outer := util.ProductOfIntSlice(a.shape[:axis])
+	inner := util.ProductOfIntSlice(a.shape[axis+1:])
+
+	shape := append([]int(nil), a.shape...)
+	shape[axis] = m
+	r := ComplexZeros(shape...)
+	src, dst := make([]complex128, n), make([]complex128, m)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for j := range src {
+				src[j] = a.data[(o*n+j)*inner+i]
+			}
+			for j := range dst {
+				dst[j] = 0
+			}
+			f(dst, src)
+			for j, v := range dst {
+				r.data[(o*m+j)*inner+i] = v
+			}
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,96 @@
+package fft
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestFFT(t *testing.T) {
+	a := FromReal(nd.Array(1, 2, 3, 4))
+
+	expected := NewComplexArray([]complex128{10, -2 + 2i, -2, -2 - 2i}, 4)
+	if !FFT(a, 0, 0).Equals(expected, 1e-12) {
+		t.Error("Expected ", expected, ", got ", FFT(a, 0, 0))
+	}
+	if !IFFT(FFT(a, 0, 0), 0, 0).Equals(a, 1e-12) {
+		t.Error("Expected ", a, ", got ", IFFT(FFT(a, 0, 0), 0, 0))
+	}
+
+	//n pads with zeros or cuts
+	padded := NewComplexArray([]complex128{3, 1 - 2i, -1, 1 + 2i}, 4)
+	if !FFT(FromReal(nd.Array(1, 2)), 4, 0).Equals(padded, 1e-12) {
+		t.Error("Expected ", padded, ", got ", FFT(FromReal(nd.Array(1, 2)), 4, 0))
+	}
+	//every lane is padded
+	rows := FromReal(nd.Array(1, 2, 3, 4).Reshape(2, 2))
+	paddedRows := NewComplexArray([]complex128{3, 1 - 2i, -1, 1 + 2i, 7, 3 - 4i, -1, 3 + 4i}, 2, 4)
+	if !FFT(rows, 4, 1).Equals(paddedRows, 1e-12) {
+		t.Error("Expected ", paddedRows, ", got ", FFT(rows, 4, 1))
+	}
+	cut := NewComplexArray([]complex128{3, -1}, 2)
+	if !FFT(a, 2, 0).Equals(cut, 1e-12) {
+		t.Error("Expected ", cut, ", got ", FFT(a, 2, 0))
+	}
+}
+
+func TestRFFT(t *testing.T) {
+	a := nd.Array(1, 2, 3, 4, 5)
+
+	expected := NewComplexArray([]complex128{15, -2.5 + 3.4409548011779i, -2.5 + 0.8122992405822i}, 3)
+	if !RFFT(a, 0, 0).Equals(expected, 1e-10) {
+		t.Error("Expected ", expected, ", got ", RFFT(a, 0, 0))
+	}
+	if !IRFFT(RFFT(a, 0, 0), 5, 0).Equals(a) {
+		t.Error("Expected ", a, ", got ", IRFFT(RFFT(a, 0, 0), 5, 0))
+	}
+
+	//even lengths round trip with the default n
+	g := random.NewGenerator(2)
+	b := g.Normal(0, 1, 3, 8)
+	if !IRFFT(RFFT(b, 0, 1), 0, 1).Equals(b) {
+		t.Error("Expected ", b, ", got ", IRFFT(RFFT(b, 0, 1), 0, 1))
+	}
+	half, full := RFFT(b, 0, 1), FFT(FromReal(b), 0, 1)
+	for i := 0; i < 3; i++ {
+		for k := 0; k < 5; k++ {
+			if cmplx.Abs(half.Get(i, k)-full.Get(i, k)) > 1e-12 {
+				t.Error("Expected ", full.Get(i, k), " at ", i, k, ", got ", half.Get(i, k))
+			}
+		}
+	}
+}
+
+func TestFFTN(t *testing.T) {
+	a := FromReal(nd.Array(1, 2, 3, 4).Reshape(2, 2))
+
+	expected := NewComplexArray([]complex128{10, -2, -4, 0}, 2, 2)
+	if !FFT2(a).Equals(expected, 1e-12) {
+		t.Error("Expected ", expected, ", got ", FFT2(a))
+	}
+	if !FFTN(a).Equals(expected, 1e-12) {
+		t.Error("Expected ", expected, ", got ", FFTN(a))
+	}
+	rows := NewComplexArray([]complex128{3, -1, 7, -1}, 2, 2)
+	if !FFTN(a, 1).Equals(rows, 1e-12) {
+		t.Error("Expected ", rows, ", got ", FFTN(a, 1))
+	}
+
+	g := random.NewGenerator(4)
+	b := FromParts(g.Normal(0, 1, 3, 5, 7), g.Normal(0, 1, 3, 5, 7))
+	if !IFFTN(FFTN(b)).Equals(b, 1e-12) {
+		t.Error("Expected IFFTN to invert FFTN")
+	}
+	if !IFFT2(FFT2(b, 0, 2), 0, 2).Equals(b, 1e-12) {
+		t.Error("Expected IFFT2 to invert FFT2")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an axis out of range")
+		}
+	}()
+	FFT(b, 0, 3)
+}
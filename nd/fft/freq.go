@@ -0,0 +1,102 @@
+package fft
+
+import (
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Frequencies of the FFT terms for n samples spaced by d: 0, 1, ..., then the negative ones -n/2, ..., -1,
+//all divided by n d.
+func FFTFreq(n int, d float64) *nd.NdArray {
+	r := nd.Zeros(n)
+	rs := r.Values()
+	for k := range rs {
+		f := k
+		if k > (n-1)/2 {
+			f = k - n
+		}
+		rs[k] = float64(f) / (float64(n) * d)
+	}
+
+	return r
+}
+
+//Frequencies of the RFFT terms for n samples spaced by d: 0, 1, ..., n/2 divided by n d.
+func RFFTFreq(n int, d float64) *nd.NdArray {
+	r := nd.Zeros(n/2 + 1)
+	rs := r.Values()
+	for k := range rs {
+		rs[k] = float64(k) / (float64(n) * d)
+	}
+
+	return r
+}
+
+//Move the zero frequency to the middle of axes, or of all axes when none is given,
+//by rolling each of them by half its length.
+func FFTShift(a *nd.NdArray, axes ...int) *nd.NdArray {
+	return shiftReal(a, axes, false)
+}
+
+//Inverse of FFTShift, which differs for odd lengths.
+func IFFTShift(a *nd.NdArray, axes ...int) *nd.NdArray {
+	return shiftReal(a, axes, true)
+}
+
+//Move the zero frequency to the middle of axes, like the function FFTShift.
+func (a *ComplexArray) FFTShift(axes ...int) *ComplexArray {
+	return a.shift(axes, false)
+}
+
+//Inverse of FFTShift.
+func (a *ComplexArray) IFFTShift(axes ...int) *ComplexArray {
+	return a.shift(axes, true)
+}
+
+func shiftReal(a *nd.NdArray, axes []int, inverse bool) *nd.NdArray {
+	r := nd.Zeros(a.Shape()...)
+	rs, as := r.Values(), a.Values()
+	for j, i := range rollIndex(a.Shape(), axes, inverse) {
+		rs[j] = as[i]
+	}
+
+	return r
+}
+
+func (a *ComplexArray) shift(axes []int, inverse bool) *ComplexArray {
+	r := ComplexZeros(a.shape...)
+	for j, i := range rollIndex(a.shape, axes, inverse) {
+		r.data[j] = a.data[i]
+	}
+
+	return r
+}
+
+//for each element of an array of shape, the flat index of the element rolled into it.
+func rollIndex(shape []int, axes []int, inverse bool) []int {
+	shifts := make([]int, len(shape))
+	for _, axis := range allAxes(shape, axes) {
+		checkAxis(shape, axis)
+		shifts[axis] = shape[axis] / 2
+		if inverse {
+			shifts[axis] = shape[axis] - shape[axis]/2
+		}
+	}
+
+	idx := make([]int, util.ProductOfIntSlice(shape))
+	pos := make([]int, len(shape))
+	for j := range idx {
+		for d, n := range shape {
+			idx[j] = idx[j]*n + (pos[d]-shifts[d]+n)%n
+		}
+		for d := len(shape) - 1; d >= 0; d-- {
+			pos[d]++
+			if pos[d] < shape[d] {
+				break
+			}
+			pos[d] = 0
+		}
+	}
+
+	return idx
+}
@@ -0,0 +1,51 @@
+package fft
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestFFTFreq(t *testing.T) {
+	if !FFTFreq(5, 0.1).Equals(nd.Array(0, 2, 4, -4, -2)) {
+		t.Error("Expected [0, 2, 4, -4, -2], got ", FFTFreq(5, 0.1))
+	}
+	if !FFTFreq(4, 1).Equals(nd.Array(0, 0.25, -0.5, -0.25)) {
+		t.Error("Expected [0, 0.25, -0.5, -0.25], got ", FFTFreq(4, 1))
+	}
+	if !RFFTFreq(5, 0.1).Equals(nd.Array(0, 2, 4)) {
+		t.Error("Expected [0, 2, 4], got ", RFFTFreq(5, 0.1))
+	}
+	if !RFFTFreq(4, 1).Equals(nd.Array(0, 0.25, 0.5)) {
+		t.Error("Expected [0, 0.25, 0.5], got ", RFFTFreq(4, 1))
+	}
+}
+
+func TestFFTShift(t *testing.T) {
+	a := nd.Arange(5)
+	if !FFTShift(a).Equals(nd.Array(3, 4, 0, 1, 2)) {
+		t.Error("Expected [3, 4, 0, 1, 2], got ", FFTShift(a))
+	}
+	if !IFFTShift(a).Equals(nd.Array(2, 3, 4, 0, 1)) {
+		t.Error("Expected [2, 3, 4, 0, 1], got ", IFFTShift(a))
+	}
+	if !IFFTShift(FFTShift(a)).Equals(a) {
+		t.Error("Expected ", a, ", got ", IFFTShift(FFTShift(a)))
+	}
+
+	b := nd.Arange(6).Reshape(2, 3)
+	if !FFTShift(b).Equals(nd.Array(5, 3, 4, 2, 0, 1).Reshape(2, 3)) {
+		t.Error("Expected [[5, 3, 4], [2, 0, 1]], got ", FFTShift(b))
+	}
+	if !FFTShift(b, 1).Equals(nd.Array(2, 0, 1, 5, 3, 4).Reshape(2, 3)) {
+		t.Error("Expected [[2, 0, 1], [5, 3, 4]], got ", FFTShift(b, 1))
+	}
+
+	c := FromParts(b, b)
+	if !c.FFTShift(1).Equals(FromParts(FFTShift(b, 1), FFTShift(b, 1)), 0) {
+		t.Error("Expected ", FromParts(FFTShift(b, 1), FFTShift(b, 1)), ", got ", c.FFTShift(1))
+	}
+	if !c.FFTShift().IFFTShift().Equals(c, 0) {
+		t.Error("Expected ", c, ", got ", c.FFTShift().IFFTShift())
+	}
+}
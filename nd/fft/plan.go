@@ -0,0 +1,206 @@
+package fft
+
+import (
+	"fmt"
+	"math"
+)
+
+//Lengths whose prime factors are all at most maxRadix are transformed by mixed radix Cooley-Tukey,
+//the others by Bluestein's algorithm on a power of two length.
+const maxRadix = 31
+
+//Precomputed factors and twiddles for transforms of one length.
+//A Plan is read only once created, so it can be used by several goroutines at once.
+type Plan struct {
+	n        int
+	factors  []int
+	twiddles []complex128
+	//set when n has a prime factor larger than maxRadix
+	chirp *bluestein
+}
+
+//Bluestein's algorithm writes the transform as a circular convolution of length m >= 2n - 1.
+type bluestein struct {
+	//power of two plan of length m
+	inner *Plan
+	//w[k] = exp(-i Pi k^2 / n)
+	w []complex128
+	//transform of the conjugate chirp, wrapped around to length m
+	kernel []complex128
+}
+
+//Create a plan for transforms of length n.
+func NewPlan(n int) *Plan {
+	if n < 1 {
+		panic(fmt.Errorf("length: %v must be positive", n))
+	}
+
+	p := &Plan{n: n, factors: factorize(n)}
+	if p.factors[len(p.factors)-1] > maxRadix {
+		p.chirp = newBluestein(n)
+		p.factors = nil
+		return p
+	}
+	p.twiddles = make([]complex128, n)
+	for k := range p.twiddles {
+		p.twiddles[k] = rootOfUnity(k, n)
+	}
+
+	return p
+}
+
+//Length of the transforms.
+func (p *Plan) Len() int {
+	return p.n
+}
+
+//Discrete Fourier transform of src into dst, dst[k] = sum src[j] exp(-2 Pi i j k / n).
+//Both have length n, and dst may be src.
+func (p *Plan) Forward(dst, src []complex128) {
+	if len(dst) != p.n || len(src) != p.n {
+		panic(fmt.Errorf("lengths: %v, %v do not match plan length %v", len(dst), len(src), p.n))
+	}
+
+	if p.chirp != nil {
+		p.chirp.transform(dst, src)
+		return
+	}
+	if p.n == 1 {
+		dst[0] = src[0]
+		return
+	}
+	in := append([]complex128(nil), src...)
+	p.work(dst, in, 0, 1, p.factors)
+}
+
+//Inverse transform of src into dst, dst[j] = sum src[k] exp(2 Pi i j k / n) / n.
+//Both have length n, and dst may be src.
+func (p *Plan) Inverse(dst, src []complex128) {
+	if len(dst) != p.n || len(src) != p.n {
+		panic(fmt.Errorf("lengths: %v, %v do not match plan length %v", len(dst), len(src), p.n))
+	}
+
+	//the inverse is the conjugate of the forward transform of the conjugate
+	in := make([]complex128, p.n)
+	for i, v := range src {
+		in[i] = complex(real(v), -imag(v))
+	}
+	p.Forward(dst, in)
+	scale := 1 / float64(p.n)
+	for i, v := range dst {
+		dst[i] = complex(real(v)*scale, -imag(v)*scale)
+	}
+}
+
+//Decimation in time: out gets the transform of the len(out) elements of in starting at off with stride,
+//factors multiply to len(out).
+func (p *Plan) work(out, in []complex128, off, stride int, factors []int) {
+	r := factors[0]
+	m := len(out) / r
+	if m == 1 {
+		for j := 0; j < r; j++ {
+			out[j] = in[off+j*stride]
+		}
+	} else {
+		//transforms of the r decimated subsequences, one after another in out
+		for j := 0; j < r; j++ {
+			p.work(out[j*m:(j+1)*m], in, off+j*stride, stride*r, factors[1:])
+		}
+	}
+
+	if r == 2 {
+		p.butterfly2(out, stride, m)
+	} else {
+		p.butterfly(out, stride, r, m)
+	}
+}
+
+func (p *Plan) butterfly2(out []complex128, stride, m int) {
+	for k := 0; k < m; k++ {
+		t := out[k+m] * p.twiddles[k*stride]
+		out[k+m] = out[k] - t
+		out[k] += t
+	}
+}
+
+//Combine r transforms of length m into one of length r m, with a direct transform of length r
+//whose twiddles include those between the stages.
+func (p *Plan) butterfly(out []complex128, stride, r, m int) {
+	scratch := make([]complex128, r)
+	for u := 0; u < m; u++ {
+		for q := 0; q < r; q++ {
+			scratch[q] = out[u+q*m]
+		}
+		for q1, k := 0, u; q1 < r; q1, k = q1+1, k+m {
+			//the twiddle index of scratch[q] is q k stride modulo n
+			sum, idx := scratch[0], 0
+			for q := 1; q < r; q++ {
+				idx += k * stride
+				if idx >= p.n {
+					idx -= p.n
+				}
+				sum += scratch[q] * p.twiddles[idx]
+			}
+			out[k] = sum
+		}
+	}
+}
+
+func newBluestein(n int) *bluestein {
+	m := 1
+	for m < 2*n-1 {
+		m *= 2
+	}
+
+	b := &bluestein{inner: NewPlan(m), w: make([]complex128, n), kernel: make([]complex128, m)}
+	for k := 0; k < n; k++ {
+		//k^2 modulo 2n keeps the angle small, so that it stays accurate for large k
+		b.w[k] = rootOfUnity(k*k%(2*n), 2*n)
+		c := complex(real(b.w[k]), -imag(b.w[k]))
+		b.kernel[k] = c
+		if k > 0 {
+			b.kernel[m-k] = c
+		}
+	}
+	b.inner.Forward(b.kernel, b.kernel)
+
+	return b
+}
+
+func (b *bluestein) transform(dst, src []complex128) {
+	n, m := len(b.w), len(b.kernel)
+	buf := make([]complex128, m)
+	for k := 0; k < n; k++ {
+		buf[k] = src[k] * b.w[k]
+	}
+	b.inner.Forward(buf, buf)
+	for k := range buf {
+		buf[k] *= b.kernel[k]
+	}
+	b.inner.Inverse(buf, buf)
+	for k := 0; k < n; k++ {
+		dst[k] = buf[k] * b.w[k]
+	}
+}
+
+//exp(-2 Pi i k / n).
+func rootOfUnity(k, n int) complex128 {
+	s, c := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+	return complex(c, s)
+}
+
+//prime factors of n in increasing order, [1] for 1.
+func factorize(n int) []int {
+	factors := []int{}
+	for f := 2; f*f <= n; f++ {
+		for n%f == 0 {
+			factors = append(factors, f)
+			n /= f
+		}
+	}
+	if n > 1 || len(factors) == 0 {
+		factors = append(factors, n)
+	}
+
+	return factors
+}
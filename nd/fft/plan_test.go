@@ -0,0 +1,69 @@
+package fft
+
+import (
+	"math/cmplx"
+	"testing"
+
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//direct O(n^2) transform.
+func naiveDFT(x []complex128) []complex128 {
+	n := len(x)
+	r := make([]complex128, n)
+	for k := range r {
+		for j, v := range x {
+			r[k] += v * rootOfUnity(j*k%n, n)
+		}
+	}
+
+	return r
+}
+
+func TestPlanAgainstNaive(t *testing.T) {
+	g := random.NewGenerator(5)
+	//powers of two, mixed radix, and primes above maxRadix going through Bluestein
+	for _, n := range []int{1, 2, 3, 8, 12, 30, 31, 37, 64, 74, 97, 100, 210, 257} {
+		re, im := g.Normal(0, 1, n).Values(), g.Normal(0, 1, n).Values()
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(re[i], im[i])
+		}
+
+		plan := NewPlan(n)
+		got := make([]complex128, n)
+		plan.Forward(got, x)
+		expected := naiveDFT(x)
+		for k := range got {
+			if cmplx.Abs(got[k]-expected[k]) > 1e-9*float64(n) {
+				t.Error("Length ", n, ": expected ", expected[k], " at ", k, ", got ", got[k])
+				break
+			}
+		}
+
+		//in place inverse gives x back
+		plan.Inverse(got, got)
+		for k := range got {
+			if cmplx.Abs(got[k]-x[k]) > 1e-12*float64(n) {
+				t.Error("Length ", n, ": expected ", x[k], " after the inverse at ", k, ", got ", got[k])
+				break
+			}
+		}
+	}
+}
+
+func TestPlanFactors(t *testing.T) {
+	if p := NewPlan(360); p.chirp != nil || len(p.factors) != 6 {
+		t.Error("Expected mixed radix factors [2 2 2 3 3 5], got ", p.factors)
+	}
+	if p := NewPlan(2 * 101); p.chirp == nil || p.chirp.inner.Len() != 512 {
+		t.Error("Expected Bluestein with an inner length of 512")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a length mismatch")
+		}
+	}()
+	NewPlan(4).Forward(make([]complex128, 4), make([]complex128, 3))
+}
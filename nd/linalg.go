@@ -0,0 +1,772 @@
+package nd
+
+import (
+	"math"
+)
+
+//Return a 2-D array with ones on the k-th diagonal and zeros elsewhere.
+//k > 0 refers to an upper diagonal, and k < 0 to a lower diagonal.
+func Identity(rows, cols, k int) *NdArray {
+	tn := Zeros(rows, cols)
+	for i := 0; i < rows; i++ {
+		j := i + k
+		if j >= 0 && j < cols {
+			tn.data[i*cols+j] = 1
+		}
+	}
+
+	return tn
+}
+
+//if a's shape is [n],
+//    then a [n+|k|, n+|k|] matrix with a on the k-th diagonal is returned;
+//if a's shape is [m, n],
+//    then the k-th diagonal of a is returned in a 1d array.
+func (a *NdArray) Diag(k int) *NdArray {
+	if a.NDims() == 1 {
+		n := a.shape[0] + absInt(k)
+		tn := Zeros(n, n)
+		for i, v := range a.data {
+			if k >= 0 {
+				tn.data[i*n+i+k] = v
+			} else {
+				tn.data[(i-k)*n+i] = v
+			}
+		}
+
+		return tn
+	}
+
+	if a.NDims() == 2 {
+		rows, cols := a.shape[0], a.shape[1]
+		diag := make([]float64, 0, rows)
+		for i := 0; i < rows; i++ {
+			j := i + k
+			if j >= 0 && j < cols {
+				diag = append(diag, a.data[i*cols+j])
+			}
+		}
+
+		return Array(diag...)
+	}
+
+	panic("shape error")
+}
+
+//Return the sum along the main diagonal of the matrix.
+func (a *NdArray) Trace() float64 {
+	if a.NDims() != 2 {
+		panic("shape error")
+	}
+
+	return a.Diag(0).SumAll()
+}
+
+//Upper triangle of the matrix, elements below the k-th diagonal are zeroed.
+func (a *NdArray) Triu(k int) *NdArray {
+	if a.NDims() != 2 {
+		panic("shape error")
+	}
+
+	tn := a.Clone()
+	for i := 0; i < a.shape[0]; i++ {
+		for j := 0; j < a.shape[1] && j < i+k; j++ {
+			tn.data[i*a.shape[1]+j] = 0
+		}
+	}
+
+	return tn
+}
+
+//Lower triangle of the matrix, elements above the k-th diagonal are zeroed.
+func (a *NdArray) Tril(k int) *NdArray {
+	if a.NDims() != 2 {
+		panic("shape error")
+	}
+
+	tn := a.Clone()
+	for i := 0; i < a.shape[0]; i++ {
+		for j := i + k + 1; j < a.shape[1]; j++ {
+			if j >= 0 {
+				tn.data[i*a.shape[1]+j] = 0
+			}
+		}
+	}
+
+	return tn
+}
+
+//Solve the equation a x = b for x, assuming a is a triangular matrix.
+//If lower is true, only the lower triangle of a is used, otherwise the upper one.
+//If trans is true, the system a.T() x = b is solved instead.
+//b may be a vector of shape [n] or a matrix of shape [n, k], x has the same shape as b.
+func (a *NdArray) SolveTriangular(b *NdArray, lower bool, trans bool) *NdArray {
+	if a.NDims() != 2 || a.shape[0] != a.shape[1] || b.NDims() < 1 || b.NDims() > 2 || b.shape[0] != a.shape[0] {
+		panic("shape error")
+	}
+
+	n := a.shape[0]
+	nrhs := 1
+	if b.NDims() == 2 {
+		nrhs = b.shape[1]
+	}
+
+	//element (i, j) of the effective matrix
+	at := func(i, j int) float64 {
+		if trans {
+			return a.data[j*n+i]
+		}
+		return a.data[i*n+j]
+	}
+	//solving with a.T() swaps the triangle that is actually read
+	forward := lower != trans
+
+	x := b.Clone()
+	for c := 0; c < nrhs; c++ {
+		for s := 0; s < n; s++ {
+			i := s
+			if !forward {
+				i = n - 1 - s
+			}
+			sum := x.data[i*nrhs+c]
+			if forward {
+				for j := 0; j < i; j++ {
+					sum -= at(i, j) * x.data[j*nrhs+c]
+				}
+			} else {
+				for j := i + 1; j < n; j++ {
+					sum -= at(i, j) * x.data[j*nrhs+c]
+				}
+			}
+			d := at(i, i)
+			if d == 0 {
+				panic("singular matrix")
+			}
+			x.data[i*nrhs+c] = sum / d
+		}
+	}
+
+	return x
+}
+
+//Solve the tridiagonal system a x = b with Gaussian elimination and partial pivoting.
+//dl is the sub-diagonal of length n-1, d the diagonal of length n, du the super-diagonal of length n-1.
+//b may be a vector of shape [n] or a matrix of shape [n, k], x has the same shape as b.
+func SolveTridiagonal(dl, d, du, b *NdArray) *NdArray {
+	n := d.Size()
+	if dl.Size() != n-1 || du.Size() != n-1 || b.NDims() < 1 || b.NDims() > 2 || b.shape[0] != n {
+		panic("shape error")
+	}
+
+	nrhs := 1
+	if b.NDims() == 2 {
+		nrhs = b.shape[1]
+	}
+
+	//work copies, u2 keeps the fill-in of the second super-diagonal created by row swaps
+	l := append([]float64{}, dl.data...)
+	m := append([]float64{}, d.data...)
+	u := append([]float64{}, du.data...)
+	u2 := make([]float64, n)
+	x := b.Clone()
+
+	for i := 0; i < n-1; i++ {
+		if math.Abs(m[i]) >= math.Abs(l[i]) {
+			if m[i] == 0 {
+				panic("singular matrix")
+			}
+			f := l[i] / m[i]
+			m[i+1] -= f * u[i]
+			for c := 0; c < nrhs; c++ {
+				x.data[(i+1)*nrhs+c] -= f * x.data[i*nrhs+c]
+			}
+			if i < n-2 {
+				u2[i] = 0
+			}
+		} else {
+			//swap row i and i+1
+			f := m[i] / l[i]
+			m[i] = l[i]
+			tmp := m[i+1]
+			m[i+1] = u[i] - f*tmp
+			u[i] = tmp
+			if i < n-2 {
+				u2[i] = u[i+1]
+				u[i+1] = -f * u2[i]
+			}
+			for c := 0; c < nrhs; c++ {
+				xi, xj := x.data[i*nrhs+c], x.data[(i+1)*nrhs+c]
+				x.data[i*nrhs+c] = xj
+				x.data[(i+1)*nrhs+c] = xi - f*xj
+			}
+		}
+	}
+	if n > 0 && m[n-1] == 0 {
+		panic("singular matrix")
+	}
+
+	for c := 0; c < nrhs; c++ {
+		for i := n - 1; i >= 0; i-- {
+			sum := x.data[i*nrhs+c]
+			if i+1 < n {
+				sum -= u[i] * x.data[(i+1)*nrhs+c]
+			}
+			if i+2 < n {
+				sum -= u2[i] * x.data[(i+2)*nrhs+c]
+			}
+			x.data[i*nrhs+c] = sum / m[i]
+		}
+	}
+
+	return x
+}
+
+func absInt(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+//Solve the linear system a x = b by LU decomposition with partial pivoting.
+//b may be a vector of shape [n] or a matrix of shape [n, k], x has the same shape as b.
+func (a *NdArray) Solve(b *NdArray) *NdArray {
+	if a.NDims() != 2 || a.shape[0] != a.shape[1] || b.NDims() < 1 || b.NDims() > 2 || b.shape[0] != a.shape[0] {
+		panic("shape error")
+	}
+
+	n := a.shape[0]
+	lu, piv := luFactor(a)
+	nrhs := 1
+	if b.NDims() == 2 {
+		nrhs = b.shape[1]
+	}
+
+	x := b.Clone()
+	for i, p := range piv {
+		if p != i {
+			for c := 0; c < nrhs; c++ {
+				x.data[i*nrhs+c], x.data[p*nrhs+c] = x.data[p*nrhs+c], x.data[i*nrhs+c]
+			}
+		}
+	}
+	for c := 0; c < nrhs; c++ {
+		for i := 0; i < n; i++ {
+			sum := x.data[i*nrhs+c]
+			for j := 0; j < i; j++ {
+				sum -= lu.data[i*n+j] * x.data[j*nrhs+c]
+			}
+			x.data[i*nrhs+c] = sum
+		}
+		for i := n - 1; i >= 0; i-- {
+			sum := x.data[i*nrhs+c]
+			for j := i + 1; j < n; j++ {
+				sum -= lu.data[i*n+j] * x.data[j*nrhs+c]
+			}
+			x.data[i*nrhs+c] = sum / lu.data[i*n+i]
+		}
+	}
+
+	return x
+}
+
+//LU decomposition with partial pivoting, L (unit diagonal omitted) and U are packed in one matrix.
+//piv[i] is the row swapped with row i at step i.
+func luFactor(a *NdArray) (*NdArray, []int) {
+	n := a.shape[0]
+	lu := a.Clone()
+	piv := make([]int, n)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(lu.data[i*n+k]) > math.Abs(lu.data[p*n+k]) {
+				p = i
+			}
+		}
+		piv[k] = p
+		if lu.data[p*n+k] == 0 {
+			panic("singular matrix")
+		}
+		if p != k {
+			for j := 0; j < n; j++ {
+				lu.data[k*n+j], lu.data[p*n+j] = lu.data[p*n+j], lu.data[k*n+j]
+			}
+		}
+		for i := k + 1; i < n; i++ {
+			f := lu.data[i*n+k] / lu.data[k*n+k]
+			lu.data[i*n+k] = f
+			for j := k + 1; j < n; j++ {
+				lu.data[i*n+j] -= f * lu.data[k*n+j]
+			}
+		}
+	}
+
+	return lu, piv
+}
+
+//Cholesky decomposition of a symmetric positive definite matrix,
+//the lower triangular matrix l with a = l l.T() is returned.
+func (a *NdArray) Cholesky() *NdArray {
+	if a.NDims() != 2 || a.shape[0] != a.shape[1] {
+		panic("shape error")
+	}
+
+	n := a.shape[0]
+	l := Zeros(n, n)
+	for j := 0; j < n; j++ {
+		sum := a.data[j*n+j]
+		for k := 0; k < j; k++ {
+			sum -= l.data[j*n+k] * l.data[j*n+k]
+		}
+		if sum <= 0 {
+			panic("matrix is not positive definite")
+		}
+		d := math.Sqrt(sum)
+		l.data[j*n+j] = d
+		for i := j + 1; i < n; i++ {
+			sum := a.data[i*n+j]
+			for k := 0; k < j; k++ {
+				sum -= l.data[i*n+k] * l.data[j*n+k]
+			}
+			l.data[i*n+j] = sum / d
+		}
+	}
+
+	return l
+}
+
+//QR decomposition a = q r of a [m, n] matrix by Householder reflections.
+//q [m, k] has orthonormal columns and r [k, n] is upper triangular, with k = min(m, n).
+func (a *NdArray) QR() (*NdArray, *NdArray) {
+	if a.NDims() != 2 {
+		panic("shape error")
+	}
+
+	m, n := a.shape[0], a.shape[1]
+	k := m
+	if n < k {
+		k = n
+	}
+	r := a.Clone()
+	//vs[j] is the Householder vector of step j, acting on rows j to m-1, nil for the identity
+	vs := make([][]float64, k)
+	for j := 0; j < k; j++ {
+		norm := 0.0
+		for i := j; i < m; i++ {
+			norm += r.data[i*n+j] * r.data[i*n+j]
+		}
+		if norm == 0 {
+			continue
+		}
+		alpha := -math.Copysign(math.Sqrt(norm), r.data[j*n+j])
+		v := make([]float64, m-j)
+		for i := range v {
+			v[i] = r.data[(j+i)*n+j]
+		}
+		v[0] -= alpha
+		vs[j] = v
+		householder(r.data, n, j, j, v)
+	}
+
+	q := Identity(m, k, 0)
+	for j := k - 1; j >= 0; j-- {
+		if vs[j] != nil {
+			householder(q.data, k, j, 0, vs[j])
+		}
+	}
+
+	rows := make([]int, k)
+	for i := range rows {
+		rows[i] = i
+	}
+
+	return q, r.GetRows(rows...).Triu(0)
+}
+
+//apply the reflection I - 2 v v.T / v.T v to the rows row.. of the matrix data with cols columns,
+//from column col on.
+func householder(data []float64, cols, row, col int, v []float64) {
+	vv := 0.0
+	for _, e := range v {
+		vv += e * e
+	}
+	for c := col; c < cols; c++ {
+		dot := 0.0
+		for i, e := range v {
+			dot += e * data[(row+i)*cols+c]
+		}
+		f := 2 * dot / vv
+		for i, e := range v {
+			data[(row+i)*cols+c] -= f * e
+		}
+	}
+}
+
+//Least squares solution x minimizing the norm of a x - b, for a [m, n] matrix with m >= n of full column rank.
+//It is computed from the QR decomposition of a, panics with "rank deficient matrix" when a has not full rank.
+//b may be a vector of shape [m] or a matrix of shape [m, k], x has shape [n] or [n, k].
+func (a *NdArray) Lstsq(b *NdArray) *NdArray {
+	if a.NDims() != 2 || a.shape[0] < a.shape[1] || b.NDims() < 1 || b.NDims() > 2 || b.shape[0] != a.shape[0] {
+		panic("shape error")
+	}
+
+	q, r := a.QR()
+	largest := 0.0
+	for i := 0; i < r.shape[0]; i++ {
+		largest = math.Max(largest, math.Abs(r.data[i*r.shape[1]+i]))
+	}
+	for i := 0; i < r.shape[0]; i++ {
+		if math.Abs(r.data[i*r.shape[1]+i]) <= 1e-12*largest || largest == 0 {
+			panic("rank deficient matrix")
+		}
+	}
+
+	return r.SolveTriangular(MatMul(q.T(), b), false, false)
+}
+
+//Least squares solution x of a x = b like Lstsq, and (a.T a)^-1, the covariance of x up to the variance of b,
+//from one QR decomposition of a [m, n] with its columns scaled to unit norm. a is rank deficient when m < n,
+//or when a diagonal element of R is not above 1e-12 times the largest, like in Lstsq. Then the inverse is all NaN,
+//and x is 0 from the column of the first such element on, which is a least squares solution when the columns
+//before it are independent, like the first columns of a Vandermonde matrix.
+//b may be a vector of shape [m] or a matrix of shape [m, k], x has shape [n] or [n, k].
+func (a *NdArray) LstsqCov(b *NdArray) (*NdArray, *NdArray) {
+	if a.NDims() != 2 || b.NDims() < 1 || b.NDims() > 2 || b.shape[0] != a.shape[0] {
+		panic("shape error")
+	}
+
+	m, n := a.shape[0], a.shape[1]
+	scaled := a.Clone()
+	scale := make([]float64, n)
+	for j := range scale {
+		for i := 0; i < m; i++ {
+			scale[j] += scaled.data[i*n+j] * scaled.data[i*n+j]
+		}
+		scale[j] = math.Sqrt(scale[j])
+		if scale[j] == 0 {
+			scale[j] = 1
+		}
+		for i := 0; i < m; i++ {
+			scaled.data[i*n+j] /= scale[j]
+		}
+	}
+
+	q, r := scaled.QR()
+	k := r.shape[0]
+	largest := 0.0
+	for i := 0; i < k; i++ {
+		largest = math.Max(largest, math.Abs(r.data[i*n+i]))
+	}
+	rank := 0
+	for rank < k && math.Abs(r.data[rank*n+rank]) > 1e-12*largest && largest != 0 {
+		rank++
+	}
+
+	//solve with the leading rank x rank block of r
+	block := Zeros(rank, rank)
+	for i := 0; i < rank; i++ {
+		copy(block.data[i*rank+i:(i+1)*rank], r.data[i*n+i:i*n+rank])
+	}
+	nrhs := 1
+	if b.NDims() == 2 {
+		nrhs = b.shape[1]
+	}
+	c := MatMul(q.T(), b).Reshape(k, nrhs)
+	head := Zeros(rank, nrhs)
+	copy(head.data, c.data[:rank*nrhs])
+	y := block.SolveTriangular(head, false, false)
+	x := Zeros(n, nrhs)
+	for i := 0; i < rank; i++ {
+		for j := 0; j < nrhs; j++ {
+			x.data[i*nrhs+j] = y.data[i*nrhs+j] / scale[i]
+		}
+	}
+	if b.NDims() == 1 {
+		x = x.Reshape(n)
+	}
+
+	inv := Zeros(n, n)
+	if rank < n {
+		for i := range inv.data {
+			inv.data[i] = math.NaN()
+		}
+		return x, inv
+	}
+	//(a.T a)^-1 = s^-1 r^-1 r^-T s^-1 for the scaled columns a s^-1 = q r
+	rInv := block.SolveTriangular(Eye(n), false, false)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			v := 0.0
+			for l := 0; l < n; l++ {
+				v += rInv.data[i*n+l] * rInv.data[j*n+l]
+			}
+			inv.data[i*n+j] = v / (scale[i] * scale[j])
+		}
+	}
+
+	return x, inv
+}
+
+//(a.T a)^-1 for a [m, n] matrix, all NaN when a is rank deficient, see LstsqCov.
+func (a *NdArray) NormalInverse() *NdArray {
+	_, inv := a.LstsqCov(Zeros(a.shape[0]))
+	return inv
+}
+
+//Eigenvalues of a square matrix, as their real and imaginary parts, in no particular order.
+//Complex eigenvalues come in adjacent conjugate pairs. The matrix is balanced and reduced to
+//Hessenberg form, then the eigenvalues are found by the shifted QR algorithm,
+//which panics with "no convergence" after 30 iterations on one eigenvalue.
+func (a *NdArray) Eigvals() (*NdArray, *NdArray) {
+	n := a.squareOrder()
+	h := a.Clone()
+	balance(h.data, n)
+	hessenberg(h.data, n)
+
+	re, im := Zeros(n), Zeros(n)
+	hqr(h.data, n, re.data, im.data)
+
+	return re, im
+}
+
+//Scale rows and columns by powers of 2 so that their norms are close, which keeps the eigenvalues
+//and improves their accuracy.
+func balance(a []float64, n int) {
+	for done := false; !done; {
+		done = true
+		for i := 0; i < n; i++ {
+			r, c := 0.0, 0.0
+			for j := 0; j < n; j++ {
+				if j != i {
+					c += math.Abs(a[j*n+i])
+					r += math.Abs(a[i*n+j])
+				}
+			}
+			if c == 0 || r == 0 {
+				continue
+			}
+			s, f := c+r, 1.0
+			for c < r/2 {
+				f *= 2
+				c *= 4
+			}
+			for c > r*2 {
+				f /= 2
+				c /= 4
+			}
+			if (c+r)/f < 0.95*s {
+				done = false
+				for j := 0; j < n; j++ {
+					a[i*n+j] /= f
+					a[j*n+i] *= f
+				}
+			}
+		}
+	}
+}
+
+//Reduce to upper Hessenberg form by similarity transforms of Gaussian elimination with pivoting,
+//the elements below the subdiagonal are set to 0.
+func hessenberg(a []float64, n int) {
+	for m := 1; m < n-1; m++ {
+		x, p := 0.0, m
+		for j := m; j < n; j++ {
+			if math.Abs(a[j*n+m-1]) > math.Abs(x) {
+				x, p = a[j*n+m-1], j
+			}
+		}
+		if p != m {
+			for j := m - 1; j < n; j++ {
+				a[p*n+j], a[m*n+j] = a[m*n+j], a[p*n+j]
+			}
+			for j := 0; j < n; j++ {
+				a[j*n+p], a[j*n+m] = a[j*n+m], a[j*n+p]
+			}
+		}
+		if x == 0 {
+			continue
+		}
+		for i := m + 1; i < n; i++ {
+			y := a[i*n+m-1]
+			if y == 0 {
+				continue
+			}
+			y /= x
+			a[i*n+m-1] = 0
+			for j := m; j < n; j++ {
+				a[i*n+j] -= y * a[m*n+j]
+			}
+			for j := 0; j < n; j++ {
+				a[j*n+m] += y * a[j*n+i]
+			}
+		}
+	}
+}
+
+//Eigenvalues of the upper Hessenberg matrix a, which is destroyed, by the QR algorithm with
+//Francis double shifts and exceptional shifts after 10 and 20 iterations.
+func hqr(a []float64, n int, wr, wi []float64) {
+	eps := math.Nextafter(1, 2) - 1
+	at := func(i, j int) int { return i*n + j }
+	sign := func(v, s float64) float64 {
+		if s >= 0 {
+			return math.Abs(v)
+		}
+		return -math.Abs(v)
+	}
+
+	norm := 0.0
+	for i := 0; i < n; i++ {
+		for j := i - 1; j < n; j++ {
+			if j >= 0 {
+				norm += math.Abs(a[at(i, j)])
+			}
+		}
+	}
+
+	var p, q, r, s, w, x, y, z float64
+	t := 0.0
+	for nn := n - 1; nn >= 0; {
+		its, l := 0, 0
+		for {
+			//look for a small subdiagonal element that splits the matrix
+			for l = nn; l > 0; l-- {
+				s = math.Abs(a[at(l-1, l-1)]) + math.Abs(a[at(l, l)])
+				if s == 0 {
+					s = norm
+				}
+				if math.Abs(a[at(l, l-1)]) <= eps*s {
+					a[at(l, l-1)] = 0
+					break
+				}
+			}
+			x = a[at(nn, nn)]
+			if l == nn {
+				//one root found
+				wr[nn], wi[nn] = x+t, 0
+				nn--
+				break
+			}
+			y = a[at(nn-1, nn-1)]
+			w = a[at(nn, nn-1)] * a[at(nn-1, nn)]
+			if l == nn-1 {
+				//two roots found
+				p = 0.5 * (y - x)
+				q = p*p + w
+				z = math.Sqrt(math.Abs(q))
+				x += t
+				if q >= 0 {
+					z = p + sign(z, p)
+					wr[nn-1], wr[nn] = x+z, x+z
+					if z != 0 {
+						wr[nn] = x - w/z
+					}
+					wi[nn-1], wi[nn] = 0, 0
+				} else {
+					wr[nn-1], wr[nn] = x+p, x+p
+					wi[nn-1], wi[nn] = z, -z
+				}
+				nn -= 2
+				break
+			}
+
+			if its == 30 {
+				panic("no convergence")
+			}
+			if its == 10 || its == 20 {
+				t += x
+				for i := 0; i <= nn; i++ {
+					a[at(i, i)] -= x
+				}
+				s = math.Abs(a[at(nn, nn-1)]) + math.Abs(a[at(nn-1, nn-2)])
+				x, y = 0.75*s, 0.75*s
+				w = -0.4375 * s * s
+			}
+			its++
+
+			//two consecutive small subdiagonal elements
+			m := nn - 2
+			for ; m >= l; m-- {
+				z = a[at(m, m)]
+				r = x - z
+				s = y - z
+				p = (r*s-w)/a[at(m+1, m)] + a[at(m, m+1)]
+				q = a[at(m+1, m+1)] - z - r - s
+				r = a[at(m+2, m+1)]
+				s = math.Abs(p) + math.Abs(q) + math.Abs(r)
+				p /= s
+				q /= s
+				r /= s
+				if m == l {
+					break
+				}
+				u := math.Abs(a[at(m, m-1)]) * (math.Abs(q) + math.Abs(r))
+				v := math.Abs(p) * (math.Abs(a[at(m-1, m-1)]) + math.Abs(z) + math.Abs(a[at(m+1, m+1)]))
+				if u <= eps*v {
+					break
+				}
+			}
+			for i := m; i < nn-1; i++ {
+				a[at(i+2, i)] = 0
+				if i != m {
+					a[at(i+2, i-1)] = 0
+				}
+			}
+
+			//double QR step on rows l to nn and columns m to nn
+			for k := m; k < nn; k++ {
+				if k != m {
+					p = a[at(k, k-1)]
+					q = a[at(k+1, k-1)]
+					r = 0
+					if k+1 != nn {
+						r = a[at(k+2, k-1)]
+					}
+					if x = math.Abs(p) + math.Abs(q) + math.Abs(r); x != 0 {
+						p /= x
+						q /= x
+						r /= x
+					}
+				}
+				if s = sign(math.Sqrt(p*p+q*q+r*r), p); s == 0 {
+					continue
+				}
+				if k == m {
+					if l != m {
+						a[at(k, k-1)] = -a[at(k, k-1)]
+					}
+				} else {
+					a[at(k, k-1)] = -s * x
+				}
+				p += s
+				x = p / s
+				y = q / s
+				z = r / s
+				q /= p
+				r /= p
+				for j := k; j <= nn; j++ {
+					p = a[at(k, j)] + q*a[at(k+1, j)]
+					if k+1 != nn {
+						p += r * a[at(k+2, j)]
+						a[at(k+2, j)] -= p * z
+					}
+					a[at(k+1, j)] -= p * y
+					a[at(k, j)] -= p * x
+				}
+				last := k + 3
+				if nn < last {
+					last = nn
+				}
+				for i := l; i <= last; i++ {
+					p = x*a[at(i, k)] + y*a[at(i, k+1)]
+					if k+1 != nn {
+						p += z * a[at(i, k+2)]
+						a[at(i, k+2)] -= p * r
+					}
+					a[at(i, k+1)] -= p * q
+					a[at(i, k)] -= p
+				}
+			}
+		}
+	}
+}
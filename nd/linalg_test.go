@@ -0,0 +1,271 @@
+package nd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIdentity(t *testing.T) {
+	e := Identity(2, 3, 1)
+
+	if !e.Equals(Array(0, 1, 0, 0, 0, 1).Reshape(2, 3)) {
+		t.Error("Expected [[0,1,0],[0,0,1]], got ", e)
+	}
+
+	e = Identity(3, 2, -1)
+
+	if !e.Equals(Array(0, 0, 1, 0, 0, 1).Reshape(3, 2)) {
+		t.Error("Expected [[0,0],[1,0],[0,1]], got ", e)
+	}
+
+	if !Identity(3, 3, 0).Equals(Eye(3)) {
+		t.Error("Expected Eye(3), got ", Identity(3, 3, 0))
+	}
+}
+
+func TestDiag(t *testing.T) {
+	a := Arange(9).Reshape(3, 3)
+
+	if !a.Diag(0).Equals(Array(0, 4, 8)) {
+		t.Error("Expected [0,4,8], got ", a.Diag(0))
+	}
+	if !a.Diag(1).Equals(Array(1, 5)) {
+		t.Error("Expected [1,5], got ", a.Diag(1))
+	}
+	if !a.Diag(-2).Equals(Array(6)) {
+		t.Error("Expected [6], got ", a.Diag(-2))
+	}
+
+	m := Array(1, 2).Diag(-1)
+	if !m.Equals(Array(0, 0, 0, 1, 0, 0, 0, 2, 0).Reshape(3, 3)) {
+		t.Error("Expected [[0,0,0],[1,0,0],[0,2,0]], got ", m)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	Arange(8).Reshape(2, 2, 2).Diag(0)
+}
+
+func TestTrace(t *testing.T) {
+	a := Arange(9).Reshape(3, 3)
+
+	if a.Trace() != 12 {
+		t.Error("Expected 12, got ", a.Trace())
+	}
+}
+
+func TestTriuTril(t *testing.T) {
+	a := Array(1, 2, 3, 4, 5, 6, 7, 8, 9).Reshape(3, 3)
+
+	if !a.Triu(0).Equals(Array(1, 2, 3, 0, 5, 6, 0, 0, 9).Reshape(3, 3)) {
+		t.Error("Expected [[1,2,3],[0,5,6],[0,0,9]], got ", a.Triu(0))
+	}
+	if !a.Triu(1).Equals(Array(0, 2, 3, 0, 0, 6, 0, 0, 0).Reshape(3, 3)) {
+		t.Error("Expected [[0,2,3],[0,0,6],[0,0,0]], got ", a.Triu(1))
+	}
+	if !a.Tril(0).Equals(Array(1, 0, 0, 4, 5, 0, 7, 8, 9).Reshape(3, 3)) {
+		t.Error("Expected [[1,0,0],[4,5,0],[7,8,9]], got ", a.Tril(0))
+	}
+	if !a.Tril(-1).Equals(Array(0, 0, 0, 4, 0, 0, 7, 8, 0).Reshape(3, 3)) {
+		t.Error("Expected [[0,0,0],[4,0,0],[7,8,0]], got ", a.Tril(-1))
+	}
+	if !a.Triu(0).Add(a.Tril(-1)).Equals(a) {
+		t.Error("Expected triu + tril == a")
+	}
+}
+
+func TestSolveTriangular(t *testing.T) {
+	l := Array(2, 0, 0, 1, 3, 0, 4, 5, 6).Reshape(3, 3)
+	x := Array(1, 2, 3)
+
+	b := l.Dot(x).Reshape(3)
+	if got := l.SolveTriangular(b, true, false); !got.Equals(x) {
+		t.Error("Expected [1,2,3], got ", got)
+	}
+
+	b = l.T().Dot(x).Reshape(3)
+	if got := l.SolveTriangular(b, true, true); !got.Equals(x) {
+		t.Error("Expected [1,2,3], got ", got)
+	}
+
+	u := l.T()
+	xs := Array(1, 2, 3, 4, 5, 6).Reshape(3, 2)
+	bs := u.Dot(xs)
+	if got := u.SolveTriangular(bs, false, false); !got.Equals(xs) {
+		t.Error("Expected [[1,2],[3,4],[5,6]], got ", got)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "singular matrix" {
+			t.Error("Expected 'singular matrix', got ", p)
+		}
+	}()
+	Zeros(2, 2).SolveTriangular(Array(1, 1), true, false)
+}
+
+func TestSolveTridiagonal(t *testing.T) {
+	dl := Array(1, 2, 3)
+	d := Array(0, 5, 1, 2)
+	du := Array(4, 1, 7)
+	a := Array(
+		0, 4, 0, 0,
+		1, 5, 1, 0,
+		0, 2, 1, 7,
+		0, 0, 3, 2).Reshape(4, 4)
+	x := Array(1, -1, 2, 0.5)
+
+	b := a.Dot(x).Reshape(4)
+	if got := SolveTridiagonal(dl, d, du, b); !got.Equals(x) {
+		t.Error("Expected [1,-1,2,0.5], got ", got)
+	}
+
+	xs := HStack(x, Array(2, 0, 1, 3))
+	bs := a.Dot(xs)
+	if got := SolveTridiagonal(dl, d, du, bs); !got.Equals(xs) {
+		t.Error("Expected ", xs, ", got ", got)
+	}
+}
+
+func TestCholesky(t *testing.T) {
+	a := Array(4, 2, 2, 2, 5, 3, 2, 3, 6).Reshape(3, 3)
+	l := a.Cholesky()
+
+	if !l.Equals(l.Tril(0)) {
+		t.Error("Expected a lower triangular matrix, got ", l)
+	}
+	if !l.Dot(l.T()).Equals(a) {
+		t.Error("Expected l l.T() == a, got ", l.Dot(l.T()))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "matrix is not positive definite" {
+			t.Error("Expected 'matrix is not positive definite', got ", p)
+		}
+	}()
+	Array(1, 2, 2, 1).Reshape(2, 2).Cholesky()
+}
+
+func TestQR(t *testing.T) {
+	a := Array(12, -51, 4, 6, 167, -68, -4, 24, -41, 1, 2, 3).Reshape(4, 3)
+	q, r := a.QR()
+
+	if !MatMul(q, r).Equals(a) {
+		t.Error("Expected q r == a, got ", MatMul(q, r))
+	}
+	if !MatMul(q.T(), q).Equals(Eye(3)) {
+		t.Error("Expected orthonormal columns, got ", MatMul(q.T(), q))
+	}
+	if !r.Equals(r.Triu(0)) || r.Shape()[0] != 3 {
+		t.Error("Expected a [3, 3] upper triangular matrix, got ", r)
+	}
+
+	//more columns than rows
+	q, r = a.T().QR()
+	if !MatMul(q, r).Equals(a.T()) || r.Shape()[0] != 3 || r.Shape()[1] != 4 {
+		t.Error("Expected q r == a.T() with r [3, 4], got ", q, r)
+	}
+}
+
+func TestLstsq(t *testing.T) {
+	//fit y = 1 + 2x exactly, then with a residual
+	a := Array(1, 0, 1, 1, 1, 2, 1, 3).Reshape(4, 2)
+	if x := a.Lstsq(Array(1, 3, 5, 7)); !x.Equals(Array(1, 2)) {
+		t.Error("Expected [1, 2], got ", x)
+	}
+	if x := a.Lstsq(Array(1, 3, 5, 8)); !x.Equals(Array(0.8, 2.3)) {
+		t.Error("Expected [0.8, 2.3], got ", x)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "rank deficient matrix" {
+			t.Error("Expected 'rank deficient matrix', got ", p)
+		}
+	}()
+	Array(1, 2, 2, 4, 3, 6).Reshape(3, 2).Lstsq(Array(1, 2, 3))
+}
+
+func TestLstsqCov(t *testing.T) {
+	a := Array(1, 0, 1, 1, 1, 2, 1, 3).Reshape(4, 2)
+	x, inv := a.LstsqCov(Array(1, 3, 5, 8))
+	if !x.Equals(Array(0.8, 2.3)) {
+		t.Error("Expected [0.8, 2.3], got ", x)
+	}
+	//(a.T a)^-1 = [[4, 6], [6, 14]]^-1
+	if want := Array(0.7, -0.3, -0.3, 0.2).Reshape(2, 2); !inv.Equals(want) || !a.NormalInverse().Equals(want) {
+		t.Error("Expected ", want, ", got ", inv)
+	}
+	x, _ = a.LstsqCov(Array(1, 1, 3, 3, 5, 5, 8, 8).Reshape(4, 2))
+	if !x.Equals(Array(0.8, 0.8, 2.3, 2.3).Reshape(2, 2)) {
+		t.Error("Expected [[0.8, 0.8], [2.3, 2.3]], got ", x)
+	}
+
+	//a Vandermonde matrix of 1, x, x^2 with only two distinct x, and one with two rows
+	for _, c := range []struct{ a, b *NdArray }{
+		{Array(1, 1, 1, 1, 1, 1, 1, 2, 4, 1, 2, 4).Reshape(4, 3), Array(1, 1, 3, 3)},
+		{Array(1, 1, 1, 1, 2, 4).Reshape(2, 3), Array(1, 3)},
+	} {
+		x, inv = c.a.LstsqCov(c.b)
+		if !x.Equals(Array(-1, 2, 0)) {
+			t.Error("Expected the line [-1, 2, 0], got ", x)
+		}
+		for _, v := range inv.Values() {
+			if !math.IsNaN(v) {
+				t.Error("Expected a NaN inverse, got ", inv)
+				break
+			}
+		}
+	}
+}
+
+func TestEigvals(t *testing.T) {
+	//sorted by real then imaginary part
+	sorted := func(a *NdArray) ([]float64, []float64) {
+		re, im := a.Eigvals()
+		rs, is := re.Values(), im.Values()
+		for i := range rs {
+			for j := i + 1; j < len(rs); j++ {
+				if rs[j] < rs[i]-1e-9 || (math.Abs(rs[j]-rs[i]) <= 1e-9 && is[j] < is[i]) {
+					rs[i], rs[j] = rs[j], rs[i]
+					is[i], is[j] = is[j], is[i]
+				}
+			}
+		}
+		return rs, is
+	}
+
+	re, im := sorted(Array(4, 1, 2, 3).Reshape(2, 2))
+	if !Array(re...).Equals(Array(2, 5)) || !Array(im...).Equals(Zeros(2)) {
+		t.Error("Expected [2, 5], got ", re, im)
+	}
+	re, im = sorted(Array(0, -1, 1, 0).Reshape(2, 2))
+	if !Array(re...).Equals(Zeros(2)) || !Array(im...).Equals(Array(-1, 1)) {
+		t.Error("Expected [-i, i], got ", re, im)
+	}
+	//companion matrix of (x - 1)(x - 2)(x - 3)
+	re, im = sorted(Array(6, -11, 6, 1, 0, 0, 0, 1, 0).Reshape(3, 3))
+	if !Array(re...).Equals(Array(1, 2, 3)) || !Array(im...).Equals(Zeros(3)) {
+		t.Error("Expected [1, 2, 3], got ", re, im)
+	}
+
+	//the eigenvalues sum to the trace and multiply to the determinant, 243
+	a := Array(2, -1, 0, 3, 1, 4, 1, -2, 0, 5, 3, 1, -1, 2, 0, 6, 1, 1, 2, 0, 3, 0, -3, 1, 2).Reshape(5, 5)
+	re, im = sorted(a)
+	sum, prod := complex(0, 0), complex(1, 0)
+	for i := range re {
+		sum += complex(re[i], im[i])
+		prod *= complex(re[i], im[i])
+	}
+	if math.Abs(real(sum)-a.Trace()) > 1e-9 || math.Abs(imag(sum)) > 1e-9 {
+		t.Error("Expected a sum of ", a.Trace(), ", got ", sum)
+	}
+	if math.Abs(real(prod)-243) > 1e-8 || math.Abs(imag(prod)) > 1e-8 {
+		t.Error("Expected a product of 243, got ", prod)
+	}
+}
@@ -27,6 +27,49 @@ func (A *NdArray) Map(f func(e float64) float64) *NdArray {
 	return tn
 }
 
+//Return 1 where the element is NaN, and 0 elsewhere.
+func (a *NdArray) IsNaN() *NdArray {
+	return a.Map(func(e float64) float64 {
+		return boolToFloat(math.IsNaN(e))
+	})
+}
+
+//Return 1 where the element is positive or negative infinity, and 0 elsewhere.
+func (a *NdArray) IsInf() *NdArray {
+	return a.Map(func(e float64) float64 {
+		return boolToFloat(math.IsInf(e, 0))
+	})
+}
+
+//Return 1 where the element is neither NaN nor infinity, and 0 elsewhere.
+func (a *NdArray) IsFinite() *NdArray {
+	return a.Map(func(e float64) float64 {
+		return boolToFloat(!math.IsNaN(e) && !math.IsInf(e, 0))
+	})
+}
+
+//Replace NaN with nan, positive infinity with posInf and negative infinity with negInf.
+func (a *NdArray) NanToNum(nan, posInf, negInf float64) *NdArray {
+	return a.Map(func(e float64) float64 {
+		switch {
+		case math.IsNaN(e):
+			return nan
+		case math.IsInf(e, 1):
+			return posInf
+		case math.IsInf(e, -1):
+			return negInf
+		}
+		return e
+	})
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 //如果A是一维的NdArray，则返回一个长度1的切片，里面是A中不等于零的元素的下标。
 //如果A是2维的NdArray，则返回一个长度2的切片，里面第一个切片是A中不等于0的元素的行下标，第二个切片是A中不等于0的元素的列下标。
 func (A *NdArray) NonZero() [][]int {
@@ -79,6 +122,64 @@ func (a *NdArray) Ravel() *NdArray {
 	}
 }
 
+//Permute the dimensions of an array, the ith axis of the result is axes[i] of a.
+//With no axes given, the order of the dimensions is reversed. A copy is made.
+func (a *NdArray) Transpose(axes ...int) *NdArray {
+	n := a.NDims()
+	if len(axes) == 0 {
+		axes = make([]int, n)
+		for i := range axes {
+			axes[i] = n - 1 - i
+		}
+	}
+	if len(axes) != n {
+		panic("shape error")
+	}
+
+	seen := make([]bool, n)
+	newShape := make([]int, n)
+	srcStrides := make([]int, n)
+	strides := stridesOf(a.shape)
+	for i, ax := range axes {
+		if ax < 0 || ax >= n || seen[ax] {
+			panic(fmt.Errorf("axes %v is not a permutation", axes))
+		}
+		seen[ax] = true
+		newShape[i] = a.shape[ax]
+		srcStrides[i] = strides[ax]
+	}
+
+	tn := Zeros(newShape...)
+	idx := make([]int, n)
+	src := 0
+	for k := range tn.data {
+		tn.data[k] = a.data[src]
+		for d := n - 1; d >= 0; d-- {
+			idx[d]++
+			src += srcStrides[d]
+			if idx[d] < newShape[d] {
+				break
+			}
+			src -= idx[d] * srcStrides[d]
+			idx[d] = 0
+		}
+	}
+
+	return tn
+}
+
+//row major strides of shape, in elements.
+func stridesOf(shape []int) []int {
+	strides := make([]int, len(shape))
+	s := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		strides[i] = s
+		s *= shape[i]
+	}
+
+	return strides
+}
+
 //View inputs as arrays with at least two dimensions.
 func (a *NdArray) Atleast2D() *NdArray {
 	if len(a.shape) == 1 {
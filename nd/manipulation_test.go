@@ -550,3 +550,29 @@ func TestNdDiv(t *testing.T) {
 		t.Error("Expected [[0.5, 1, 1.5],[2, 2.5, 3]], got ", a3)
 	}
 }
+
+func TestIsNaNIsInf(t *testing.T) {
+	a := Array(1, math.NaN(), math.Inf(1), math.Inf(-1))
+
+	if !a.IsNaN().Equals(Array(0, 1, 0, 0)) {
+		t.Error("Expected [0, 1, 0, 0], got ", a.IsNaN())
+	}
+	if !a.IsInf().Equals(Array(0, 0, 1, 1)) {
+		t.Error("Expected [0, 0, 1, 1], got ", a.IsInf())
+	}
+	if !a.IsFinite().Equals(Array(1, 0, 0, 0)) {
+		t.Error("Expected [1, 0, 0, 0], got ", a.IsFinite())
+	}
+}
+
+func TestNanToNum(t *testing.T) {
+	a := Array(1, math.NaN(), math.Inf(1), math.Inf(-1)).Reshape(2, 2)
+	b := a.NanToNum(0, 100, -100)
+
+	if !b.Equals(Array(1, 0, 100, -100).Reshape(2, 2)) {
+		t.Error("Expected [[1, 0], [100, -100]], got ", b)
+	}
+	if !math.IsNaN(a.Get(0, 1)) {
+		t.Error("Expected a to be kept, got ", a)
+	}
+}
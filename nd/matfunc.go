@@ -0,0 +1,234 @@
+package nd
+
+import (
+	"fmt"
+	"math"
+)
+
+//Raise a square matrix to the integer power n.
+//n == 0 gives the identity, and n < 0 the power of the inverse of a.
+func (a *NdArray) MatrixPower(n int) *NdArray {
+	size := a.squareOrder()
+	if n == 0 {
+		return Eye(size)
+	}
+
+	base := a
+	if n < 0 {
+		base = a.Solve(Eye(size))
+		n = -n
+	}
+
+	//binary exponentiation
+	var res *NdArray
+	for n > 0 {
+		if n&1 == 1 {
+			if res == nil {
+				res = base.Clone()
+			} else {
+				res = res.matMul(base)
+			}
+		}
+		n >>= 1
+		if n > 0 {
+			base = base.matMul(base)
+		}
+	}
+
+	return res
+}
+
+var (
+	padeThetas = []float64{1.495585217958292e-2, 2.539398330063230e-1, 9.504178996162932e-1, 2.097847961257068, 5.371920351148152}
+	padeOrders = []int{3, 5, 7, 9, 13}
+	padeCoefs  = map[int][]float64{
+		3:  {120, 60, 12, 1},
+		5:  {30240, 15120, 3360, 420, 30, 1},
+		7:  {17297280, 8648640, 1995840, 277200, 25200, 1512, 56, 1},
+		9:  {17643225600, 8821612800, 2075673600, 302702400, 30270240, 2162160, 110880, 3960, 90, 1},
+		13: {64764752532480000, 32382376266240000, 7771770303897600, 1187353796428800, 129060195264000, 10559470521600, 670442572800, 33522128640, 1323241920, 40840800, 960960, 16380, 182, 1},
+	}
+)
+
+//Matrix exponential, computed by the scaling and squaring method with Padé approximants (Higham 2005).
+//Unlike Exp(), which is elementwise, this is the sum of a^k/k! over k.
+func (a *NdArray) Expm() *NdArray {
+	a.squareOrder()
+	norm := a.norm1()
+
+	for i, theta := range padeThetas[:len(padeThetas)-1] {
+		if norm <= theta {
+			u, v := a.padeUV(padeOrders[i])
+			return v.Sub(u).Solve(v.Add(u))
+		}
+	}
+
+	s := 0
+	if norm > padeThetas[len(padeThetas)-1] {
+		s = int(math.Ceil(math.Log2(norm / padeThetas[len(padeThetas)-1])))
+	}
+	scaled := a.Mul(Array(math.Pow(2, -float64(s))))
+	u, v := scaled.padeUV(13)
+	r := v.Sub(u).Solve(v.Add(u))
+	for i := 0; i < s; i++ {
+		r = r.matMul(r)
+	}
+
+	return r
+}
+
+//numerator and denominator parts of the [m/m] Padé approximant of exp, exp(a) ~ (v-u)^-1 (v+u).
+func (a *NdArray) padeUV(m int) (*NdArray, *NdArray) {
+	b := padeCoefs[m]
+	n := a.shape[0]
+	ident := Eye(n)
+	a2 := a.matMul(a)
+
+	if m == 13 {
+		a4 := a2.matMul(a2)
+		a6 := a4.matMul(a2)
+		u := a6.matMul(a6.scaled(b[13]).Add(a4.scaled(b[11])).Add(a2.scaled(b[9])))
+		u = u.Add(a6.scaled(b[7])).Add(a4.scaled(b[5])).Add(a2.scaled(b[3])).Add(ident.scaled(b[1]))
+		u = a.matMul(u)
+		v := a6.matMul(a6.scaled(b[12]).Add(a4.scaled(b[10])).Add(a2.scaled(b[8])))
+		v = v.Add(a6.scaled(b[6])).Add(a4.scaled(b[4])).Add(a2.scaled(b[2])).Add(ident.scaled(b[0]))
+		return u, v
+	}
+
+	u := ident.scaled(b[1])
+	v := ident.scaled(b[0])
+	pow := ident
+	for k := 2; k <= m; k += 2 {
+		pow = pow.matMul(a2)
+		v = v.Add(pow.scaled(b[k]))
+		u = u.Add(pow.scaled(b[k+1]))
+	}
+
+	return a.matMul(u), v
+}
+
+//Principal square root of a square matrix by the Denman-Beavers iteration.
+//a must not have eigenvalues on the closed negative real axis.
+func (a *NdArray) Sqrtm() *NdArray {
+	n := a.squareOrder()
+	y, z := a.Clone(), Eye(n)
+	prevDiff := math.Inf(1)
+	for iter := 0; iter < 100; iter++ {
+		yInv, zInv := y.Solve(Eye(n)), z.Solve(Eye(n))
+		nextY := y.Add(zInv).scaled(0.5)
+		z = z.Add(yInv).scaled(0.5)
+
+		//stop once converged, or when rounding errors keep the steps from shrinking
+		diff := nextY.Sub(y).norm1() / nextY.norm1()
+		y = nextY
+		if diff <= 1e-14 || (diff <= 1e-8 && diff >= prevDiff) {
+			return y
+		}
+		prevDiff = diff
+	}
+
+	panic(fmt.Errorf("Sqrtm did not converge"))
+}
+
+//Principal logarithm of a square matrix by inverse scaling and squaring:
+//square roots are taken until a is close to the identity, then log(I + x) is evaluated
+//with Gauss-Legendre quadrature of the integral of (I + t x)^-1 x over [0, 1].
+//a must not have eigenvalues on the closed negative real axis.
+func (a *NdArray) Logm() *NdArray {
+	n := a.squareOrder()
+	ident := Eye(n)
+
+	k := 0
+	x := a.Clone()
+	for x.Sub(ident).norm1() > 0.25 {
+		if k >= 64 {
+			panic(fmt.Errorf("Logm did not converge"))
+		}
+		x = x.Sqrtm()
+		k++
+	}
+	x = x.Sub(ident)
+
+	nodes, weights := gaussLegendre(8)
+	log := Zeros(n, n)
+	for i := range nodes {
+		//map the nodes from [-1, 1] to [0, 1]
+		t := (nodes[i] + 1) / 2
+		term := ident.Add(x.scaled(t)).Solve(x)
+		log = log.Add(term.scaled(weights[i] / 2))
+	}
+
+	return log.scaled(math.Pow(2, float64(k)))
+}
+
+//nodes and weights of the m points Gauss-Legendre quadrature on [-1, 1].
+func gaussLegendre(m int) ([]float64, []float64) {
+	nodes, weights := make([]float64, m), make([]float64, m)
+	for i := 0; i < (m+1)/2; i++ {
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(m) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			p0, p1 := 1.0, x
+			for j := 2; j <= m; j++ {
+				p0, p1 = p1, ((2*float64(j)-1)*x*p1-(float64(j)-1)*p0)/float64(j)
+			}
+			dp = float64(m) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		nodes[i], nodes[m-1-i] = -x, x
+		weights[i] = 2 / ((1 - x*x) * dp * dp)
+		weights[m-1-i] = weights[i]
+	}
+
+	return nodes, weights
+}
+
+//matrix product of two matrices.
+func (a *NdArray) matMul(b *NdArray) *NdArray {
+	if a.NDims() != 2 || b.NDims() != 2 || a.shape[1] != b.shape[0] {
+		panic("shape error")
+	}
+
+	return &NdArray{
+		shape: []int{a.shape[0], b.shape[1]},
+		data:  matMul(a.data, b.data, a.shape[0], a.shape[1], b.shape[1]),
+	}
+}
+
+//every element multiplied by v.
+func (a *NdArray) scaled(v float64) *NdArray {
+	tn := Zeros(a.shape...)
+	for i := range tn.data {
+		tn.data[i] = a.data[i] * v
+	}
+
+	return tn
+}
+
+//maximum absolute column sum of a matrix.
+func (a *NdArray) norm1() float64 {
+	norm := 0.0
+	for j := 0; j < a.shape[1]; j++ {
+		sum := 0.0
+		for i := 0; i < a.shape[0]; i++ {
+			sum += math.Abs(a.data[i*a.shape[1]+j])
+		}
+		if sum > norm {
+			norm = sum
+		}
+	}
+
+	return norm
+}
+
+//check that a is a square matrix, and return its order.
+func (a *NdArray) squareOrder() int {
+	if a.NDims() != 2 || a.shape[0] != a.shape[1] {
+		panic("shape error")
+	}
+	return a.shape[0]
+}
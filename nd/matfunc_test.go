@@ -0,0 +1,91 @@
+package nd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	a := Array(0, 2, 1, 1, 1, 0, 3, 0, 1).Reshape(3, 3)
+	x := Array(1, -2, 3)
+
+	b := a.Dot(x).Reshape(3)
+	if got := a.Solve(b); !got.Equals(x) {
+		t.Error("Expected [1,-2,3], got ", got)
+	}
+
+	if got := a.Solve(Eye(3)); !a.Dot(got).Equals(Eye(3)) {
+		t.Error("Expected the inverse of a, got ", got)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "singular matrix" {
+			t.Error("Expected 'singular matrix', got ", p)
+		}
+	}()
+	Array(1, 2, 2, 4).Reshape(2, 2).Solve(Array(1, 1))
+}
+
+func TestMatrixPower(t *testing.T) {
+	a := Array(1, 1, 0, 1).Reshape(2, 2)
+
+	if !a.MatrixPower(0).Equals(Eye(2)) {
+		t.Error("Expected Eye(2), got ", a.MatrixPower(0))
+	}
+	if !a.MatrixPower(5).Equals(Array(1, 5, 0, 1).Reshape(2, 2)) {
+		t.Error("Expected [[1,5],[0,1]], got ", a.MatrixPower(5))
+	}
+	if !a.MatrixPower(-3).Equals(Array(1, -3, 0, 1).Reshape(2, 2)) {
+		t.Error("Expected [[1,-3],[0,1]], got ", a.MatrixPower(-3))
+	}
+}
+
+func TestExpm(t *testing.T) {
+	if !Zeros(3, 3).Expm().Equals(Eye(3)) {
+		t.Error("Expected Eye(3), got ", Zeros(3, 3).Expm())
+	}
+
+	d := Array(1, 0, 0, -2).Reshape(2, 2)
+	if !d.Expm().Equals(Array(math.E, 0, 0, math.Exp(-2)).Reshape(2, 2)) {
+		t.Error("Expected diag(e, e^-2), got ", d.Expm())
+	}
+
+	//rotation generator, exp gives a rotation by theta
+	theta := 7.0
+	r := Array(0, -theta, theta, 0).Reshape(2, 2).Expm()
+	expected := Array(math.Cos(theta), -math.Sin(theta), math.Sin(theta), math.Cos(theta)).Reshape(2, 2)
+	if !r.Equals(expected) {
+		t.Error("Expected ", expected, ", got ", r)
+	}
+
+	n := Array(0, 1, 0, 0).Reshape(2, 2)
+	if !n.Expm().Equals(Array(1, 1, 0, 1).Reshape(2, 2)) {
+		t.Error("Expected [[1,1],[0,1]], got ", n.Expm())
+	}
+}
+
+func TestSqrtm(t *testing.T) {
+	a := Array(4, 1, 0, 9).Reshape(2, 2)
+	s := a.Sqrtm()
+
+	if !s.Dot(s).Equals(a) {
+		t.Error("Expected sqrtm(a)^2 == a, got ", s.Dot(s))
+	}
+	if !s.Equals(Array(2, 0.2, 0, 3).Reshape(2, 2)) {
+		t.Error("Expected [[2,0.2],[0,3]], got ", s)
+	}
+}
+
+func TestLogm(t *testing.T) {
+	a := Array(4, 1, 0.5, 0.8, 3, 0.2, 0.5, 0.1, 2).Reshape(3, 3)
+
+	if !a.Logm().Expm().Equals(a) {
+		t.Error("Expected expm(logm(a)) == a, got ", a.Logm().Expm())
+	}
+
+	d := Array(math.E, 0, 0, 1).Reshape(2, 2)
+	if !d.Logm().Equals(Array(1, 0, 0, 0).Reshape(2, 2)) {
+		t.Error("Expected [[1,0],[0,0]], got ", d.Logm())
+	}
+}
@@ -0,0 +1,236 @@
+package nd
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/util"
+)
+
+//Outer product of two arrays, both are flattened first.
+//The result has shape [a.Size(), b.Size()].
+func Outer(a, b *NdArray) *NdArray {
+	tn := Zeros(a.Size(), b.Size())
+	for i, va := range a.data {
+		row := tn.data[i*b.Size() : (i+1)*b.Size()]
+		for j, vb := range b.data {
+			row[j] = va * vb
+		}
+	}
+
+	return tn
+}
+
+//Inner product of two arrays, which is a sum product over the last axes.
+//The result has shape a.shape[:-1] + b.shape[:-1], or [1] when both are 1d.
+func Inner(a, b *NdArray) *NdArray {
+	if a.NDims() == 0 || b.NDims() == 0 || a.shape[a.NDims()-1] != b.shape[b.NDims()-1] {
+		panic("shape error")
+	}
+
+	return TensorDot(a, b, []int{a.NDims() - 1}, []int{b.NDims() - 1})
+}
+
+//Kronecker product, a composite array made of blocks of b scaled by a.
+//The array with fewer dimensions is prepended with ones.
+func Kron(a, b *NdArray) *NdArray {
+	n := a.NDims()
+	if b.NDims() > n {
+		n = b.NDims()
+	}
+	sa, sb := padShape(a.shape, n), padShape(b.shape, n)
+	shape := make([]int, n)
+	for i := range shape {
+		shape[i] = sa[i] * sb[i]
+	}
+
+	tn := Zeros(shape...)
+	strides := stridesOf(shape)
+	ia, ib := make([]int, n), make([]int, n)
+	for p, va := range a.data {
+		unravel(p, sa, ia)
+		for q, vb := range b.data {
+			unravel(q, sb, ib)
+			pos := 0
+			for d := 0; d < n; d++ {
+				pos += (ia[d]*sb[d] + ib[d]) * strides[d]
+			}
+			tn.data[pos] = va * vb
+		}
+	}
+
+	return tn
+}
+
+//Sum products over the axes axesA of a and axesB of b, which must have equal lengths.
+//The result has the remaining axes of a followed by the remaining axes of b,
+//or shape [1] when all axes are summed over.
+func TensorDot(a, b *NdArray, axesA, axesB []int) *NdArray {
+	if len(axesA) != len(axesB) {
+		panic("shape error")
+	}
+
+	inA, inB := make([]bool, a.NDims()), make([]bool, b.NDims())
+	k := 1
+	for i := range axesA {
+		if axesA[i] < 0 || axesA[i] >= a.NDims() || axesB[i] < 0 || axesB[i] >= b.NDims() {
+			panic(fmt.Errorf("axes %v, %v out of range", axesA, axesB))
+		}
+		if a.shape[axesA[i]] != b.shape[axesB[i]] {
+			panic("shape error")
+		}
+		inA[axesA[i]], inB[axesB[i]] = true, true
+		k *= a.shape[axesA[i]]
+	}
+
+	//move the summed axes of a to the end, and those of b to the front
+	permA, permB := []int{}, []int{}
+	shape := []int{}
+	for i := range inA {
+		if !inA[i] {
+			permA = append(permA, i)
+			shape = append(shape, a.shape[i])
+		}
+	}
+	permA = append(permA, axesA...)
+	permB = append(permB, axesB...)
+	for i := range inB {
+		if !inB[i] {
+			permB = append(permB, i)
+			shape = append(shape, b.shape[i])
+		}
+	}
+
+	m, n := a.Size()/k, b.Size()/k
+	data := matMul(a.Transpose(permA...).data, b.Transpose(permB...).data, m, k, n)
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+
+	return &NdArray{
+		shape: shape,
+		data:  data,
+	}
+}
+
+//Matrix product of two arrays with numpy matmul semantics.
+//1d arguments are promoted to matrices and the added dimension is removed afterwards,
+//dimensions before the last two are batch dimensions and are broadcast against each other.
+func MatMul(a, b *NdArray) *NdArray {
+	if a.NDims() == 0 || b.NDims() == 0 {
+		panic("shape error")
+	}
+
+	sa, sb := a.shape, b.shape
+	if a.NDims() == 1 {
+		sa = []int{1, a.shape[0]}
+	}
+	if b.NDims() == 1 {
+		sb = []int{b.shape[0], 1}
+	}
+	m, k, n := sa[len(sa)-2], sa[len(sa)-1], sb[len(sb)-1]
+	if k != sb[len(sb)-2] {
+		panic("shape error")
+	}
+
+	batchA, batchB := sa[:len(sa)-2], sb[:len(sb)-2]
+	batch := broadcastShapes(batchA, batchB)
+	nb := util.ProductOfIntSlice(batch)
+	data := make([]float64, 0, nb*m*n)
+	idx := make([]int, len(batch))
+	for p := 0; p < nb; p++ {
+		unravel(p, batch, idx)
+		offA := broadcastOffset(idx, batchA) * m * k
+		offB := broadcastOffset(idx, batchB) * k * n
+		data = append(data, matMul(a.data[offA:offA+m*k], b.data[offB:offB+k*n], m, k, n)...)
+	}
+
+	shape := append([]int{}, batch...)
+	if a.NDims() > 1 {
+		shape = append(shape, m)
+	}
+	if b.NDims() > 1 {
+		shape = append(shape, n)
+	}
+	if len(shape) == 0 {
+		shape = []int{1}
+	}
+
+	return &NdArray{
+		shape: shape,
+		data:  data,
+	}
+}
+
+//product of the row major matrices a [m, k] and b [k, n].
+func matMul(a, b []float64, m, k, n int) []float64 {
+	c := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		row := c[i*n : (i+1)*n]
+		for l := 0; l < k; l++ {
+			v := a[i*k+l]
+			if v == 0 {
+				continue
+			}
+			for j, w := range b[l*n : (l+1)*n] {
+				row[j] += v * w
+			}
+		}
+	}
+
+	return c
+}
+
+//broadcast two shapes against each other, aligned at the last dimension.
+func broadcastShapes(a, b []int) []int {
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	pa, pb := padShape(a, n), padShape(b, n)
+	shape := make([]int, n)
+	for i := range shape {
+		switch {
+		case pa[i] == pb[i] || pb[i] == 1:
+			shape[i] = pa[i]
+		case pa[i] == 1:
+			shape[i] = pb[i]
+		default:
+			panic("shape error")
+		}
+	}
+
+	return shape
+}
+
+//flat position in shape of the broadcast multi index idx, shape may have fewer dimensions.
+func broadcastOffset(idx []int, shape []int) int {
+	off := 0
+	skip := len(idx) - len(shape)
+	for i, s := range shape {
+		off *= s
+		if s != 1 {
+			off += idx[skip+i]
+		}
+	}
+
+	return off
+}
+
+//prepend ones to shape until it has n dimensions.
+func padShape(shape []int, n int) []int {
+	padded := make([]int, n)
+	for i := range padded {
+		padded[i] = 1
+	}
+	copy(padded[n-len(shape):], shape)
+
+	return padded
+}
+
+//multi index of the flat position p in shape, written to idx.
+func unravel(p int, shape []int, idx []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		idx[d] = p % shape[d]
+		p /= shape[d]
+	}
+}
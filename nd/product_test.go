@@ -0,0 +1,121 @@
+package nd
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/util"
+)
+
+func TestTranspose(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+
+	if !a.Transpose().Equals(a.T()) {
+		t.Error("Expected ", a.T(), ", got ", a.Transpose())
+	}
+
+	b := Arange(24).Reshape(2, 3, 4)
+	bt := b.Transpose(2, 0, 1)
+	if !util.EqualOfIntSlice(bt.Shape(), []int{4, 2, 3}) {
+		t.Error("Expected [4 2 3], got ", bt.Shape())
+	}
+	if bt.Get(3, 1, 2) != b.Get(1, 2, 3) {
+		t.Error("Expected ", b.Get(1, 2, 3), ", got ", bt.Get(3, 1, 2))
+	}
+}
+
+func TestOuterInner(t *testing.T) {
+	a := Array(1, 2)
+	b := Array(3, 4, 5)
+
+	if !Outer(a, b).Equals(Array(3, 4, 5, 6, 8, 10).Reshape(2, 3)) {
+		t.Error("Expected [[3,4,5],[6,8,10]], got ", Outer(a, b))
+	}
+
+	if !Inner(b, b).Equals(Array(50)) {
+		t.Error("Expected [50], got ", Inner(b, b))
+	}
+
+	m := Arange(6).Reshape(2, 3)
+	if !Inner(m, m).Equals(m.Dot(m.T())) {
+		t.Error("Expected ", m.Dot(m.T()), ", got ", Inner(m, m))
+	}
+}
+
+func TestKron(t *testing.T) {
+	a := Array(1, 2, 3, 4).Reshape(2, 2)
+	b := Array(0, 1, 1, 0).Reshape(2, 2)
+	expected := Array(
+		0, 1, 0, 2,
+		1, 0, 2, 0,
+		0, 3, 0, 4,
+		3, 0, 4, 0).Reshape(4, 4)
+
+	if !Kron(a, b).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", Kron(a, b))
+	}
+
+	if !Kron(Array(1, 10), Array(1, 2)).Equals(Array(1, 2, 10, 20)) {
+		t.Error("Expected [1,2,10,20], got ", Kron(Array(1, 10), Array(1, 2)))
+	}
+}
+
+func TestTensorDot(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	b := Arange(12).Reshape(3, 4)
+
+	if !TensorDot(a, b, []int{1}, []int{0}).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", TensorDot(a, b, []int{1}, []int{0}))
+	}
+
+	c := Arange(24).Reshape(2, 3, 4)
+	d := Arange(6).Reshape(3, 2)
+	r := TensorDot(c, d, []int{0, 1}, []int{1, 0})
+	if !util.EqualOfIntSlice(r.Shape(), []int{4}) {
+		t.Error("Expected [4], got ", r.Shape())
+	}
+	if !r.Equals(Einsum("ijk,ji->k", c, d)) {
+		t.Error("Expected ", Einsum("ijk,ji->k", c, d), ", got ", r)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	TensorDot(a, b, []int{0}, []int{0})
+}
+
+func TestMatMul(t *testing.T) {
+	a := Arange(6).Reshape(2, 3)
+	b := Arange(12).Reshape(3, 4)
+
+	if !MatMul(a, b).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", MatMul(a, b))
+	}
+
+	v := Array(1, 0, 2)
+	if !MatMul(a, v).Equals(Array(4, 13)) {
+		t.Error("Expected [4,13], got ", MatMul(a, v))
+	}
+
+	batch := Arange(12).Reshape(2, 2, 3)
+	r := MatMul(batch, b)
+	if !util.EqualOfIntSlice(r.Shape(), []int{2, 2, 4}) {
+		t.Error("Expected [2 2 4], got ", r.Shape())
+	}
+	if !r.Ix(1).Equals(batch.Ix(1).Dot(b)) {
+		t.Error("Expected ", batch.Ix(1).Dot(b), ", got ", r.Ix(1))
+	}
+
+	//[2, 1, 2, 3] x [3, 3, 1] broadcasts to [2, 3, 2, 1]
+	x := Arange(12).Reshape(2, 1, 2, 3)
+	y := Arange(9).Reshape(3, 3, 1)
+	r = MatMul(x, y)
+	if !util.EqualOfIntSlice(r.Shape(), []int{2, 3, 2, 1}) {
+		t.Error("Expected [2 3 2 1], got ", r.Shape())
+	}
+	if !r.Ix(1, 2).Equals(x.Ix(1, 0).Dot(y.Ix(2))) {
+		t.Error("Expected ", x.Ix(1, 0).Dot(y.Ix(2)), ", got ", r.Ix(1, 2))
+	}
+}
@@ -0,0 +1,285 @@
+package random
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Seedable pseudo random generator based on xoshiro256**.
+//It only uses 64 bits integer arithmetic, so a seed gives the same sequence on every platform.
+//A Generator is not safe for concurrent use.
+type Generator struct {
+	s [4]uint64
+
+	//second variate of the last polar method draw
+	spare    float64
+	hasSpare bool
+}
+
+//Create a generator, the state is expanded from seed with splitmix64.
+func NewGenerator(seed uint64) *Generator {
+	g := &Generator{}
+	for i := range g.s {
+		seed += 0x9e3779b97f4a7c15
+		z := seed
+		z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+		z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+		g.s[i] = z ^ (z >> 31)
+	}
+
+	return g
+}
+
+//Next 64 random bits.
+func (g *Generator) Uint64() uint64 {
+	s := &g.s
+	result := bits.RotateLeft64(s[1]*5, 7) * 9
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+
+	return result
+}
+
+//Uniform float64 in [0, 1), with 53 random bits.
+func (g *Generator) Float64() float64 {
+	return float64(g.Uint64()>>11) / (1 << 53)
+}
+
+//Uniform integer in [0, n), without modulo bias (Lemire's method).
+func (g *Generator) Intn(n int) int {
+	if n <= 0 {
+		panic(fmt.Errorf("n: %v <= 0", n))
+	}
+	bound := uint64(n)
+	hi, lo := bits.Mul64(g.Uint64(), bound)
+	if lo < bound {
+		threshold := -bound % bound
+		for lo < threshold {
+			hi, lo = bits.Mul64(g.Uint64(), bound)
+		}
+	}
+
+	return int(hi)
+}
+
+//Standard normal float64, by the Marsaglia polar method.
+func (g *Generator) NormFloat64() float64 {
+	if g.hasSpare {
+		g.hasSpare = false
+		return g.spare
+	}
+
+	for {
+		u := 2*g.Float64() - 1
+		v := 2*g.Float64() - 1
+		s := u*u + v*v
+		if s > 0 && s < 1 {
+			f := math.Sqrt(-2 * math.Log(s) / s)
+			g.spare, g.hasSpare = v*f, true
+			return u * f
+		}
+	}
+}
+
+//Samples uniformly distributed over [low, high).
+func (g *Generator) Uniform(low, high float64, shape ...int) *nd.NdArray {
+	tn := nd.Zeros(shape...)
+	values := tn.Values()
+	for i := range values {
+		values[i] = low + (high-low)*g.Float64()
+	}
+
+	return tn
+}
+
+//Samples from a normal distribution with the given mean and standard deviation.
+func (g *Generator) Normal(mean, std float64, shape ...int) *nd.NdArray {
+	if std < 0 {
+		panic(fmt.Errorf("std: %v < 0", std))
+	}
+	tn := nd.Zeros(shape...)
+	values := tn.Values()
+	for i := range values {
+		values[i] = mean + std*g.NormFloat64()
+	}
+
+	return tn
+}
+
+//Random integers uniformly distributed over [low, high).
+func (g *Generator) Integers(low, high int, shape ...int) *nd.NdArray {
+	if high <= low {
+		panic(fmt.Errorf("high: %v <= low: %v", high, low))
+	}
+	tn := nd.Zeros(shape...)
+	values := tn.Values()
+	for i := range values {
+		values[i] = float64(low + g.Intn(high-low))
+	}
+
+	return tn
+}
+
+//A random permutation of 0 : n.
+func (g *Generator) Permutation(n int) *nd.NdArray {
+	perm := nd.Arange(n)
+	values := perm.Values()
+	for i := n - 1; i > 0; i-- {
+		j := g.Intn(i + 1)
+		values[i], values[j] = values[j], values[i]
+	}
+
+	return perm
+}
+
+//Shuffle a in place along axis, the sub-arrays along the other axes keep their contents.
+func (g *Generator) Shuffle(a *nd.NdArray, axis int) {
+	shape := a.Shape()
+	if axis < 0 || axis >= len(shape) {
+		panic(fmt.Errorf("axis: %v out of range for shape %v", axis, shape))
+	}
+
+	outer := util.ProductOfIntSlice(shape[:axis])
+	inner := util.ProductOfIntSlice(shape[axis+1:])
+	n := shape[axis]
+	values := a.Values()
+	for i := n - 1; i > 0; i-- {
+		j := g.Intn(i + 1)
+		if i == j {
+			continue
+		}
+		for o := 0; o < outer; o++ {
+			pi := values[(o*n+i)*inner : (o*n+i+1)*inner]
+			pj := values[(o*n+j)*inner : (o*n+j+1)*inner]
+			for k := range pi {
+				pi[k], pj[k] = pj[k], pi[k]
+			}
+		}
+	}
+}
+
+//Draw size elements of the flattened a.
+//If replace is false, every element is drawn at most once.
+//weights is nil for uniform sampling, otherwise the elements are drawn proportionally to
+//weights, which must be non-negative and have the same size as a.
+func (g *Generator) Choice(a *nd.NdArray, size int, replace bool, weights *nd.NdArray) *nd.NdArray {
+	values := a.Values()
+	n := len(values)
+	if !replace && size > n {
+		panic(fmt.Errorf("size: %v > population: %v without replacement", size, n))
+	}
+	if n == 0 && size > 0 {
+		panic("empty population")
+	}
+
+	idx := make([]int, size)
+	switch {
+	case weights == nil && replace:
+		for i := range idx {
+			idx[i] = g.Intn(n)
+		}
+	case weights == nil:
+		//partial Fisher-Yates
+		perm := make([]int, n)
+		for i := range perm {
+			perm[i] = i
+		}
+		for i := range idx {
+			j := i + g.Intn(n-i)
+			perm[i], perm[j] = perm[j], perm[i]
+			idx[i] = perm[i]
+		}
+	default:
+		w := checkWeights(weights, n)
+		if replace {
+			cdf := make([]float64, n)
+			sum := 0.0
+			//last is the last positive weight, taken when rounding leaves u at the total
+			last := -1
+			for i, v := range w {
+				sum += v
+				cdf[i] = sum
+				if v > 0 {
+					last = i
+				}
+			}
+			if last < 0 && size > 0 {
+				panic("fewer non-zero weights than size")
+			}
+			for i := range idx {
+				u := g.Float64() * sum
+				k := sort.SearchFloat64s(cdf, u)
+				//skip zero weights when u falls exactly on a boundary
+				for k < last && (cdf[k] <= u || w[k] == 0) {
+					k++
+				}
+				if k > last {
+					k = last
+				}
+				idx[i] = k
+			}
+		} else {
+			//draw one by one, removing the drawn element from the population
+			w = append([]float64{}, w...)
+			sum := util.SumOfFloat64Slice(w)
+			for i := range idx {
+				if sum <= 0 {
+					panic("fewer non-zero weights than size")
+				}
+				u := g.Float64() * sum
+				//last is the last positive weight, taken when rounding leaves u beyond all of them
+				k, last := 0, -1
+				for ; k < n; k++ {
+					if w[k] > 0 {
+						last = k
+						if u < w[k] {
+							break
+						}
+						u -= w[k]
+					}
+				}
+				if last < 0 {
+					panic("fewer non-zero weights than size")
+				}
+				if k == n {
+					k = last
+				}
+				idx[i] = k
+				sum -= w[k]
+				w[k] = 0
+			}
+		}
+	}
+
+	tn := nd.Zeros(size)
+	out := tn.Values()
+	for i, k := range idx {
+		out[i] = values[k]
+	}
+
+	return tn
+}
+
+func checkWeights(weights *nd.NdArray, n int) []float64 {
+	w := weights.Values()
+	if len(w) != n {
+		panic("shape error")
+	}
+	for _, v := range w {
+		if v < 0 || math.IsNaN(v) {
+			panic(fmt.Errorf("weight: %v < 0", v))
+		}
+	}
+
+	return w
+}
@@ -0,0 +1,167 @@
+package random
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/stats"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestGeneratorReproducible(t *testing.T) {
+	g := NewGenerator(42)
+	expected := []uint64{1546998764402558742, 6990951692964543102, 12544586762248559009}
+
+	for i, e := range expected {
+		if v := g.Uint64(); v != e {
+			t.Errorf("Expected %v at %v, got %v", e, i, v)
+		}
+	}
+
+	a := NewGenerator(7).Normal(0, 1, 3, 2)
+	b := NewGenerator(7).Normal(0, 1, 3, 2)
+	if !a.Equals(b) {
+		t.Error("Expected the same samples for the same seed, got ", a, b)
+	}
+}
+
+func TestUniform(t *testing.T) {
+	g := NewGenerator(1)
+	u := g.Uniform(2, 5, 100, 10)
+
+	if !util.EqualOfIntSlice(u.Shape(), []int{100, 10}) {
+		t.Error("Expected [100 10], got ", u.Shape())
+	}
+	for _, v := range u.Values() {
+		if v < 2 || v >= 5 {
+			t.Fatal("Expected values in [2, 5), got ", v)
+		}
+	}
+	if mean := stats.Mean(u.Flat()).Get(0); math.Abs(mean-3.5) > 0.1 {
+		t.Error("Expected mean near 3.5, got ", mean)
+	}
+}
+
+func TestNormal(t *testing.T) {
+	g := NewGenerator(2)
+	n := g.Normal(1, 2, 20000)
+
+	if mean := stats.Mean(n).Get(0); math.Abs(mean-1) > 0.05 {
+		t.Error("Expected mean near 1, got ", mean)
+	}
+	if std := stats.Std(n).Get(0); math.Abs(std-2) > 0.05 {
+		t.Error("Expected std near 2, got ", std)
+	}
+}
+
+func TestIntegers(t *testing.T) {
+	g := NewGenerator(3)
+	counts := make([]int, 3)
+	for _, v := range g.Integers(-1, 2, 3000).Values() {
+		if v != math.Trunc(v) || v < -1 || v >= 2 {
+			t.Fatal("Expected integers in [-1, 2), got ", v)
+		}
+		counts[int(v)+1]++
+	}
+	for _, c := range counts {
+		if c < 900 || c > 1100 {
+			t.Error("Expected about 1000 of each value, got ", counts)
+		}
+	}
+}
+
+func TestPermutationShuffle(t *testing.T) {
+	g := NewGenerator(4)
+	p := g.Permutation(10)
+
+	if !nd.Array(p.Unique()...).Equals(nd.Arange(10)) {
+		t.Error("Expected a permutation of 0:10, got ", p)
+	}
+
+	a := nd.Arange(12).Reshape(4, 3)
+	g.Shuffle(a, 0)
+	for i := 0; i < 4; i++ {
+		row := a.NthRow(i)
+		first := row.Get(0)
+		if !row.Equals(nd.Array(first, first+1, first+2)) {
+			t.Error("Expected rows to be kept whole, got ", a)
+		}
+	}
+	if !nd.Array(a.Unique()...).Equals(nd.Arange(12)) {
+		t.Error("Expected the same elements, got ", a)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an out of range axis")
+		}
+	}()
+	g.Shuffle(a, 2)
+}
+
+func TestChoice(t *testing.T) {
+	g := NewGenerator(5)
+	a := nd.Array(10, 20, 30, 40)
+
+	c := g.Choice(a, 4, false, nil)
+	if !nd.Array(c.Unique()...).Equals(a) {
+		t.Error("Expected every element once, got ", c)
+	}
+
+	w := nd.Array(0, 1, 0, 3)
+	counts := map[float64]int{}
+	for _, v := range g.Choice(a, 4000, true, w).Values() {
+		counts[v]++
+	}
+	if counts[10] != 0 || counts[30] != 0 || counts[20] < 900 || counts[20] > 1100 {
+		t.Error("Expected about 1000 draws of 20 and none of 10 or 30, got ", counts)
+	}
+
+	c = g.Choice(a, 2, false, w)
+	if !nd.Array(c.Unique()...).Equals(nd.Array(20, 40)) {
+		t.Error("Expected 20 and 40, got ", c)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic when size > population without replacement")
+		}
+	}()
+	g.Choice(a, 5, false, nil)
+}
+
+func TestChoiceTrailingZeroWeights(t *testing.T) {
+	g := NewGenerator(6)
+	a := nd.Array(10, 20, 30, 40)
+	w := nd.Array(0.1, 0.2, 0.7, 0)
+	for i := 0; i < 100; i++ {
+		c := g.Choice(a, 3, false, w)
+		if !nd.Array(c.Unique()...).Equals(nd.Array(10, 20, 30)) {
+			t.Fatal("Expected 10, 20 and 30, got ", c)
+		}
+	}
+
+	for _, v := range g.Choice(a, 10000, true, nd.Array(0.5, 0.5, 0, 0)).Values() {
+		if v != 10 && v != 20 {
+			t.Fatal("Expected only 10 and 20 with replacement, got ", v)
+		}
+	}
+
+	panics := func(f func()) (r interface{}) {
+		defer func() { r = recover() }()
+		f()
+		return nil
+	}
+	if r := panics(func() { g.Choice(a, 1, true, nd.Zeros(4)) }); r != "fewer non-zero weights than size" {
+		t.Error("Expected a panic for zero weights with replacement, got ", r)
+	}
+	r := panics(func() {
+		for i := 0; i < 100; i++ {
+			g.Choice(a, 4, false, w)
+		}
+	})
+	if r != "fewer non-zero weights than size" {
+		t.Error("Expected a panic for fewer non-zero weights than size, got ", r)
+	}
+}
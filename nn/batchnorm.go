@@ -0,0 +1,73 @@
+package nn
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+)
+
+//Batch normalization of the features along axis 1 of inputs [batch, features, ...]. During training each
+//feature is normalized with the mean and variance of the batch, which are also averaged into running
+//statistics used for inference. The normalized values are scaled by Gamma and shifted by Beta.
+type BatchNorm struct {
+	//[features], initially ones and zeros
+	Gamma, Beta *Param
+	//[features], not trained, but saved with the parameters
+	RunningMean, RunningVar *Param
+	//weight of a batch in the running statistics, and the term added to the variances
+	Momentum, Eps float64
+}
+
+//Create a batch normalization of features, with momentum 0.1 and eps 1e-5.
+func NewBatchNorm(features int) *BatchNorm {
+	return &BatchNorm{
+		Gamma:       NewParam("gamma", nd.Ones(features)),
+		Beta:        NewParam("beta", nd.Zeros(features)),
+		RunningMean: NewParam("running_mean", nd.Zeros(features)),
+		RunningVar:  NewParam("running_var", nd.Ones(features)),
+		Momentum:    0.1,
+		Eps:         1e-5,
+	}
+}
+
+func (b *BatchNorm) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	t := x.Tape()
+	shape := x.Shape()
+	//the statistics of each feature are over every axis but 1
+	var axes []int
+	for d := range shape {
+		if d != 1 {
+			axes = append(axes, d)
+		}
+	}
+	kept := keep(shape, axes...)
+
+	var mean, variance *autograd.Variable
+	if train {
+		mean = x.Mean(axes...).Reshape(kept...)
+		centered := x.Sub(mean)
+		variance = centered.Mul(centered).Mean(axes...).Reshape(kept...)
+
+		//the running variance is unbiased
+		n := float64(x.Value.Size() / shape[1])
+		rm, rv := b.RunningMean.Value.Values(), b.RunningVar.Value.Values()
+		for i := range rm {
+			rm[i] = (1-b.Momentum)*rm[i] + b.Momentum*mean.Value.Values()[i]
+			rv[i] = (1-b.Momentum)*rv[i] + b.Momentum*variance.Value.Values()[i]*n/math.Max(n-1, 1)
+		}
+	} else {
+		mean = t.Constant(b.RunningMean.Value.Reshape(kept...))
+		variance = t.Constant(b.RunningVar.Value.Reshape(kept...))
+	}
+
+	eps := b.Eps
+	std := variance.Map(func(v float64) float64 { return math.Sqrt(v + eps) },
+		func(v, y float64) float64 { return 0.5 / y })
+	normalized := x.Sub(mean).Div(std)
+	return normalized.Mul(b.Gamma.variable(t).Reshape(kept...)).Add(b.Beta.variable(t).Reshape(kept...))
+}
+
+func (b *BatchNorm) Params() []*Param {
+	return []*Param{b.Gamma, b.Beta, b.RunningMean, b.RunningVar}
+}
@@ -0,0 +1,58 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestBatchNorm(t *testing.T) {
+	b := NewBatchNorm(2)
+	x := nd.Array(1, 10, 3, 20, 5, 30).Reshape(3, 2)
+	y := b.Forward(autograd.NewTape().Constant(x), true).Value
+	//each column has mean 0 and variance 1 - eps / (var + eps)
+	for j := 0; j < 2; j++ {
+		mean, square := 0.0, 0.0
+		for i := 0; i < 3; i++ {
+			mean += y.Get(i, j) / 3
+			square += y.Get(i, j) * y.Get(i, j) / 3
+		}
+		if math.Abs(mean) > 1e-12 || math.Abs(square-1) > 1e-4 {
+			t.Error("Expected mean 0 and variance 1, got ", mean, square)
+		}
+	}
+	//the unbiased variances are 4 and 100
+	if !b.RunningMean.Value.Equals(nd.Array(0.3, 2)) {
+		t.Error("Expected running means [0.3, 2], got ", b.RunningMean.Value)
+	}
+	if math.Abs(b.RunningVar.Value.Get(0)-1.3) > 1e-12 || math.Abs(b.RunningVar.Value.Get(1)-10.9) > 1e-12 {
+		t.Error("Expected running variances [1.3, 10.9], got ", b.RunningVar.Value)
+	}
+
+	b.RunningMean.Value = nd.Array(3, 20)
+	b.RunningVar.Value = nd.Array(4, 100)
+	b.Gamma.Value = nd.Array(2, 1)
+	b.Beta.Value = nd.Array(0, 1)
+	y = Predict(b, x)
+	want := []float64{-2, 0, 0, 1, 2, 2}
+	for i, v := range y.Values() {
+		if math.Abs(v-want[i]) > 1e-5 {
+			t.Error("Expected ", want[i], ", got ", v)
+		}
+	}
+}
+
+func TestBatchNormGradients(t *testing.T) {
+	g := random.NewGenerator(8)
+	b := NewBatchNorm(3)
+	b.Gamma.Value = g.Normal(1, 0.5, 3)
+	b.Beta.Value = g.Normal(0, 1, 3)
+	for _, train := range []bool{true, false} {
+		if worst := checkLayer(b, g.Normal(0, 2, 4, 3, 2, 2), train); worst > 1e-6 {
+			t.Error("Expected gradients to agree in training ", train, ", got ", worst)
+		}
+	}
+}
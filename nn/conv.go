@@ -0,0 +1,221 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//2d convolution (cross-correlation, like most frameworks) of inputs [batch, in, height, width].
+type Conv2D struct {
+	//[out, in, size, size] and [out]
+	W, B            *Param
+	Stride, Padding int
+}
+
+//Create a convolution from in to out channels with square kernels of size, moved by stride over the
+//inputs padded with padding zeros on each side. The weights are uniform in +-sqrt(6 / (fan in + fan out))
+//drawn from g, the biases zero.
+func NewConv2D(in, out, size, stride, padding int, g *random.Generator) *Conv2D {
+	if size < 1 || stride < 1 || padding < 0 {
+		panic(fmt.Errorf("size: %v, stride: %v, padding: %v invalid", size, stride, padding))
+	}
+	limit := math.Sqrt(6 / float64((in+out)*size*size))
+	return &Conv2D{
+		W:       NewParam("weight", g.Uniform(-limit, limit, out, in, size, size)),
+		B:       NewParam("bias", nd.Zeros(out)),
+		Stride:  stride,
+		Padding: padding,
+	}
+}
+
+func (c *Conv2D) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	t := x.Tape()
+	w, b := c.W.variable(t), c.B.variable(t)
+	xs, ws := x.Shape(), w.Shape()
+	if len(xs) != 4 || xs[1] != ws[1] {
+		panic(fmt.Errorf("input shape: %v does not match weight shape %v", xs, ws))
+	}
+	batch, in, h, wd := xs[0], xs[1], xs[2], xs[3]
+	out, k, s, p := ws[0], ws[2], c.Stride, c.Padding
+	oh, ow := outputSize(h, k, s, p), outputSize(wd, k, s, p)
+
+	//calls visit with the positions in x, w and the result of each product of the convolution
+	each := func(visit func(ix, iw, iy int)) {
+		iy := 0
+		for n := 0; n < batch; n++ {
+			for o := 0; o < out; o++ {
+				for i := 0; i < oh; i++ {
+					for j := 0; j < ow; j++ {
+						for ch := 0; ch < in; ch++ {
+							for ki := 0; ki < k; ki++ {
+								r := i*s + ki - p
+								if r < 0 || r >= h {
+									continue
+								}
+								for kj := 0; kj < k; kj++ {
+									col := j*s + kj - p
+									if col < 0 || col >= wd {
+										continue
+									}
+									visit(((n*in+ch)*h+r)*wd+col, ((o*in+ch)*k+ki)*k+kj, iy)
+								}
+							}
+						}
+						iy++
+					}
+				}
+			}
+		}
+	}
+
+	xv, wv, bv := x.Value.Values(), w.Value.Values(), b.Value.Values()
+	value := nd.Zeros(batch, out, oh, ow)
+	vs := value.Values()
+	for i := range vs {
+		vs[i] = bv[(i/(oh*ow))%out]
+	}
+	each(func(ix, iw, iy int) {
+		vs[iy] += xv[ix] * wv[iw]
+	})
+
+	return autograd.Apply(value, func(grad *nd.NdArray) []*nd.NdArray {
+		gs := grad.Values()
+		gx, gw, gb := nd.Zeros(xs...), nd.Zeros(ws...), nd.Zeros(out)
+		gxv, gwv, gbv := gx.Values(), gw.Values(), gb.Values()
+		each(func(ix, iw, iy int) {
+			gxv[ix] += gs[iy] * wv[iw]
+			gwv[iw] += gs[iy] * xv[ix]
+		})
+		for i, g := range gs {
+			gbv[(i/(oh*ow))%out] += g
+		}
+		return []*nd.NdArray{gx, gw, gb}
+	}, x, w, b)
+}
+
+func (c *Conv2D) Params() []*Param {
+	return []*Param{c.W, c.B}
+}
+
+//Maximum over windows of Size x Size moved by Stride, of inputs [batch, channels, height, width].
+type MaxPool2D struct {
+	Size, Stride int
+}
+
+//Create a max pooling layer, stride 0 for non overlapping windows.
+func NewMaxPool2D(size, stride int) *MaxPool2D {
+	if stride == 0 {
+		stride = size
+	}
+	return &MaxPool2D{Size: size, Stride: stride}
+}
+
+func (m *MaxPool2D) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	return pool(x, m.Size, m.Stride, true)
+}
+
+func (m *MaxPool2D) Params() []*Param {
+	return nil
+}
+
+//Mean over windows of Size x Size moved by Stride, of inputs [batch, channels, height, width].
+type AvgPool2D struct {
+	Size, Stride int
+}
+
+//Create an average pooling layer, stride 0 for non overlapping windows.
+func NewAvgPool2D(size, stride int) *AvgPool2D {
+	if stride == 0 {
+		stride = size
+	}
+	return &AvgPool2D{Size: size, Stride: stride}
+}
+
+func (a *AvgPool2D) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	return pool(x, a.Size, a.Stride, false)
+}
+
+func (a *AvgPool2D) Params() []*Param {
+	return nil
+}
+
+//the maximum or the mean over the windows of x, the gradient of a maximum goes to the first maximal element.
+func pool(x *autograd.Variable, size, stride int, maximum bool) *autograd.Variable {
+	xs := x.Shape()
+	if len(xs) != 4 || size < 1 || stride < 1 {
+		panic(fmt.Errorf("shape: %v, size: %v, stride: %v invalid", xs, size, stride))
+	}
+	h, w := xs[2], xs[3]
+	oh, ow := outputSize(h, size, stride, 0), outputSize(w, size, stride, 0)
+	planes := xs[0] * xs[1]
+	xv := x.Value.Values()
+	value := nd.Zeros(xs[0], xs[1], oh, ow)
+	vs := value.Values()
+	//the position in x of each maximum
+	var arg []int
+	if maximum {
+		arg = make([]int, len(vs))
+	}
+	area := float64(size * size)
+
+	iy := 0
+	for c := 0; c < planes; c++ {
+		for i := 0; i < oh; i++ {
+			for j := 0; j < ow; j++ {
+				best := -1
+				for ki := 0; ki < size; ki++ {
+					for kj := 0; kj < size; kj++ {
+						ix := (c*h+i*stride+ki)*w + j*stride + kj
+						if !maximum {
+							vs[iy] += xv[ix] / area
+						} else if best < 0 || xv[ix] > xv[best] {
+							best = ix
+						}
+					}
+				}
+				if maximum {
+					vs[iy], arg[iy] = xv[best], best
+				}
+				iy++
+			}
+		}
+	}
+
+	return autograd.Apply(value, func(grad *nd.NdArray) []*nd.NdArray {
+		gx := nd.Zeros(xs...)
+		gxv, gs := gx.Values(), grad.Values()
+		if maximum {
+			for iy, ix := range arg {
+				gxv[ix] += gs[iy]
+			}
+			return []*nd.NdArray{gx}
+		}
+		iy := 0
+		for c := 0; c < planes; c++ {
+			for i := 0; i < oh; i++ {
+				for j := 0; j < ow; j++ {
+					for ki := 0; ki < size; ki++ {
+						for kj := 0; kj < size; kj++ {
+							gxv[(c*h+i*stride+ki)*w+j*stride+kj] += gs[iy] / area
+						}
+					}
+					iy++
+				}
+			}
+		}
+		return []*nd.NdArray{gx}
+	}, x)
+}
+
+//length of the output of a window of size moved by stride over n elements padded on both sides.
+func outputSize(n, size, stride, padding int) int {
+	m := (n+2*padding-size)/stride + 1
+	if m < 1 {
+		panic(fmt.Errorf("window: %v larger than the input %v with padding %v", size, n, padding))
+	}
+	return m
+}
@@ -0,0 +1,65 @@
+package nn
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestConv2D(t *testing.T) {
+	g := random.NewGenerator(5)
+	c := NewConv2D(1, 1, 2, 1, 0, g)
+	c.W.Value = nd.Array(1, 0, 0, -1).Reshape(1, 1, 2, 2)
+	c.B.Value = nd.Array(0.5)
+	x := nd.Arange(9).Reshape(1, 1, 3, 3)
+	y := Predict(c, x)
+	//x[i, j] - x[i+1, j+1] = -4
+	if !y.Equals(nd.Array(-3.5, -3.5, -3.5, -3.5).Reshape(1, 1, 2, 2)) {
+		t.Error("Expected [[-3.5, -3.5], [-3.5, -3.5]], got ", y)
+	}
+
+	for _, s := range [][2]int{{1, 0}, {2, 1}, {1, 2}} {
+		c := NewConv2D(2, 3, 3, s[0], s[1], g)
+		c.B.Value = g.Normal(0, 1, 3)
+		if worst := checkLayer(c, g.Normal(0, 1, 2, 2, 5, 4), true); worst > 1e-6 {
+			t.Error("Expected gradients to agree for stride and padding ", s, ", got ", worst)
+		}
+	}
+
+	padded := Predict(NewConv2D(2, 3, 3, 2, 1, g), g.Normal(0, 1, 2, 2, 5, 4))
+	if !util.EqualOfIntSlice(padded.Shape(), []int{2, 3, 3, 2}) {
+		t.Error("Expected shape [2, 3, 3, 2], got ", padded.Shape())
+	}
+}
+
+func TestConv2DShape(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a channel mismatch")
+		}
+	}()
+	c := NewConv2D(3, 1, 3, 1, 0, random.NewGenerator(6))
+	Predict(c, nd.Zeros(1, 2, 4, 4))
+}
+
+func TestPool(t *testing.T) {
+	x := nd.Array(1, 5, 2, 0, 3, 4, 8, 6, 0, 1, 2, 3, 9, 7, 4, 5).Reshape(1, 1, 4, 4)
+	if y := Predict(NewMaxPool2D(2, 0), x); !y.Equals(nd.Array(5, 8, 9, 5).Reshape(1, 1, 2, 2)) {
+		t.Error("Expected [[5, 8], [9, 5]], got ", y)
+	}
+	if y := Predict(NewAvgPool2D(2, 0), x); !y.Equals(nd.Array(3.25, 4, 4.25, 3.5).Reshape(1, 1, 2, 2)) {
+		t.Error("Expected [[3.25, 4], [4.25, 3.5]], got ", y)
+	}
+	if y := Predict(NewMaxPool2D(3, 1), x); !y.Equals(nd.Array(8, 8, 9, 8).Reshape(1, 1, 2, 2)) {
+		t.Error("Expected [[8, 8], [9, 8]], got ", y)
+	}
+
+	g := random.NewGenerator(7)
+	for _, l := range []Layer{NewMaxPool2D(2, 0), NewAvgPool2D(2, 0), NewMaxPool2D(3, 2), NewAvgPool2D(2, 1)} {
+		if worst := checkLayer(l, g.Normal(0, 1, 2, 3, 5, 5), true); worst > 1e-6 {
+			t.Error("Expected gradients to agree for ", l, ", got ", worst)
+		}
+	}
+}
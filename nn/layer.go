@@ -0,0 +1,76 @@
+//Package nn builds neural networks from layers whose forward passes are recorded on an autograd tape,
+//so that Backward on a loss gives the gradients of their parameters. Optimizers update the parameters
+//from these gradients, and Save and Load serialize them.
+package nn
+
+import (
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+)
+
+//A named array of a layer, updated in place by the optimizers.
+type Param struct {
+	Name  string
+	Value *nd.NdArray
+
+	//the variable of Value on the tape of the last forward pass
+	v *autograd.Variable
+}
+
+//Create a parameter holding value.
+func NewParam(name string, value *nd.NdArray) *Param {
+	return &Param{Name: name, Value: value}
+}
+
+//Gradient of the last Backward with respect to the parameter, nil when it was not part of the computation.
+func (p *Param) Grad() *nd.NdArray {
+	if p.v == nil {
+		return nil
+	}
+	return p.v.Grad
+}
+
+//the parameter as a variable on t, whose gradient Grad returns.
+func (p *Param) variable(t *autograd.Tape) *autograd.Variable {
+	p.v = t.Variable(p.Value)
+	return p.v
+}
+
+//Part of a network. Forward records the output for the input x on the tape of x, train selects the
+//behaviour of training over that of inference for layers like Dropout and BatchNorm.
+type Layer interface {
+	Forward(x *autograd.Variable, train bool) *autograd.Variable
+	Params() []*Param
+}
+
+//Layers applied one after the other.
+type Sequential struct {
+	Layers []Layer
+}
+
+//Create a network of layers, applied in order.
+func NewSequential(layers ...Layer) *Sequential {
+	return &Sequential{Layers: layers}
+}
+
+func (s *Sequential) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	for _, l := range s.Layers {
+		x = l.Forward(x, train)
+	}
+	return x
+}
+
+//Parameters of all the layers, in order.
+func (s *Sequential) Params() []*Param {
+	var params []*Param
+	for _, l := range s.Layers {
+		params = append(params, l.Params()...)
+	}
+	return params
+}
+
+//Output of l for the input x in inference mode. The gradients of the parameters are discarded,
+//so it must not run between Backward and the step of an optimizer.
+func Predict(l Layer, x *nd.NdArray) *nd.NdArray {
+	return l.Forward(autograd.NewTape().Constant(x), false).Value
+}
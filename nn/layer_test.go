@@ -0,0 +1,90 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Largest relative difference between the gradients of a weighted sum of the outputs of l, with respect
+//to x and to the parameters, by Backward and by finite differences.
+func checkLayer(l Layer, x *nd.NdArray, train bool) float64 {
+	params := l.Params()
+	var weights *nd.NdArray
+	loss := func(t *autograd.Tape, in *autograd.Variable) *autograd.Variable {
+		y := l.Forward(in, train)
+		if weights == nil {
+			weights = random.NewGenerator(7).Normal(0, 1, y.Shape()...)
+		}
+		return y.Mul(t.Constant(weights)).Sum()
+	}
+
+	t := autograd.NewTape()
+	in := t.Variable(x)
+	loss(t, in).Backward()
+	analytic := []*nd.NdArray{in.Grad}
+	inputs := []*nd.NdArray{x}
+	for _, p := range params {
+		analytic = append(analytic, p.Grad())
+		inputs = append(inputs, p.Value)
+	}
+
+	numeric := autograd.NumericalGradients(func(t *autograd.Tape, xs ...*autograd.Variable) *autograd.Variable {
+		for i, p := range params {
+			p.Value = xs[i+1].Value
+		}
+		return loss(t, xs[0])
+	}, inputs...)
+	for i, p := range params {
+		p.Value = inputs[i+1]
+	}
+
+	worst := 0.0
+	for i, g := range numeric {
+		if analytic[i] == nil {
+			//not trained, like running statistics
+			continue
+		}
+		for k, n := range g.Values() {
+			a := analytic[i].Values()[k]
+			worst = math.Max(worst, math.Abs(a-n)/math.Max(1, math.Abs(a)+math.Abs(n)))
+		}
+	}
+	return worst
+}
+
+func TestSequential(t *testing.T) {
+	g := random.NewGenerator(1)
+	net := NewSequential(NewDense(3, 4, g), Tanh, NewDense(4, 2, g))
+	if len(net.Params()) != 4 {
+		t.Error("Expected 4 parameters, got ", len(net.Params()))
+	}
+	x := g.Normal(0, 1, 5, 3)
+	if worst := checkLayer(net, x, true); worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+
+	y := Predict(net, x)
+	h := nd.MatMul(x, net.Layers[0].Params()[0].Value).Map(math.Tanh)
+	want := nd.MatMul(h, net.Layers[2].Params()[0].Value)
+	for i, v := range y.Values() {
+		if math.Abs(v-want.Values()[i]) > 1e-12 {
+			t.Error("Expected ", want.Values()[i], ", got ", v)
+		}
+	}
+}
+
+func TestParamGrad(t *testing.T) {
+	p := NewParam("w", nd.Array(1, 2))
+	if p.Grad() != nil {
+		t.Error("Expected no gradient before a forward pass, got ", p.Grad())
+	}
+	tape := autograd.NewTape()
+	p.variable(tape).Pow(2).Sum().Backward()
+	if !p.Grad().Equals(nd.Array(2, 4)) {
+		t.Error("Expected [2, 4], got ", p.Grad())
+	}
+}
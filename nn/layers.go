@@ -0,0 +1,122 @@
+package nn
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Fully connected layer, x W + b for inputs x [batch, in].
+type Dense struct {
+	//[in, out] and [out]
+	W, B *Param
+}
+
+//Create a dense layer from in to out features, with weights uniform in +-sqrt(6 / (in + out))
+//(Glorot) drawn from g and zero biases.
+func NewDense(in, out int, g *random.Generator) *Dense {
+	limit := math.Sqrt(6 / float64(in+out))
+	return &Dense{
+		W: NewParam("weight", g.Uniform(-limit, limit, in, out)),
+		B: NewParam("bias", nd.Zeros(out)),
+	}
+}
+
+func (d *Dense) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	t := x.Tape()
+	return x.Dot(d.W.variable(t)).Add(d.B.variable(t))
+}
+
+func (d *Dense) Params() []*Param {
+	return []*Param{d.W, d.B}
+}
+
+//Elementwise function as a layer without parameters.
+type Activation func(x *autograd.Variable) *autograd.Variable
+
+var (
+	ReLU    Activation = (*autograd.Variable).ReLU
+	Sigmoid Activation = (*autograd.Variable).Sigmoid
+	Tanh    Activation = (*autograd.Variable).Tanh
+	//softmax over the last axis
+	Softmax Activation = func(x *autograd.Variable) *autograd.Variable {
+		return LogSoftmax(x, x.Value.NDims()-1).Exp()
+	}
+)
+
+func (a Activation) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	return a(x)
+}
+
+func (a Activation) Params() []*Param {
+	return nil
+}
+
+//Log of the softmax of x along axis, x - log(sum(exp(x))), computed without overflow.
+func LogSoftmax(x *autograd.Variable, axis int) *autograd.Variable {
+	return x.Sub(x.LogSumExp(axis).Reshape(keep(x.Shape(), axis)...))
+}
+
+//Flatten the inputs [batch, ...] to [batch, features].
+type Flatten struct{}
+
+func (Flatten) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	shape := x.Shape()
+	return x.Reshape(shape[0], x.Value.Size()/shape[0])
+}
+
+func (Flatten) Params() []*Param {
+	return nil
+}
+
+//Set elements to zero with probability Rate during training, and scale the others by 1 / (1 - Rate),
+//so that inference needs no scaling.
+type Dropout struct {
+	Rate float64
+	g    *random.Generator
+}
+
+//Create a dropout layer drawing its masks from g.
+func NewDropout(rate float64, g *random.Generator) *Dropout {
+	if rate < 0 || rate >= 1 {
+		panic(fmt.Errorf("rate: %v not in [0, 1)", rate))
+	}
+	return &Dropout{Rate: rate, g: g}
+}
+
+func (d *Dropout) Forward(x *autograd.Variable, train bool) *autograd.Variable {
+	if !train || d.Rate == 0 {
+		return x
+	}
+	mask := nd.Zeros(x.Shape()...)
+	for i := range mask.Values() {
+		if d.g.Float64() >= d.Rate {
+			mask.Values()[i] = 1 / (1 - d.Rate)
+		}
+	}
+	return x.Mul(x.Tape().Constant(mask))
+}
+
+func (d *Dropout) Params() []*Param {
+	return nil
+}
+
+//shape with the length of the axes set to 1, so that a reduction over them broadcasts against shape.
+func keep(shape []int, axes ...int) []int {
+	kept := append([]int(nil), shape...)
+	for _, ax := range axes {
+		if ax < 0 || ax >= len(shape) {
+			panic(fmt.Errorf("axis: %v invalid for shape %v", ax, shape))
+		}
+		kept[ax] = 1
+	}
+	return kept
+}
+
+//x times c.
+func scale(x *autograd.Variable, c float64) *autograd.Variable {
+	return x.Map(func(v float64) float64 { return v * c }, func(v, y float64) float64 { return c })
+}
@@ -0,0 +1,98 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestDense(t *testing.T) {
+	g := random.NewGenerator(2)
+	d := NewDense(3, 2, g)
+	if !util.EqualOfIntSlice(d.W.Value.Shape(), []int{3, 2}) || !d.B.Value.Equals(nd.Zeros(2)) {
+		t.Error("Expected weights [3, 2] and zero biases, got ", d.W.Value, d.B.Value)
+	}
+	limit := math.Sqrt(6.0 / 5)
+	for _, v := range d.W.Value.Values() {
+		if math.Abs(v) > limit {
+			t.Error("Expected weights within ", limit, ", got ", v)
+		}
+	}
+	d.B.Value = nd.Array(0.5, -0.5)
+	if worst := checkLayer(d, g.Normal(0, 1, 4, 3), true); worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+}
+
+func TestActivations(t *testing.T) {
+	x := nd.Array(-1.5, -0.2, 0.3, 2.1, 0.7, -0.9).Reshape(2, 3)
+	for name, a := range map[string]Activation{"relu": ReLU, "sigmoid": Sigmoid, "tanh": Tanh, "softmax": Softmax} {
+		if worst := checkLayer(a, x, true); worst > 1e-6 {
+			t.Error("Expected gradients of ", name, " to agree, got ", worst)
+		}
+	}
+
+	y := Predict(Softmax, nd.Array(1, 2, 3, 1000, 1000, 1000).Reshape(2, 3))
+	e := math.Exp(1) + math.Exp(2) + math.Exp(3)
+	want := []float64{math.Exp(1) / e, math.Exp(2) / e, math.Exp(3) / e, 1.0 / 3, 1.0 / 3, 1.0 / 3}
+	for i, v := range y.Values() {
+		if math.Abs(v-want[i]) > 1e-12 {
+			t.Error("Expected ", want[i], ", got ", v)
+		}
+	}
+}
+
+func TestLogSoftmax(t *testing.T) {
+	tape := autograd.NewTape()
+	y := LogSoftmax(tape.Constant(nd.Array(0, 0, -800, 800).Reshape(2, 2)), 1)
+	want := []float64{-math.Log(2), -math.Log(2), -1600, 0}
+	for i, v := range y.Value.Values() {
+		if math.Abs(v-want[i]) > 1e-9 {
+			t.Error("Expected ", want[i], ", got ", v)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	x := random.NewGenerator(3).Normal(0, 1, 2, 3, 2, 2)
+	y := Predict(Flatten{}, x)
+	if !util.EqualOfIntSlice(y.Shape(), []int{2, 12}) {
+		t.Error("Expected shape [2, 12], got ", y.Shape())
+	}
+	if worst := checkLayer(Flatten{}, x, true); worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+}
+
+func TestDropout(t *testing.T) {
+	d := NewDropout(0.25, random.NewGenerator(4))
+	x := nd.Ones(100, 100)
+	if !Predict(d, x).Equals(x) {
+		t.Error("Expected no change during inference")
+	}
+
+	tape := autograd.NewTape()
+	in := tape.Variable(x)
+	y := d.Forward(in, true)
+	dropped := 0
+	for _, v := range y.Value.Values() {
+		switch v {
+		case 0:
+			dropped++
+		case 1 / 0.75:
+		default:
+			t.Error("Expected 0 or ", 1/0.75, ", got ", v)
+		}
+	}
+	if dropped < 2300 || dropped > 2700 {
+		t.Error("Expected about 2500 dropped elements, got ", dropped)
+	}
+	y.Sum().Backward()
+	if !in.Grad.Equals(y.Value) {
+		t.Error("Expected the gradient to be the mask")
+	}
+}
@@ -0,0 +1,32 @@
+package nn
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+)
+
+//Mean squared error between the predictions and target, of the same shape.
+func MSE(pred *autograd.Variable, target *nd.NdArray) *autograd.Variable {
+	diff := pred.Sub(pred.Tape().Constant(target))
+	return diff.Mul(diff).Mean()
+}
+
+//Mean over the batch of the cross-entropy between the softmax of logits [batch, classes] and the classes
+//in labels, one per row. The softmax is not computed, the log-sum-exp keeps large logits finite.
+func CrossEntropy(logits *autograd.Variable, labels []int) *autograd.Variable {
+	shape := logits.Shape()
+	if len(shape) != 2 || shape[0] != len(labels) {
+		panic(fmt.Errorf("logits shape: %v does not match %v labels", shape, len(labels)))
+	}
+	onehot := nd.Zeros(shape...)
+	for i, c := range labels {
+		if c < 0 || c >= shape[1] {
+			panic(fmt.Errorf("label: %v not in [0, %v)", c, shape[1]))
+		}
+		onehot.Set(1, i, c)
+	}
+	picked := LogSoftmax(logits, 1).Mul(logits.Tape().Constant(onehot)).Sum()
+	return scale(picked, -1/float64(len(labels)))
+}
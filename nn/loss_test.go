@@ -0,0 +1,53 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestMSE(t *testing.T) {
+	tape := autograd.NewTape()
+	pred := tape.Variable(nd.Array(1, 2, 3, 4).Reshape(2, 2))
+	loss := MSE(pred, nd.Array(1, 0, 3, 0).Reshape(2, 2))
+	if loss.Value.Values()[0] != 5 {
+		t.Error("Expected 5, got ", loss.Value)
+	}
+	loss.Backward()
+	if !pred.Grad.Equals(nd.Array(0, 1, 0, 2).Reshape(2, 2)) {
+		t.Error("Expected [[0, 1], [0, 2]], got ", pred.Grad)
+	}
+}
+
+func TestCrossEntropy(t *testing.T) {
+	tape := autograd.NewTape()
+	logits := tape.Variable(nd.Array(0, 0, 1000, 0).Reshape(2, 2))
+	loss := CrossEntropy(logits, []int{1, 1})
+	//log 2 for the first row, 1000 for the second
+	if math.Abs(loss.Value.Values()[0]-(math.Log(2)+1000)/2) > 1e-9 {
+		t.Error("Expected ", (math.Log(2)+1000)/2, ", got ", loss.Value)
+	}
+	loss.Backward()
+	//(softmax - onehot) / batch
+	if !logits.Grad.Equals(nd.Array(0.25, -0.25, 0.5, -0.5).Reshape(2, 2)) {
+		t.Error("Expected [[0.25, -0.25], [0.5, -0.5]], got ", logits.Grad)
+	}
+
+	worst := autograd.GradCheck(func(t *autograd.Tape, xs ...*autograd.Variable) *autograd.Variable {
+		return CrossEntropy(xs[0], []int{2, 0, 1})
+	}, nd.Array(0.1, -0.4, 1.2, 2.0, 0.3, -1.1, 0.5, 0.5, 0.7).Reshape(3, 3))
+	if worst > 1e-6 {
+		t.Error("Expected gradients to agree, got ", worst)
+	}
+}
+
+func TestCrossEntropyLabels(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a label out of range")
+		}
+	}()
+	CrossEntropy(autograd.NewTape().Constant(nd.Zeros(1, 3)), []int{3})
+}
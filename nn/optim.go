@@ -0,0 +1,108 @@
+package nn
+
+import (
+	"math"
+)
+
+//Update of parameters from the gradients of the last Backward. Parameters without a gradient,
+//like the running statistics of BatchNorm, are left alone.
+type Optimizer interface {
+	Step(params []*Param)
+}
+
+//Stochastic gradient descent with momentum: v = Momentum v + grad, value -= LearningRate v.
+type SGD struct {
+	LearningRate, Momentum float64
+	velocity               map[*Param][]float64
+}
+
+//Create a gradient descent, momentum 0 for plain steps along the gradient.
+func NewSGD(learningRate, momentum float64) *SGD {
+	return &SGD{LearningRate: learningRate, Momentum: momentum, velocity: map[*Param][]float64{}}
+}
+
+func (o *SGD) Step(params []*Param) {
+	for _, p := range params {
+		grad := p.Grad()
+		if grad == nil {
+			continue
+		}
+		v := state(o.velocity, p)
+		vs := p.Value.Values()
+		for i, g := range grad.Values() {
+			v[i] = o.Momentum*v[i] + g
+			vs[i] -= o.LearningRate * v[i]
+		}
+	}
+}
+
+//Adam (Kingma and Ba), steps scaled by running averages of the gradients and of their squares,
+//corrected for their zero initialization.
+type Adam struct {
+	LearningRate, Beta1, Beta2, Eps float64
+
+	steps map[*Param]int
+	m, v  map[*Param][]float64
+}
+
+//Create an Adam optimizer with beta1 0.9, beta2 0.999 and eps 1e-8.
+func NewAdam(learningRate float64) *Adam {
+	return &Adam{LearningRate: learningRate, Beta1: 0.9, Beta2: 0.999, Eps: 1e-8,
+		steps: map[*Param]int{}, m: map[*Param][]float64{}, v: map[*Param][]float64{}}
+}
+
+func (o *Adam) Step(params []*Param) {
+	for _, p := range params {
+		grad := p.Grad()
+		if grad == nil {
+			continue
+		}
+		o.steps[p]++
+		t := float64(o.steps[p])
+		m, v := state(o.m, p), state(o.v, p)
+		c1, c2 := 1-math.Pow(o.Beta1, t), 1-math.Pow(o.Beta2, t)
+		vs := p.Value.Values()
+		for i, g := range grad.Values() {
+			m[i] = o.Beta1*m[i] + (1-o.Beta1)*g
+			v[i] = o.Beta2*v[i] + (1-o.Beta2)*g*g
+			vs[i] -= o.LearningRate * (m[i] / c1) / (math.Sqrt(v[i]/c2) + o.Eps)
+		}
+	}
+}
+
+//RMSProp, steps along the gradient divided by the root of a running average of its squares.
+type RMSProp struct {
+	LearningRate, Rho, Eps float64
+	square                 map[*Param][]float64
+}
+
+//Create an RMSProp optimizer with rho 0.9 and eps 1e-8.
+func NewRMSProp(learningRate float64) *RMSProp {
+	return &RMSProp{LearningRate: learningRate, Rho: 0.9, Eps: 1e-8, square: map[*Param][]float64{}}
+}
+
+func (o *RMSProp) Step(params []*Param) {
+	for _, p := range params {
+		grad := p.Grad()
+		if grad == nil {
+			continue
+		}
+		s := state(o.square, p)
+		vs := p.Value.Values()
+		for i, g := range grad.Values() {
+			s[i] = o.Rho*s[i] + (1-o.Rho)*g*g
+			vs[i] -= o.LearningRate * g / (math.Sqrt(s[i]) + o.Eps)
+		}
+	}
+}
+
+//the state of an optimizer for p, zeros at first.
+func state(states map[*Param][]float64, p *Param) []float64 {
+	s, ok := states[p]
+	if !ok {
+		s = make([]float64, p.Value.Size())
+		states[p] = s
+	}
+	return s
+}
+
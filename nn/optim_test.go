@@ -0,0 +1,94 @@
+package nn
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/autograd"
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestSGD(t *testing.T) {
+	p := NewParam("x", nd.Array(1, -2))
+	o := NewSGD(0.1, 0.5)
+	for _, want := range [][]float64{{0.8, -1.6}, {0.54, -1.08}} {
+		//the gradient of the sum of squares is 2 x
+		p.variable(autograd.NewTape()).Pow(2).Sum().Backward()
+		o.Step([]*Param{p})
+		for i, v := range p.Value.Values() {
+			if math.Abs(v-want[i]) > 1e-12 {
+				t.Error("Expected ", want[i], ", got ", v)
+			}
+		}
+	}
+}
+
+func TestAdam(t *testing.T) {
+	p := NewParam("x", nd.Array(1, -2))
+	o := NewAdam(0.1)
+	p.variable(autograd.NewTape()).Pow(2).Sum().Backward()
+	o.Step([]*Param{p})
+	//the first step has the length of the learning rate in each element
+	if math.Abs(p.Value.Get(0)-0.9) > 1e-6 || math.Abs(p.Value.Get(1)+1.9) > 1e-6 {
+		t.Error("Expected [0.9, -1.9], got ", p.Value)
+	}
+}
+
+func TestRMSProp(t *testing.T) {
+	p := NewParam("x", nd.Array(1, -2))
+	o := NewRMSProp(0.01)
+	p.variable(autograd.NewTape()).Pow(2).Sum().Backward()
+	o.Step([]*Param{p})
+	//the gradient divided by sqrt(0.1) of its magnitude
+	step := 0.01 / math.Sqrt(0.1)
+	if math.Abs(p.Value.Get(0)-(1-step)) > 1e-6 || math.Abs(p.Value.Get(1)-(-2+step)) > 1e-6 {
+		t.Error("Expected ", []float64{1 - step, -2 + step}, ", got ", p.Value)
+	}
+}
+
+func TestSkipWithoutGradient(t *testing.T) {
+	b := NewBatchNorm(2)
+	Predict(b, nd.Zeros(1, 2))
+	for _, o := range []Optimizer{NewSGD(0.1, 0.9), NewAdam(0.1), NewRMSProp(0.1)} {
+		o.Step(b.Params())
+	}
+	if !b.Gamma.Value.Equals(nd.Ones(2)) || !b.RunningVar.Value.Equals(nd.Ones(2)) {
+		t.Error("Expected parameters without gradients unchanged")
+	}
+}
+
+//Train a small network to tell apart the quadrants where the coordinates have the same sign (xor).
+func TestTraining(t *testing.T) {
+	g := random.NewGenerator(9)
+	x := g.Uniform(-1, 1, 200, 2)
+	labels := make([]int, 200)
+	for i := range labels {
+		if x.Get(i, 0)*x.Get(i, 1) > 0 {
+			labels[i] = 1
+		}
+	}
+
+	for name, o := range map[string]Optimizer{"sgd": NewSGD(0.1, 0.9), "adam": NewAdam(0.02), "rmsprop": NewRMSProp(0.01)} {
+		net := NewSequential(NewDense(2, 16, g), ReLU, NewDense(16, 16, g), Tanh, NewDense(16, 2, g))
+		for epoch := 0; epoch < 300; epoch++ {
+			CrossEntropy(net.Forward(autograd.NewTape().Constant(x), true), labels).Backward()
+			o.Step(net.Params())
+		}
+
+		y := Predict(net, x)
+		correct := 0
+		for i, c := range labels {
+			predicted := 0
+			if y.Get(i, 1) > y.Get(i, 0) {
+				predicted = 1
+			}
+			if predicted == c {
+				correct++
+			}
+		}
+		if correct < 180 {
+			t.Error("Expected at least 180 of 200 correct with ", name, ", got ", correct)
+		}
+	}
+}
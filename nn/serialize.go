@@ -0,0 +1,118 @@
+package nn
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/ledao/ndarray/util"
+)
+
+//first bytes of the serialized parameters, with the version of the format
+const magic = "NDNN\x01"
+
+//Write the names, shapes and values of params to w, in a little endian binary format read by Load.
+func Save(w io.Writer, params []*Param) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString(magic)
+	put := func(v uint64) {
+		var b [8]byte
+		binary.LittleEndian.PutUint64(b[:], v)
+		bw.Write(b[:])
+	}
+	put(uint64(len(params)))
+	for _, p := range params {
+		put(uint64(len(p.Name)))
+		bw.WriteString(p.Name)
+		shape := p.Value.Shape()
+		put(uint64(len(shape)))
+		for _, s := range shape {
+			put(uint64(s))
+		}
+		for _, v := range p.Value.Values() {
+			put(math.Float64bits(v))
+		}
+	}
+
+	return bw.Flush()
+}
+
+//Read the values of params, written by Save from parameters of the same names and shapes in the same order,
+//like those of the same network. The values are copied into the existing arrays once all of them are read,
+//so that on an error the parameters are unchanged.
+func Load(r io.Reader, params []*Param) error {
+	br := bufio.NewReader(r)
+	head := make([]byte, len(magic))
+	if _, err := io.ReadFull(br, head); err != nil {
+		return err
+	}
+	if string(head) != magic {
+		return fmt.Errorf("not serialized parameters")
+	}
+	get := func() (uint64, error) {
+		var b [8]byte
+		_, err := io.ReadFull(br, b[:])
+		return binary.LittleEndian.Uint64(b[:]), err
+	}
+
+	n, err := get()
+	if err != nil {
+		return err
+	}
+	if n != uint64(len(params)) {
+		return fmt.Errorf("parameters: %v saved, %v expected", n, len(params))
+	}
+	loaded := make([][]float64, len(params))
+	for j, p := range params {
+		length, err := get()
+		if err != nil {
+			return err
+		}
+		if length != uint64(len(p.Name)) {
+			return fmt.Errorf("parameter name length: %v, expected %q", length, p.Name)
+		}
+		name := make([]byte, length)
+		if _, err := io.ReadFull(br, name); err != nil {
+			return err
+		}
+		if string(name) != p.Name {
+			return fmt.Errorf("parameter: %q, expected %q", name, p.Name)
+		}
+
+		dims, err := get()
+		if err != nil {
+			return err
+		}
+		if dims != uint64(p.Value.NDims()) {
+			return fmt.Errorf("parameter %q: %v dimensions, expected shape %v", p.Name, dims, p.Value.Shape())
+		}
+		shape := make([]int, dims)
+		for i := range shape {
+			s, err := get()
+			if err != nil {
+				return err
+			}
+			shape[i] = int(s)
+		}
+		if !util.EqualOfIntSlice(shape, p.Value.Shape()) {
+			return fmt.Errorf("parameter %q: shape %v, expected %v", p.Name, shape, p.Value.Shape())
+		}
+
+		values := make([]float64, p.Value.Size())
+		for i := range values {
+			bits, err := get()
+			if err != nil {
+				return err
+			}
+			values[i] = math.Float64frombits(bits)
+		}
+		loaded[j] = values
+	}
+	for j, p := range params {
+		copy(p.Value.Values(), loaded[j])
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package nn
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func network(seed uint64) *Sequential {
+	g := random.NewGenerator(seed)
+	return NewSequential(NewConv2D(1, 2, 3, 1, 1, g), NewBatchNorm(2), ReLU, Flatten{}, NewDense(32, 3, g))
+}
+
+func TestSaveLoad(t *testing.T) {
+	src, dst := network(10), network(11)
+	src.Layers[1].(*BatchNorm).RunningMean.Value = nd.Array(0.5, -0.5)
+	var buf bytes.Buffer
+	if err := Save(&buf, src.Params()); err != nil {
+		t.Fatal("Expected no error, got ", err)
+	}
+	if err := Load(&buf, dst.Params()); err != nil {
+		t.Fatal("Expected no error, got ", err)
+	}
+	for i, p := range dst.Params() {
+		if !p.Value.Equals(src.Params()[i].Value) {
+			t.Error("Expected ", p.Name, " loaded, got ", p.Value)
+		}
+	}
+
+	x := random.NewGenerator(12).Normal(0, 1, 2, 1, 4, 4)
+	if !Predict(dst, x).Equals(Predict(src, x)) {
+		t.Error("Expected the same predictions")
+	}
+}
+
+func TestLoadMismatch(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Save(&buf, network(13).Params()); err != nil {
+		t.Fatal("Expected no error, got ", err)
+	}
+	data := buf.Bytes()
+
+	g := random.NewGenerator(14)
+	other := NewSequential(NewConv2D(1, 2, 3, 1, 1, g), NewBatchNorm(2), ReLU, Flatten{}, NewDense(32, 4, g))
+	before := other.Params()[0].Value.Clone()
+	if err := Load(bytes.NewReader(data), other.Params()); err == nil {
+		t.Error("Expected an error for a shape mismatch")
+	}
+	if !other.Params()[0].Value.Equals(before) {
+		t.Error("Expected the parameters unchanged")
+	}
+	if err := Load(bytes.NewReader(data[:len(data)-3]), network(15).Params()); err == nil {
+		t.Error("Expected an error for truncated data")
+	}
+	if err := Load(bytes.NewReader([]byte("nonsense")), network(15).Params()); err == nil {
+		t.Error("Expected an error for data of another format")
+	}
+}
@@ -0,0 +1,233 @@
+package ode
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/calculus"
+	"github.com/ledao/ndarray/nd"
+)
+
+//Radau IIA tableau of order 5, the stages at c, the last at the end of the step.
+var (
+	sqrt6  = math.Sqrt(6)
+	radauC = [3]float64{(4 - sqrt6) / 10, (4 + sqrt6) / 10, 1}
+	radauA = [3][3]float64{
+		{(88 - 7*sqrt6) / 360, (296 - 169*sqrt6) / 1800, (-2 + 3*sqrt6) / 225},
+		{(296 + 169*sqrt6) / 1800, (88 + 7*sqrt6) / 360, (-2 - 3*sqrt6) / 225},
+		{(16 - sqrt6) / 36, (16 + sqrt6) / 36, 1.0 / 9},
+	}
+	//the real eigenvalue of the inverse of radauA, and the weights of the stages in the error estimate, from scipy
+	radauMu = 3 + math.Cbrt(9) - math.Cbrt(3)
+	radauE  = [3]float64{(-13 - 7*sqrt6) / 3, (-13 + 7*sqrt6) / 3, -1.0 / 3}
+)
+
+//most simplified Newton iterations for the stages of a step.
+const newtonLimit = 6
+
+//Radau IIA stepper, keeping the size of the next step and the Jacobian, which is updated
+//when the Newton iterations fail to converge.
+type radau struct {
+	sys *system
+	h   float64
+	jac func(t float64, y *nd.NdArray) *nd.NdArray
+	//the Jacobian, [n * n], and whether it was computed at the current point
+	j     []float64
+	fresh bool
+}
+
+func (r *radau) jacobian(t float64, y []float64) {
+	s := r.sys
+	s.sol.JacEvals++
+	var j *nd.NdArray
+	if r.jac != nil {
+		j = r.jac(t, nd.Array(y...))
+	} else {
+		j = calculus.Jacobian(func(v *nd.NdArray) *nd.NdArray {
+			return nd.Array(s.eval(t, v.Values())...)
+		}, nd.Array(y...))
+	}
+	if j.Size() != s.n*s.n {
+		panic("shape error")
+	}
+	r.j, r.fresh = append([]float64(nil), j.Values()...), true
+}
+
+func (r *radau) step(t float64, y []float64, bound float64) (float64, []float64, interpolant, string) {
+	s := r.sys
+	n := s.n
+	f0 := s.eval(t, y)
+	if r.h == 0 {
+		r.h = s.initialStep(t, y, f0, 3, bound)
+	}
+	if r.j == nil {
+		r.jacobian(t, y)
+	}
+
+	eps := math.Nextafter(1, 2) - 1
+	newtonTol := math.Max(10*eps/s.rtol, math.Min(0.03, math.Sqrt(s.rtol)))
+	rejected := false
+	for {
+		h := math.Min(r.h, s.maxStep)
+		if h < s.minStep(t) {
+			return t, y, nil, "step size too small"
+		}
+		tn, dt := s.advance(t, h, bound)
+
+		z, ok := r.stages(t, y, dt, newtonTol)
+		if !ok {
+			//a Jacobian from the current point first, then a smaller step
+			if r.fresh {
+				r.h = math.Abs(dt) / 2
+				rejected = true
+				s.sol.Rejected++
+			} else {
+				r.jacobian(t, y)
+			}
+			continue
+		}
+		yn := make([]float64, n)
+		for p := range yn {
+			yn[p] = y[p] + z[2][p]
+		}
+
+		//the error estimate solves (mu / dt - J) e = f0 + sum(E z) / dt
+		lhs := nd.Zeros(n, n)
+		ls := lhs.Values()
+		rhs := make([]float64, n)
+		for p := 0; p < n; p++ {
+			for q := 0; q < n; q++ {
+				ls[p*n+q] = -r.j[p*n+q]
+			}
+			ls[p*n+p] += radauMu / dt
+			rhs[p] = f0[p]
+			for i := range z {
+				rhs[p] += radauE[i] * z[i][p] / dt
+			}
+		}
+		e := lhs.Solve(nd.Array(rhs...)).Values()
+		errNorm := s.norm(e, y, yn)
+		if errNorm >= 1 {
+			r.h = math.Abs(dt) * math.Max(0.2, 0.9*math.Pow(errNorm, -0.25))
+			rejected = true
+			s.sol.Rejected++
+			continue
+		}
+
+		factor := 10.0
+		if errNorm > 0 {
+			factor = math.Min(10, 0.9*math.Pow(errNorm, -0.25))
+		}
+		if rejected {
+			factor = math.Min(1, factor)
+		}
+		r.h = math.Abs(dt) * factor
+		r.fresh = false
+
+		return tn, yn, collocation(t, dt, y, z), ""
+	}
+}
+
+//Simplified Newton iterations for the stage increments z[i] = dt sum(A[i][j] f(t + c[j] dt, y + z[j])),
+//with the Jacobian at the start of the step. false when they do not converge.
+func (r *radau) stages(t float64, y []float64, dt, tol float64) ([3][]float64, bool) {
+	s := r.sys
+	n := s.n
+	var z [3][]float64
+	for i := range z {
+		z[i] = make([]float64, n)
+	}
+
+	//I - dt A (x) J, for the 3n stage unknowns
+	m := nd.Zeros(3*n, 3*n)
+	ms := m.Values()
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			for p := 0; p < n; p++ {
+				for q := 0; q < n; q++ {
+					ms[(i*n+p)*3*n+j*n+q] = -dt * radauA[i][j] * r.j[p*n+q]
+				}
+			}
+		}
+	}
+	for i := 0; i < 3*n; i++ {
+		ms[i*3*n+i]++
+	}
+
+	prev := 0.0
+	for iter := 0; iter < newtonLimit; iter++ {
+		var fs [3][]float64
+		for j := range fs {
+			yj := make([]float64, n)
+			for p := range yj {
+				yj[p] = y[p] + z[j][p]
+			}
+			fs[j] = s.eval(t+radauC[j]*dt, yj)
+		}
+		rhs := make([]float64, 3*n)
+		for i := 0; i < 3; i++ {
+			for p := 0; p < n; p++ {
+				v := -z[i][p]
+				for j := 0; j < 3; j++ {
+					v += dt * radauA[i][j] * fs[j][p]
+				}
+				rhs[i*n+p] = v
+			}
+		}
+		for _, v := range rhs {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return z, false
+			}
+		}
+
+		dz := m.Solve(nd.Array(rhs...)).Values()
+		for i := range z {
+			for p := range z[i] {
+				z[i][p] += dz[i*n+p]
+			}
+		}
+		sum := 0.0
+		for k, v := range dz {
+			v /= s.atol + s.rtol*math.Abs(y[k%n])
+			sum += v * v
+		}
+		norm := math.Sqrt(sum / float64(3*n))
+		if norm == 0 {
+			return z, true
+		}
+		if iter > 0 {
+			rate := norm / prev
+			if rate >= 1 {
+				return z, false
+			}
+			if rate/(1-rate)*norm < tol {
+				return z, true
+			}
+		}
+		prev = norm
+	}
+
+	return z, false
+}
+
+//the collocation polynomial through y at t and y + z[i] at t + c[i] dt.
+func collocation(t, dt float64, y []float64, z [3][]float64) interpolant {
+	y0 := append([]float64(nil), y...)
+	nodes := [4]float64{0, radauC[0], radauC[1], radauC[2]}
+	return func(at float64) []float64 {
+		x := (at - t) / dt
+		r := append([]float64(nil), y0...)
+		//Lagrange basis on the nodes, the one of 0 multiplies no increment
+		for i := 1; i < 4; i++ {
+			l := 1.0
+			for k, c := range nodes {
+				if k != i {
+					l *= (x - c) / (nodes[i] - c)
+				}
+			}
+			for p := range r {
+				r[p] += l * z[i-1][p]
+			}
+		}
+		return r
+	}
+}
@@ -0,0 +1,55 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Robertson's chemical kinetics, stiff with rates from 0.04 to 3e7.
+func robertson(t float64, y *nd.NdArray) *nd.NdArray {
+	a, b, c := y.Get(0), y.Get(1), y.Get(2)
+	return nd.Array(-0.04*a+1e4*b*c, 0.04*a-1e4*b*c-3e7*b*b, 3e7*b*b)
+}
+
+func TestRadau(t *testing.T) {
+	decay := func(t float64, y *nd.NdArray) *nd.NdArray { return y.Map(func(v float64) float64 { return -2 * v }) }
+	sol := Solve(decay, 0, 3, nd.Array(1), Radau, &Options{RTol: 1e-8, ATol: 1e-12})
+	k := sol.T.Size()
+	if !sol.Success || math.Abs(sol.Y.Get(k-1, 0)-math.Exp(-6)) > 1e-9 {
+		t.Error("Expected exp(-6), got ", sol.Y.Get(k-1, 0), sol.Message)
+	}
+
+	opts := &Options{RTol: 1e-6, ATol: 1e-10}
+	sol = Solve(robertson, 0, 1e4, nd.Array(1, 0, 0), Radau, opts)
+	k = sol.T.Size()
+	sum := sol.Y.Get(k-1, 0) + sol.Y.Get(k-1, 1) + sol.Y.Get(k-1, 2)
+	if !sol.Success || sol.Steps > 500 || math.Abs(sum-1) > 1e-6 {
+		t.Error("Expected to reach the end in few steps conserving mass, got ", sol.Steps, sum, sol.Message)
+	}
+	//the reference values of Hairer and Wanner at 40
+	sol = Solve(robertson, 0, 40, nd.Array(1, 0, 0), Radau, opts)
+	k = sol.T.Size()
+	if math.Abs(sol.Y.Get(k-1, 0)-0.7158271) > 1e-6 || math.Abs(sol.Y.Get(k-1, 1)-9.185535e-6)/9.185535e-6 > 1e-5 {
+		t.Error("Expected [0.7158271, 9.185535e-6, ...], got ", sol.Y.Get(k-1, 0), sol.Y.Get(k-1, 1))
+	}
+
+	//the explicit method needs many more calls for the same problem over a short interval
+	explicit := Solve(robertson, 0, 10, nd.Array(1, 0, 0), RK45, opts)
+	implicit := Solve(robertson, 0, 10, nd.Array(1, 0, 0), Radau, opts)
+	if implicit.FuncEvals*3 > explicit.FuncEvals {
+		t.Error("Expected Radau to use fewer calls, got ", implicit.FuncEvals, explicit.FuncEvals)
+	}
+
+	//an analytic Jacobian is used instead of differences
+	jac := func(t float64, y *nd.NdArray) *nd.NdArray {
+		b, c := y.Get(1), y.Get(2)
+		return nd.Array(-0.04, 1e4*c, 1e4*b, 0.04, -1e4*c-6e7*b, -1e4*b, 0, 6e7*b, 0).Reshape(3, 3)
+	}
+	withJac := Solve(robertson, 0, 10, nd.Array(1, 0, 0), Radau, &Options{RTol: 1e-6, ATol: 1e-10, Jac: jac})
+	kj, ki := withJac.T.Size(), implicit.T.Size()
+	if withJac.FuncEvals >= implicit.FuncEvals || math.Abs(withJac.Y.Get(kj-1, 0)-implicit.Y.Get(ki-1, 0)) > 1e-5 {
+		t.Error("Expected the same solution in fewer calls, got ", withJac.FuncEvals, implicit.FuncEvals)
+	}
+}
@@ -0,0 +1,167 @@
+package ode
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Integrate dy/dt = f(t, y) from t0 to t1 starting at y0 by the classical fourth order Runge-Kutta method
+//with steps equal steps. The solution has the steps + 1 times and states, and no error control.
+func RK4(f func(t float64, y *nd.NdArray) *nd.NdArray, t0, t1 float64, y0 *nd.NdArray, steps int) *Solution {
+	if y0.NDims() != 1 || y0.Size() == 0 {
+		panic("shape error")
+	}
+	if steps < 1 {
+		panic(fmt.Errorf("steps: %v must be positive", steps))
+	}
+
+	sol := &Solution{Success: true, Message: "reached the end of the interval"}
+	n := y0.Size()
+	sys := &system{f: f, n: n, sol: sol}
+	h := (t1 - t0) / float64(steps)
+	ts, ys := nd.Zeros(steps+1), nd.Zeros(steps+1, n)
+	y := append([]float64(nil), y0.Values()...)
+	ts.Set(t0, 0)
+	copy(ys.Values(), y)
+
+	at := func(y, k []float64, c float64) []float64 {
+		r := make([]float64, n)
+		for i := range r {
+			r[i] = y[i] + c*k[i]
+		}
+		return r
+	}
+	for s := 1; s <= steps; s++ {
+		t := t0 + float64(s-1)*h
+		k1 := sys.eval(t, y)
+		k2 := sys.eval(t+h/2, at(y, k1, h/2))
+		k3 := sys.eval(t+h/2, at(y, k2, h/2))
+		k4 := sys.eval(t+h, at(y, k3, h))
+		for i := range y {
+			y[i] += h / 6 * (k1[i] + 2*k2[i] + 2*k3[i] + k4[i])
+		}
+		ts.Set(t0+float64(s)*h, s)
+		copy(ys.Values()[s*n:], y)
+	}
+	sol.T, sol.Y, sol.Steps = ts, ys, steps
+
+	return sol
+}
+
+//Dormand-Prince tableau, the last stage is the derivative at the new point.
+var (
+	dopriC = [7]float64{0, 1.0 / 5, 3.0 / 10, 4.0 / 5, 8.0 / 9, 1, 1}
+	dopriA = [7][6]float64{
+		{},
+		{1.0 / 5},
+		{3.0 / 40, 9.0 / 40},
+		{44.0 / 45, -56.0 / 15, 32.0 / 9},
+		{19372.0 / 6561, -25360.0 / 2187, 64448.0 / 6561, -212.0 / 729},
+		{9017.0 / 3168, -355.0 / 33, 46732.0 / 5247, 49.0 / 176, -5103.0 / 18656},
+		{35.0 / 384, 0, 500.0 / 1113, 125.0 / 192, -2187.0 / 6784, 11.0 / 84},
+	}
+	//difference of the order 5 and order 4 solutions
+	dopriE = [7]float64{-71.0 / 57600, 0, 71.0 / 16695, -71.0 / 1920, 17253.0 / 339200, -22.0 / 525, 1.0 / 40}
+	//coefficients of the order 4 interpolant in powers of the fraction of the step, from scipy
+	dopriP = [7][4]float64{
+		{1, -8048581381.0 / 2820520608, 8663915743.0 / 2820520608, -12715105075.0 / 11282082432},
+		{0, 0, 0, 0},
+		{0, 131558114200.0 / 32700410799, -68118460800.0 / 10900136933, 87487479700.0 / 32700410799},
+		{0, -1754552775.0 / 470086768, 14199869525.0 / 1410260304, -10690763975.0 / 1880347072},
+		{0, 127303824393.0 / 49829197408, -318862633887.0 / 49829197408, 701980252875.0 / 199316789632},
+		{0, -282668133.0 / 205662961, 2019193451.0 / 616988883, -1453857185.0 / 822651844},
+		{0, 40617522.0 / 29380423, -110615467.0 / 29380423, 69997945.0 / 29380423},
+	}
+)
+
+//Dormand-Prince stepper, keeping the size of the next step and the derivative at the current point.
+type dopri struct {
+	sys *system
+	h   float64
+	f   []float64
+}
+
+func (d *dopri) step(t float64, y []float64, bound float64) (float64, []float64, interpolant, string) {
+	s := d.sys
+	if d.f == nil {
+		d.f = s.eval(t, y)
+		if d.h == 0 {
+			d.h = s.initialStep(t, y, d.f, 4, bound)
+		}
+	}
+
+	n := len(y)
+	k := make([][]float64, 7)
+	rejected := false
+	for {
+		h := math.Min(d.h, s.maxStep)
+		if h < s.minStep(t) {
+			return t, y, nil, "step size too small"
+		}
+		tn, dt := s.advance(t, h, bound)
+
+		k[0] = d.f
+		var yn []float64
+		for i := 1; i < 7; i++ {
+			yi := append([]float64(nil), y...)
+			for j := 0; j < i; j++ {
+				if a := dopriA[i][j]; a != 0 {
+					for p := range yi {
+						yi[p] += dt * a * k[j][p]
+					}
+				}
+			}
+			if i == 6 {
+				yn = yi
+			}
+			k[i] = s.eval(t+dopriC[i]*dt, yi)
+		}
+
+		e := make([]float64, n)
+		for j, c := range dopriE {
+			for p := range e {
+				e[p] += dt * c * k[j][p]
+			}
+		}
+		errNorm := s.norm(e, y, yn)
+		if errNorm >= 1 {
+			d.h = math.Abs(dt) * math.Max(0.2, 0.9*math.Pow(errNorm, -0.2))
+			s.sol.Rejected++
+			rejected = true
+			continue
+		}
+
+		factor := 10.0
+		if errNorm > 0 {
+			factor = math.Min(10, 0.9*math.Pow(errNorm, -0.2))
+		}
+		if rejected {
+			factor = math.Min(1, factor)
+		}
+		d.h = math.Abs(dt) * factor
+		d.f = k[6]
+
+		//q[p] holds the coefficients of the interpolant of element p
+		q := make([][4]float64, n)
+		for j := range k {
+			for p := range q {
+				for m := 0; m < 4; m++ {
+					q[p][m] += k[j][p] * dopriP[j][m]
+				}
+			}
+		}
+		y0 := append([]float64(nil), y...)
+		interp := func(at float64) []float64 {
+			x := (at - t) / dt
+			r := make([]float64, n)
+			for p := range r {
+				r[p] = y0[p] + dt*x*(q[p][0]+x*(q[p][1]+x*(q[p][2]+x*q[p][3])))
+			}
+			return r
+		}
+
+		return tn, yn, interp, ""
+	}
+}
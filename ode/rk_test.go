@@ -0,0 +1,60 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestRK4(t *testing.T) {
+	decay := func(t float64, y *nd.NdArray) *nd.NdArray { return y.Map(func(v float64) float64 { return -v }) }
+	sol := RK4(decay, 0, 1, nd.Array(1, 2), 10)
+	if !sol.T.Equals(nd.Arange(11).Map(func(v float64) float64 { return v / 10 })) {
+		t.Error("Expected 11 times from 0 to 1, got ", sol.T)
+	}
+	if !nd.Array(sol.Y.Get(10, 0), sol.Y.Get(10, 1)).Equals(nd.Array(math.Exp(-1), 2*math.Exp(-1))) {
+		t.Error("Expected [1/e, 2/e], got ", sol.Y.Get(10, 0), sol.Y.Get(10, 1))
+	}
+	if sol.FuncEvals != 40 || sol.Steps != 10 {
+		t.Error("Expected 4 calls per step, got ", sol.FuncEvals, sol.Steps)
+	}
+
+	//the error falls as the fourth power of the step
+	errAt := func(steps int) float64 {
+		s := RK4(decay, 0, 1, nd.Array(1), steps)
+		return math.Abs(s.Y.Get(steps, 0) - math.Exp(-1))
+	}
+	if ratio := errAt(10) / errAt(20); ratio < 14 || ratio > 18 {
+		t.Error("Expected an error ratio near 16, got ", ratio)
+	}
+}
+
+func TestRK45(t *testing.T) {
+	//harmonic oscillator y'' = -y, y = cos t
+	osc := func(t float64, y *nd.NdArray) *nd.NdArray { return nd.Array(y.Get(1), -y.Get(0)) }
+	sol := Solve(osc, 0, 10, nd.Array(1, 0), RK45, &Options{RTol: 1e-8, ATol: 1e-10})
+	k := sol.T.Size()
+	if !sol.Success || sol.T.Get(k-1) != 10 {
+		t.Error("Expected to reach 10, got ", sol.T.Get(k-1), sol.Message)
+	}
+	if math.Abs(sol.Y.Get(k-1, 0)-math.Cos(10)) > 1e-6 || math.Abs(sol.Y.Get(k-1, 1)+math.Sin(10)) > 1e-6 {
+		t.Error("Expected [cos 10, -sin 10], got ", sol.Y.Get(k-1, 0), sol.Y.Get(k-1, 1))
+	}
+	if sol.Steps+1 != k || sol.FuncEvals < 6*sol.Steps {
+		t.Error("Expected a point per step and 6 calls per step, got ", k, sol.Steps, sol.FuncEvals)
+	}
+
+	//looser tolerances take fewer steps
+	loose := Solve(osc, 0, 10, nd.Array(1, 0), RK45, nil)
+	if loose.Steps >= sol.Steps {
+		t.Error("Expected fewer steps, got ", loose.Steps, sol.Steps)
+	}
+
+	//backwards in time
+	back := Solve(osc, 0, -2, nd.Array(1, 0), RK45, &Options{RTol: 1e-8, ATol: 1e-10})
+	k = back.T.Size()
+	if back.T.Get(k-1) != -2 || math.Abs(back.Y.Get(k-1, 1)-math.Sin(2)) > 1e-6 {
+		t.Error("Expected sin 2 at -2, got ", back.Y.Get(k-1, 1))
+	}
+}
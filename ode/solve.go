@@ -0,0 +1,327 @@
+//Package ode integrates initial value problems dy/dt = f(t, y) for states held in 1d *nd.NdArray.
+//RK4 takes fixed steps. Solve adapts the steps to tolerances with the explicit Dormand-Prince pair
+//RK45, or with the implicit Radau IIA method for stiff systems, like scipy.integrate.solve_ivp,
+//and supports dense output and events.
+package ode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/optimize"
+)
+
+//Integration method of Solve.
+type Method int
+
+const (
+	//explicit Runge-Kutta of order 5 with an embedded order 4 error estimate (Dormand-Prince)
+	RK45 Method = iota
+	//implicit Runge-Kutta of order 5 (Radau IIA), for stiff systems
+	Radau
+)
+
+//A zero crossing of F(t, y) to locate during Solve.
+type Event struct {
+	F func(t float64, y *nd.NdArray) float64
+	//stop the integration at the first crossing
+	Terminal bool
+	//only crossings from negative to positive when > 0, from positive to negative when < 0, both when 0
+	Direction int
+}
+
+//Settings of Solve, nil for the defaults.
+type Options struct {
+	//relative and absolute tolerances on each element of y, 1e-3 and 1e-6 when 0
+	RTol, ATol float64
+	//first step, chosen from f when 0, and the largest step, unlimited when 0
+	FirstStep, MaxStep float64
+	//times to report the solution at, in the direction of integration, nil for the end of every step
+	TEval *nd.NdArray
+	//keep the interpolant of every step in Solution.Dense
+	DenseOutput bool
+	Events      []Event
+	//Jacobian of f with respect to y, [n, n], for Radau, estimated by central differences when nil
+	Jac func(t float64, y *nd.NdArray) *nd.NdArray
+}
+
+//Result of an integration.
+type Solution struct {
+	//the times [k] and the states [k, n] there
+	T, Y *nd.NdArray
+	//the solution at any time between the start and the end, when DenseOutput is set
+	Dense *Dense
+	//the crossings of each event, their times and the states there
+	EventTimes  [][]float64
+	EventStates [][]*nd.NdArray
+	//accepted and rejected steps, calls of f and of the Jacobian, including those estimating it
+	Steps, Rejected, FuncEvals, JacEvals int
+	//false when the step size became too small
+	Success bool
+	Message string
+}
+
+//Continuous solution, made of the interpolants of the steps.
+type Dense struct {
+	ts       []float64
+	segments []interpolant
+	//the initial state, the solution everywhere when no step was taken
+	y0 []float64
+}
+
+//solution at times in [start, end], for the step from start to end.
+type interpolant func(t float64) []float64
+
+//The solution at t, extrapolated beyond the ends, the initial state when no step was taken.
+func (d *Dense) At(t float64) *nd.NdArray {
+	n := len(d.segments)
+	if n == 0 {
+		return nd.Array(d.y0...)
+	}
+	//ts is monotonic in the direction of integration
+	i := sort.Search(n, func(i int) bool {
+		if d.ts[n] >= d.ts[0] {
+			return d.ts[i+1] >= t
+		}
+		return d.ts[i+1] <= t
+	})
+	if i == n {
+		i = n - 1
+	}
+
+	return nd.Array(d.segments[i](t)...)
+}
+
+//one adaptive step method.
+type stepper interface {
+	//an accepted step from (t, y) towards bound, the new point, the interpolant over the step,
+	//and "" or why no step could be taken
+	step(t float64, y []float64, bound float64) (float64, []float64, interpolant, string)
+}
+
+//Integrate dy/dt = f(t, y) from t0 to t1 starting at y0, with method and opts, nil for the defaults.
+//t1 may be before t0. The steps are chosen so that the local error estimate of each element stays below
+//ATol + RTol |y|.
+func Solve(f func(t float64, y *nd.NdArray) *nd.NdArray, t0, t1 float64, y0 *nd.NdArray, method Method, opts *Options) *Solution {
+	if y0.NDims() != 1 || y0.Size() == 0 {
+		panic("shape error")
+	}
+	if opts == nil {
+		opts = &Options{}
+	}
+	rtol, atol := opts.RTol, opts.ATol
+	if rtol == 0 {
+		rtol = 1e-3
+	}
+	if atol == 0 {
+		atol = 1e-6
+	}
+	if rtol < 0 || atol < 0 || opts.FirstStep < 0 || opts.MaxStep < 0 {
+		panic(fmt.Errorf("tolerances and steps must not be negative"))
+	}
+	maxStep := opts.MaxStep
+	if maxStep == 0 {
+		maxStep = math.Inf(1)
+	}
+
+	sol := &Solution{Success: true, Message: "reached the end of the interval"}
+	n := y0.Size()
+	sys := &system{f: f, n: n, rtol: rtol, atol: atol, maxStep: maxStep, dir: 1, sol: sol}
+	if t1 < t0 {
+		sys.dir = -1
+	}
+
+	var s stepper
+	switch method {
+	case RK45:
+		s = &dopri{sys: sys, h: opts.FirstStep}
+	case Radau:
+		s = &radau{sys: sys, h: opts.FirstStep, jac: opts.Jac}
+	default:
+		panic(fmt.Errorf("method: %v unknown", method))
+	}
+
+	var teval []float64
+	if opts.TEval != nil {
+		teval = opts.TEval.Values()
+		for i, te := range teval {
+			if sys.dir*(te-t0) < 0 || sys.dir*(te-t1) > 0 || (i > 0 && sys.dir*(te-teval[i-1]) < 0) {
+				panic(fmt.Errorf("evaluation times: %v must be ordered within [%v, %v]", teval, t0, t1))
+			}
+		}
+	}
+
+	var ts []float64
+	var ys [][]float64
+	record := func(t float64, y []float64) {
+		ts, ys = append(ts, t), append(ys, y)
+	}
+	t, y := t0, append([]float64(nil), y0.Values()...)
+	if teval == nil {
+		record(t, y)
+	}
+	for len(teval) > 0 && teval[0] == t0 {
+		record(t, y)
+		teval = teval[1:]
+	}
+
+	if opts.DenseOutput {
+		sol.Dense = &Dense{ts: []float64{t0}, y0: append([]float64(nil), y0.Values()...)}
+	}
+	sol.EventTimes, sol.EventStates = make([][]float64, len(opts.Events)), make([][]*nd.NdArray, len(opts.Events))
+	values := make([]float64, len(opts.Events))
+	for k, e := range opts.Events {
+		values[k] = e.F(t, nd.Array(y...))
+	}
+
+	for sys.dir*(t1-t) > 0 {
+		tn, yn, interp, failure := s.step(t, y, t1)
+		if failure != "" {
+			sol.Success, sol.Message = false, failure
+			break
+		}
+		sol.Steps++
+
+		stop := false
+		if len(opts.Events) > 0 {
+			tn, yn, stop = events(opts.Events, values, t, tn, yn, interp, sys.dir, sol)
+		}
+		if sol.Dense != nil {
+			sol.Dense.ts = append(sol.Dense.ts, tn)
+			sol.Dense.segments = append(sol.Dense.segments, interp)
+		}
+		if opts.TEval != nil {
+			for len(teval) > 0 && sys.dir*(teval[0]-tn) <= 0 {
+				record(teval[0], interp(teval[0]))
+				teval = teval[1:]
+			}
+		} else {
+			record(tn, yn)
+		}
+
+		t, y = tn, yn
+		if stop {
+			sol.Message = "a terminal event occurred"
+			break
+		}
+	}
+
+	sol.T = nd.Array(ts...)
+	sol.Y = nd.Zeros(len(ts), n)
+	for i, v := range ys {
+		copy(sol.Y.Values()[i*n:], v)
+	}
+
+	return sol
+}
+
+//Record the crossings of the events within the step from t to tn, whose values at t are in values.
+//At a terminal crossing the step is cut short there, and the new end, state, and true are returned.
+func events(evs []Event, values []float64, t, tn float64, yn []float64, interp interpolant, dir float64, sol *Solution) (float64, []float64, bool) {
+	type crossing struct {
+		k int
+		t float64
+	}
+	var found []crossing
+	next := make([]float64, len(evs))
+	for k, e := range evs {
+		next[k] = e.F(tn, nd.Array(yn...))
+		up, down := values[k] < 0 && next[k] >= 0, values[k] > 0 && next[k] <= 0
+		if (up && e.Direction >= 0) || (down && e.Direction <= 0) {
+			g := func(s float64) float64 { return e.F(s, nd.Array(interp(s)...)) }
+			root := optimize.Brentq(g, t, tn, 4*(math.Nextafter(1, 2)-1), 100)
+			found = append(found, crossing{k, root.X})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return dir*(found[i].t-found[j].t) < 0 })
+
+	stop := false
+	for _, c := range found {
+		state := interp(c.t)
+		sol.EventTimes[c.k] = append(sol.EventTimes[c.k], c.t)
+		sol.EventStates[c.k] = append(sol.EventStates[c.k], nd.Array(state...))
+		if evs[c.k].Terminal {
+			tn, yn, stop = c.t, state, true
+			break
+		}
+	}
+	copy(values, next)
+
+	return tn, yn, stop
+}
+
+//The problem and the settings shared by the steppers.
+type system struct {
+	f                   func(t float64, y *nd.NdArray) *nd.NdArray
+	n                   int
+	rtol, atol, maxStep float64
+	//1 forwards in time, -1 backwards
+	dir float64
+	sol *Solution
+}
+
+func (s *system) eval(t float64, y []float64) []float64 {
+	s.sol.FuncEvals++
+	r := s.f(t, nd.Array(y...))
+	if r.Size() != s.n {
+		panic("shape error")
+	}
+
+	return append([]float64(nil), r.Values()...)
+}
+
+//root mean square of e / (atol + rtol max(|y|, |yn|)).
+func (s *system) norm(e, y, yn []float64) float64 {
+	sum := 0.0
+	for i, v := range e {
+		scale := s.atol + s.rtol*math.Max(math.Abs(y[i]), math.Abs(yn[i]))
+		sum += (v / scale) * (v / scale)
+	}
+
+	return math.Sqrt(sum / float64(len(e)))
+}
+
+//smallest step that changes t.
+func (s *system) minStep(t float64) float64 {
+	return 10 * math.Abs(math.Nextafter(t, s.dir*math.Inf(1))-t)
+}
+
+//the end of a step of size h from t, kept within bound, and the signed step.
+func (s *system) advance(t, h, bound float64) (float64, float64) {
+	tn := t + s.dir*h
+	if s.dir*(tn-bound) > 0 {
+		tn = bound
+	}
+
+	return tn, tn - t
+}
+
+//First step for a method of the given order, from the size of y and its first two derivatives at t
+//(Hairer, Norsett and Wanner, section II.4).
+func (s *system) initialStep(t float64, y, f []float64, order, bound float64) float64 {
+	d0, d1 := s.norm(y, y, y), s.norm(f, y, y)
+	h0 := 0.01 * d0 / d1
+	if d0 < 1e-5 || d1 < 1e-5 {
+		h0 = 1e-6
+	}
+	h0 = math.Min(h0, math.Abs(bound-t))
+	y1 := make([]float64, len(y))
+	for i := range y {
+		y1[i] = y[i] + s.dir*h0*f[i]
+	}
+	f1 := s.eval(t+s.dir*h0, y1)
+	diff := make([]float64, len(f))
+	for i := range f {
+		diff[i] = f1[i] - f[i]
+	}
+	d2 := s.norm(diff, y, y) / h0
+
+	h1 := math.Max(1e-6, h0*1e-3)
+	if d1 > 1e-15 || d2 > 1e-15 {
+		h1 = math.Pow(0.01/math.Max(d1, d2), 1/(order+1))
+	}
+
+	return math.Min(math.Min(100*h0, h1), s.maxStep)
+}
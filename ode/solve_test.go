@@ -0,0 +1,67 @@
+package ode
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestTEvalAndDense(t *testing.T) {
+	osc := func(t float64, y *nd.NdArray) *nd.NdArray { return nd.Array(y.Get(1), -y.Get(0)) }
+	for _, method := range []Method{RK45, Radau} {
+		times := nd.Array(0, 0.5, 1, 2.5, 4)
+		sol := Solve(osc, 0, 4, nd.Array(1, 0), method, &Options{RTol: 1e-8, ATol: 1e-10, TEval: times, DenseOutput: true})
+		if !sol.T.Equals(times) {
+			t.Error("Expected the evaluation times, got ", sol.T)
+		}
+		if !sol.Y.Reshape(10).Equals(nd.Array(1, 0, math.Cos(0.5), -math.Sin(0.5), math.Cos(1), -math.Sin(1),
+			math.Cos(2.5), -math.Sin(2.5), math.Cos(4), -math.Sin(4))) {
+			t.Error("Expected cos and -sin, got ", sol.Y)
+		}
+		for _, at := range []float64{0.1, 1.7, 3.3} {
+			if !sol.Dense.At(at).Equals(nd.Array(math.Cos(at), -math.Sin(at))) {
+				t.Error("Expected the dense output at ", at, ", got ", sol.Dense.At(at))
+			}
+		}
+	}
+}
+
+func TestDenseWithoutSteps(t *testing.T) {
+	f := func(t float64, y *nd.NdArray) *nd.NdArray { return y }
+	sol := Solve(f, 1, 1, nd.Array(2, 3), RK45, &Options{DenseOutput: true})
+	if sol.Steps != 0 || !sol.Dense.At(1).Equals(nd.Array(2, 3)) || !sol.Dense.At(5).Equals(nd.Array(2, 3)) {
+		t.Error("Expected the initial state without steps, got ", sol.Steps, sol.Dense.At(1))
+	}
+}
+
+func TestEvents(t *testing.T) {
+	//a ball thrown up at 10 m/s from 2 m, g = 9.81: height, velocity
+	ball := func(t float64, y *nd.NdArray) *nd.NdArray { return nd.Array(y.Get(1), -9.81) }
+	ground := Event{F: func(t float64, y *nd.NdArray) float64 { return y.Get(0) }, Terminal: true, Direction: -1}
+	apex := Event{F: func(t float64, y *nd.NdArray) float64 { return y.Get(1) }}
+	sol := Solve(ball, 0, 10, nd.Array(2, 10), RK45, &Options{Events: []Event{ground, apex}})
+
+	landing := (10 + math.Sqrt(100+4*9.81)) / 9.81
+	k := sol.T.Size()
+	if len(sol.EventTimes[0]) != 1 || math.Abs(sol.EventTimes[0][0]-landing) > 1e-9 {
+		t.Error("Expected landing at ", landing, ", got ", sol.EventTimes[0])
+	}
+	if sol.T.Get(k-1) != sol.EventTimes[0][0] || math.Abs(sol.Y.Get(k-1, 0)) > 1e-9 {
+		t.Error("Expected to stop on the ground, got ", sol.T.Get(k-1), sol.Y.Get(k-1, 0), sol.Message)
+	}
+	if len(sol.EventTimes[1]) != 1 || math.Abs(sol.EventTimes[1][0]-10/9.81) > 1e-9 {
+		t.Error("Expected the apex at ", 10/9.81, ", got ", sol.EventTimes[1])
+	}
+	if h := sol.EventStates[1][0].Get(0); math.Abs(h-(2+100/(2*9.81))) > 1e-9 {
+		t.Error("Expected the apex height, got ", h)
+	}
+
+	//rising crossings only
+	up := Event{F: func(t float64, y *nd.NdArray) float64 { return y.Get(0) }, Direction: 1}
+	osc := func(t float64, y *nd.NdArray) *nd.NdArray { return nd.Array(y.Get(1), -y.Get(0)) }
+	sol = Solve(osc, 0, 10, nd.Array(0, 1), RK45, &Options{RTol: 1e-8, ATol: 1e-10, Events: []Event{up}})
+	if len(sol.EventTimes[0]) != 1 || math.Abs(sol.EventTimes[0][0]-2*math.Pi) > 1e-6 {
+		t.Error("Expected one rising crossing at 2 pi, got ", sol.EventTimes[0])
+	}
+}
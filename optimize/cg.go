@@ -0,0 +1,54 @@
+package optimize
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Minimize f from x0 by the nonlinear conjugate gradient method of Polak and Ribiere, like
+//scipy.optimize.minimize with method CG. The direction is restarted along the negative gradient every
+//len(x0) iterations and whenever the Polak-Ribiere coefficient is negative. It stops when no element
+//of the gradient exceeds tol in magnitude, or after maxIter iterations. grad may be nil.
+func CG(f func(*nd.NdArray) float64, grad func(*nd.NdArray) *nd.NdArray, x0 *nd.NdArray, maxIter int, tol float64) *Result {
+	o := &objective{f: f, grad: grad}
+	x := start(x0)
+	n := len(x)
+	fx, g := o.value(x), o.gradient(x)
+
+	d := negate(g)
+	alpha := math.Min(1, 1/infNorm(g))
+	for iter := 0; ; iter++ {
+		if infNorm(g) <= tol {
+			return o.result(x, fx, g, iter, true, msgGradient)
+		}
+		if iter >= maxIter {
+			return o.result(x, fx, g, iter, false, msgMaxIter)
+		}
+
+		p, ok := wolfeSearch(o, x, d, fx, g, alpha, 0.4)
+		if !ok {
+			return o.result(x, fx, g, iter, false, msgLineSearch)
+		}
+
+		//beta = g1.(g1 - g0) / g0.g0, at least 0
+		beta := 0.0
+		if (iter+1)%n != 0 {
+			beta = math.Max(0, (dot(p.g, p.g)-dot(p.g, g))/dot(g, g))
+		}
+		next := make([]float64, n)
+		for i := range next {
+			next[i] = -p.g[i] + beta*d[i]
+		}
+		if dot(next, p.g) >= 0 {
+			next = negate(p.g)
+		}
+		//the first step of the next search expects the same decrease along the new direction
+		alpha = p.alpha * dot(g, d) / dot(p.g, next)
+		if !(alpha > 0) || math.IsInf(alpha, 0) {
+			alpha = 1
+		}
+
+		x, fx, g, d = step(x, p.alpha, d), p.f, p.g, next
+	}
+}
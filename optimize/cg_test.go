@@ -0,0 +1,26 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestCG(t *testing.T) {
+	r := CG(rosen, rosenGrad, nd.Array(-1.2, 1), 1000, 1e-6)
+	if !r.Converged || !r.X.Equals(nd.Array(1, 1)) {
+		t.Error("Expected convergence to [1, 1], got ", r.X, r.Message)
+	}
+
+	//a quadratic in n dimensions takes about n iterations
+	a := nd.Array(4, 1, 0, 1, 3, 1, 0, 1, 2).Reshape(3, 3)
+	b := nd.Array(1, 2, 3)
+	quadratic := func(x *nd.NdArray) float64 {
+		return nd.MatMul(x, nd.MatMul(a, x)).Get(0)/2 - nd.MatMul(b, x).Get(0)
+	}
+	grad := func(x *nd.NdArray) *nd.NdArray { return nd.MatMul(a, x).Sub(b) }
+	r = CG(quadratic, grad, nd.Zeros(3), 100, 1e-8)
+	if !r.Converged || !r.X.Equals(a.Solve(b)) || r.Iterations > 10 {
+		t.Error("Expected ", a.Solve(b), " in a few iterations, got ", r.X, r.Iterations)
+	}
+}
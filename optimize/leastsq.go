@@ -0,0 +1,184 @@
+package optimize
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/calculus"
+	"github.com/ledao/ndarray/nd"
+)
+
+//Outcome of CurveFit: the parameters in X and their covariance.
+type FitResult struct {
+	*Result
+	Cov *nd.NdArray
+}
+
+//Minimize half the sum of squares of residual(x) from x0 by the Levenberg-Marquardt method,
+//like scipy.optimize.least_squares with method lm. jac gives the Jacobian of the residuals, [m, n]
+//for m residuals and n parameters, it may be nil. It stops when no element of the gradient exceeds tol
+//in magnitude, when a step changes no parameter by more than tol relative to the largest, when the cost
+//decreases by less than tol relative, or after maxIter iterations, including the rejected steps.
+func LeastSquares(residual, jac func(*nd.NdArray) *nd.NdArray, x0 *nd.NdArray, maxIter int, tol float64) *Result {
+	return levenberg(residual, jac, x0, maxIter, tol, false)
+}
+
+//Fit the parameters p of the model f(x, p) to the data ydata at xdata by least squares, starting from p0,
+//like scipy.optimize.curve_fit. sigma holds the standard deviations of ydata, the residuals are divided
+//by them, nil for none. The covariance of the parameters is scaled by the residual variance, the sum of
+//squared residuals over m - n degrees of freedom, NaN when there are none. It is all NaN when the Jacobian
+//at the solution is rank deficient, so that some parameters are not determined by the data.
+//maxIter and tol are as in LeastSquares.
+func CurveFit(f func(x, p *nd.NdArray) *nd.NdArray, xdata, ydata, p0, sigma *nd.NdArray, maxIter int, tol float64) *FitResult {
+	if ydata.NDims() != 1 || (sigma != nil && (sigma.NDims() != 1 || sigma.Size() != ydata.Size())) {
+		panic("shape error")
+	}
+	ys := ydata.Values()
+	residual := func(p *nd.NdArray) *nd.NdArray {
+		r := f(xdata, p).Clone()
+		if r.Size() != len(ys) {
+			panic("shape error")
+		}
+		rs := r.Values()
+		for i := range rs {
+			rs[i] -= ys[i]
+			if sigma != nil {
+				rs[i] /= sigma.Get(i)
+			}
+		}
+		return r.Reshape(len(rs))
+	}
+
+	res := levenberg(residual, nil, p0, maxIter, tol, false)
+	m, n := len(ys), res.X.Size()
+	j := calculus.Jacobian(residual, res.X)
+	cov := j.NormalInverse()
+	variance := 2 * res.F / float64(m-n)
+	if m <= n {
+		variance = math.NaN()
+	}
+
+	return &FitResult{Result: res, Cov: cov.Map(func(v float64) float64 { return v * variance })}
+}
+
+//Solve the system f(x) = 0 of n equations in n unknowns from x0, like scipy.optimize.root, by Levenberg-Marquardt
+//steps on the sum of squares of f, which become Newton steps near a root. jac gives the [n, n] Jacobian of f,
+//it may be nil. It converges when no element of f(x) exceeds tol in magnitude, and fails at a local minimum
+//of |f| that is not a root, or after maxIter iterations.
+func Root(f, jac func(*nd.NdArray) *nd.NdArray, x0 *nd.NdArray, maxIter int, tol float64) *Result {
+	return levenberg(f, jac, x0, maxIter, tol, true)
+}
+
+func levenberg(residual, jac func(*nd.NdArray) *nd.NdArray, x0 *nd.NdArray, maxIter int, tol float64, root bool) *Result {
+	x := start(x0)
+	n := len(x)
+	nf, nj := 0, 0
+	eval := func(x []float64) []float64 {
+		nf++
+		r := residual(nd.Array(x...))
+		if r.NDims() != 1 {
+			panic("shape error")
+		}
+		return append([]float64(nil), r.Values()...)
+	}
+	jacobian := func(x []float64, r []float64) []float64 {
+		var j *nd.NdArray
+		if jac == nil {
+			j = calculus.Jacobian(func(p *nd.NdArray) *nd.NdArray { return nd.Array(eval(p.Values())...) }, nd.Array(x...))
+		} else {
+			nj++
+			j = jac(nd.Array(x...))
+		}
+		if j.NDims() != 2 || j.Shape()[0] != len(r) || j.Shape()[1] != n {
+			panic("shape error")
+		}
+		return j.Values()
+	}
+	result := func(x, r, g []float64, iter int, converged bool, message string) *Result {
+		return &Result{
+			X: nd.Array(x...), F: dot(r, r) / 2, Grad: nd.Array(g...), Iterations: iter,
+			FuncEvals: nf, GradEvals: nj, Converged: converged, Message: message,
+		}
+	}
+
+	r := eval(x)
+	m := len(r)
+	if root && m != n {
+		panic("shape error")
+	}
+	j := jacobian(x, r)
+	//normal equations a = J^T J and the gradient g = J^T r
+	normal := func() ([]float64, []float64) {
+		a, g := make([]float64, n*n), make([]float64, n)
+		for k := 0; k < m; k++ {
+			row := j[k*n : (k+1)*n]
+			for p := 0; p < n; p++ {
+				g[p] += row[p] * r[k]
+				for q := 0; q < n; q++ {
+					a[p*n+q] += row[p] * row[q]
+				}
+			}
+		}
+		return a, g
+	}
+	a, g := normal()
+
+	lambda, nu := 0.0, 2.0
+	for p := 0; p < n; p++ {
+		lambda = math.Max(lambda, a[p*n+p])
+	}
+	lambda *= 1e-3
+	for iter := 0; ; iter++ {
+		switch {
+		case root && infNorm(r) <= tol:
+			return result(x, r, g, iter, true, "residuals below tolerance")
+		case infNorm(g) <= tol:
+			if root {
+				return result(x, r, g, iter, false, "stuck at a local minimum of the residuals")
+			}
+			return result(x, r, g, iter, true, msgGradient)
+		case iter >= maxIter:
+			return result(x, r, g, iter, false, msgMaxIter)
+		}
+
+		//(J^T J + lambda diag(J^T J)) delta = -g, the diagonal kept positive
+		damped := nd.Zeros(n, n)
+		ds := damped.Values()
+		copy(ds, a)
+		for p := 0; p < n; p++ {
+			ds[p*n+p] += lambda * math.Max(a[p*n+p], 1e-12)
+		}
+		delta := damped.Solve(nd.Array(negate(g)...)).Values()
+		xn := step(x, 1, delta)
+		rn := eval(xn)
+
+		//actual over predicted decrease of the cost
+		cost, costN := dot(r, r)/2, dot(rn, rn)/2
+		predicted := 0.0
+		for p := 0; p < n; p++ {
+			predicted += delta[p] * (lambda*math.Max(a[p*n+p], 1e-12)*delta[p] - g[p]) / 2
+		}
+		rho := (cost - costN) / predicted
+		if !(rho > 0) {
+			lambda *= nu
+			nu *= 2
+			if lambda > 1e16 {
+				return result(x, r, g, iter+1, false, "no step decreases the cost")
+			}
+			continue
+		}
+		lambda *= math.Max(1.0/3, 1-math.Pow(2*rho-1, 3))
+		nu = 2
+
+		small := infNorm(delta) <= tol*(infNorm(x)+tol)
+		flat := cost-costN <= tol*cost
+		x, r = xn, rn
+		j = jacobian(x, r)
+		a, g = normal()
+		if !root && small {
+			return result(x, r, g, iter+1, true, "step below tolerance")
+		}
+		if !root && flat {
+			return result(x, r, g, iter+1, true, "relative reduction of the cost below tolerance")
+		}
+	}
+}
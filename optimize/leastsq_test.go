@@ -0,0 +1,100 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestLeastSquares(t *testing.T) {
+	//Rosenbrock as the residuals 10 (x1 - x0^2) and 1 - x0
+	residual := func(x *nd.NdArray) *nd.NdArray {
+		return nd.Array(10*(x.Get(1)-x.Get(0)*x.Get(0)), 1-x.Get(0))
+	}
+	jac := func(x *nd.NdArray) *nd.NdArray {
+		return nd.Array(-20*x.Get(0), 10, -1, 0).Reshape(2, 2)
+	}
+	for _, j := range []func(*nd.NdArray) *nd.NdArray{jac, nil} {
+		r := LeastSquares(residual, j, nd.Array(-1.2, 1), 100, 1e-10)
+		if !r.Converged || !r.X.Equals(nd.Array(1, 1)) || r.F > 1e-12 {
+			t.Error("Expected convergence to [1, 1], got ", r.X, r.F, r.Message)
+		}
+	}
+}
+
+func TestCurveFit(t *testing.T) {
+	//y = a exp(-b x) + c, with alternating errors of 0.01
+	model := func(x, p *nd.NdArray) *nd.NdArray {
+		return x.Map(func(v float64) float64 { return p.Get(0)*math.Exp(-p.Get(1)*v) + p.Get(2) })
+	}
+	x := nd.Arange(20).Map(func(v float64) float64 { return v / 4 })
+	y := model(x, nd.Array(2.5, 1.3, 0.5)).Clone()
+	ys := y.Values()
+	for i := range ys {
+		ys[i] += 0.01 * float64(1-2*(i%2))
+	}
+
+	r := CurveFit(model, x, y, nd.Array(1, 1, 0), nil, 200, 1e-12)
+	if !r.Converged {
+		t.Error("Expected convergence, got ", r.Message)
+	}
+	for i, want := range []float64{2.5, 1.3, 0.5} {
+		if math.Abs(r.X.Get(i)-want) > 0.02 {
+			t.Error("Expected about ", want, ", got ", r.X.Get(i))
+		}
+	}
+	//the standard errors are of the order of the errors
+	for i := 0; i < 3; i++ {
+		if se := math.Sqrt(r.Cov.Get(i, i)); !(se > 1e-4 && se < 0.05) {
+			t.Error("Expected a standard error of about 0.01, got ", se)
+		}
+	}
+
+	//exact data with sigma and no degrees of freedom left
+	line := func(x, p *nd.NdArray) *nd.NdArray {
+		return x.Map(func(v float64) float64 { return p.Get(0)*v + p.Get(1) })
+	}
+	r = CurveFit(line, nd.Array(0, 1), nd.Array(1, 3), nd.Zeros(2), nd.Array(0.1, 0.2), 100, 1e-10)
+	if !r.X.Equals(nd.Array(2, 1)) || !math.IsNaN(r.Cov.Get(0, 0)) {
+		t.Error("Expected [2, 1] and a NaN covariance, got ", r.X, r.Cov)
+	}
+
+	//parameters the data cannot tell apart: one unused, and two only entering as a sum
+	x = nd.Array(0, 1, 2, 3)
+	y = nd.Array(1, 3.1, 4.9, 7)
+	unused := func(x, p *nd.NdArray) *nd.NdArray {
+		return x.Map(func(v float64) float64 { return p.Get(0)*v + 1 })
+	}
+	summed := func(x, p *nd.NdArray) *nd.NdArray {
+		return x.Map(func(v float64) float64 { return (p.Get(0)+p.Get(1))*v + 1 })
+	}
+	for _, model := range []func(x, p *nd.NdArray) *nd.NdArray{unused, summed} {
+		r = CurveFit(model, x, y, nd.Array(1, 1), nil, 100, 1e-10)
+		for _, v := range r.Cov.Values() {
+			if !math.IsNaN(v) {
+				t.Error("Expected a NaN covariance, got ", r.Cov)
+				break
+			}
+		}
+	}
+}
+
+func TestRoot(t *testing.T) {
+	//x^2 + y^2 = 4 and x y = 1
+	f := func(v *nd.NdArray) *nd.NdArray {
+		x, y := v.Get(0), v.Get(1)
+		return nd.Array(x*x+y*y-4, x*y-1)
+	}
+	r := Root(f, nil, nd.Array(2, 0.5), 100, 1e-12)
+	x, y := r.X.Get(0), r.X.Get(1)
+	if !r.Converged || math.Abs(x*x+y*y-4) > 1e-10 || math.Abs(x*y-1) > 1e-10 || x < y {
+		t.Error("Expected the root with x > y, got ", r.X, r.Message)
+	}
+
+	//x^2 + 1 = 0 has no real root, the residual is smallest at 0
+	r = Root(func(v *nd.NdArray) *nd.NdArray { return nd.Array(v.Get(0)*v.Get(0) + 1) }, nil, nd.Array(3), 100, 1e-10)
+	if r.Converged || math.Abs(r.X.Get(0)) > 1e-4 {
+		t.Error("Expected to fail near 0, got ", r.X, r.Message)
+	}
+}
@@ -0,0 +1,107 @@
+package optimize
+
+import "math"
+
+const (
+	//sufficient decrease: f(x + a d) <= f(x) + armijo a g.d
+	armijo = 1e-4
+	//most evaluations of a line search
+	lineSearchLimit = 40
+)
+
+//A point on the search line, with the directional derivative there.
+type linePoint struct {
+	alpha, f, slope float64
+	g               []float64
+}
+
+//Line search along the descent direction d from x for a step satisfying the strong Wolfe conditions,
+//sufficient decrease and |g(x + a d).d| <= curvature |g(x).d|, by bracketing and zooming with cubic
+//interpolation (Nocedal and Wright, algorithms 3.5 and 3.6). It starts with the step alpha0 and
+//returns the accepted point, or false when no step decreases f enough.
+func wolfeSearch(o *objective, x, d []float64, f0 float64, g0 []float64, alpha0, curvature float64) (linePoint, bool) {
+	slope0 := dot(g0, d)
+	if !(slope0 < 0) {
+		return linePoint{}, false
+	}
+	at := func(alpha float64) linePoint {
+		xa := step(x, alpha, d)
+		f := o.value(xa)
+		if math.IsNaN(f) || math.IsInf(f, 1) {
+			return linePoint{alpha: alpha, f: math.Inf(1), slope: math.NaN()}
+		}
+		g := o.gradient(xa)
+		return linePoint{alpha: alpha, f: f, slope: dot(g, d), g: g}
+	}
+	sufficient := func(p linePoint) bool { return p.f <= f0+armijo*p.alpha*slope0 }
+	flat := func(p linePoint) bool { return math.Abs(p.slope) <= -curvature*slope0 }
+
+	zoom := func(lo, hi linePoint, evals int) (linePoint, bool) {
+		for ; evals < lineSearchLimit; evals++ {
+			p := at(interpolate(lo, hi))
+			if !sufficient(p) || p.f >= lo.f {
+				hi = p
+				continue
+			}
+			if flat(p) {
+				return p, true
+			}
+			if p.slope*(hi.alpha-lo.alpha) >= 0 {
+				hi = lo
+			}
+			lo = p
+		}
+		//settle for sufficient decrease
+		return lo, lo.alpha > 0
+	}
+
+	prev := linePoint{alpha: 0, f: f0, slope: slope0, g: g0}
+	alpha := alpha0
+	for evals := 1; evals <= lineSearchLimit; evals++ {
+		p := at(alpha)
+		switch {
+		case math.IsInf(p.f, 1):
+			//out of the domain of f, step back
+			alpha = (prev.alpha + alpha) / 2
+			continue
+		case !sufficient(p) || (evals > 1 && p.f >= prev.f):
+			return zoom(prev, p, evals)
+		case flat(p):
+			return p, true
+		case p.slope >= 0:
+			return zoom(p, prev, evals)
+		}
+		prev = p
+		alpha *= 2
+	}
+
+	return prev, prev.alpha > 0
+}
+
+//minimizer of the cubic matching the values and slopes at lo and hi, kept in the middle 80%
+//of the interval, else the midpoint.
+func interpolate(lo, hi linePoint) float64 {
+	a, b := lo.alpha, hi.alpha
+	mid := (a + b) / 2
+	if math.IsInf(hi.f, 1) || math.IsNaN(hi.slope) {
+		return mid
+	}
+	d1 := lo.slope + hi.slope - 3*(lo.f-hi.f)/(a-b)
+	disc := d1*d1 - lo.slope*hi.slope
+	if !(disc >= 0) {
+		return mid
+	}
+	d2 := math.Sqrt(disc)
+	if b < a {
+		d2 = -d2
+	}
+	t := b - (b-a)*(hi.slope+d2-d1)/(hi.slope-lo.slope+2*d2)
+
+	lower, upper := math.Min(a, b), math.Max(a, b)
+	margin := 0.1 * (upper - lower)
+	if !(t >= lower+margin && t <= upper-margin) {
+		return mid
+	}
+
+	return t
+}
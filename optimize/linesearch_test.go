@@ -0,0 +1,32 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWolfeSearch(t *testing.T) {
+	o := &objective{f: rosen, grad: rosenGrad}
+	x := []float64{-1.2, 1}
+	f0, g0 := o.value(x), o.gradient(x)
+	d := negate(g0)
+	slope0 := dot(g0, d)
+	for _, alpha0 := range []float64{1e-6, 1e-3, 1} {
+		p, ok := wolfeSearch(o, x, d, f0, g0, alpha0, 0.9)
+		if !ok {
+			t.Error("Expected a step from ", alpha0)
+			continue
+		}
+		if p.f > f0+armijo*p.alpha*slope0 {
+			t.Error("Expected sufficient decrease, got ", p.f, " from ", f0)
+		}
+		if math.Abs(p.slope) > 0.9*math.Abs(slope0) {
+			t.Error("Expected the curvature condition, got the slope ", p.slope)
+		}
+	}
+
+	//no step along an ascent direction
+	if _, ok := wolfeSearch(o, x, g0, f0, g0, 1, 0.9); ok {
+		t.Error("Expected a failure along an ascent direction")
+	}
+}
@@ -0,0 +1,96 @@
+package optimize
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Minimize f from x0 by the Nelder-Mead simplex method, like scipy.optimize.minimize with method Nelder-Mead.
+//Only values of f are used, so f need not be smooth. The initial simplex moves each element of x0 by 5%,
+//or by 0.00025 when it is 0. It stops when the vertices are within tol of the best one in every element,
+//and so are their values, or after maxIter iterations.
+func NelderMead(f func(*nd.NdArray) float64, x0 *nd.NdArray, maxIter int, tol float64) *Result {
+	o := &objective{f: f}
+	x := start(x0)
+	n := len(x)
+
+	type vertex struct {
+		x []float64
+		f float64
+	}
+	simplex := make([]vertex, n+1)
+	simplex[0] = vertex{x, o.value(x)}
+	for i := 0; i < n; i++ {
+		v := append([]float64(nil), x...)
+		if v[i] != 0 {
+			v[i] *= 1.05
+		} else {
+			v[i] = 0.00025
+		}
+		simplex[i+1] = vertex{v, o.value(v)}
+	}
+	//x + t (x - y)
+	along := func(x, y []float64, t float64) vertex {
+		v := make([]float64, n)
+		for i := range v {
+			v[i] = x[i] + t*(x[i]-y[i])
+		}
+		return vertex{v, o.value(v)}
+	}
+
+	for iter := 0; ; iter++ {
+		sort.SliceStable(simplex, func(i, j int) bool { return simplex[i].f < simplex[j].f })
+		best, worst := simplex[0], simplex[n]
+		size, spread := 0.0, 0.0
+		for _, v := range simplex[1:] {
+			for i := range v.x {
+				size = math.Max(size, math.Abs(v.x[i]-best.x[i]))
+			}
+			spread = math.Max(spread, math.Abs(v.f-best.f))
+		}
+		if size <= tol && spread <= tol {
+			return o.result(best.x, best.f, nil, iter, true, "simplex below tolerance")
+		}
+		if iter >= maxIter {
+			return o.result(best.x, best.f, nil, iter, false, msgMaxIter)
+		}
+
+		centroid := make([]float64, n)
+		for _, v := range simplex[:n] {
+			for i := range centroid {
+				centroid[i] += v.x[i] / float64(n)
+			}
+		}
+
+		reflected := along(centroid, worst.x, 1)
+		switch {
+		case reflected.f < best.f:
+			if expanded := along(centroid, worst.x, 2); expanded.f < reflected.f {
+				simplex[n] = expanded
+			} else {
+				simplex[n] = reflected
+			}
+			continue
+		case reflected.f < simplex[n-1].f:
+			simplex[n] = reflected
+			continue
+		case reflected.f < worst.f:
+			if outside := along(centroid, worst.x, 0.5); outside.f <= reflected.f {
+				simplex[n] = outside
+				continue
+			}
+		default:
+			if inside := along(centroid, worst.x, -0.5); inside.f < worst.f {
+				simplex[n] = inside
+				continue
+			}
+		}
+
+		//shrink towards the best vertex
+		for k := 1; k <= n; k++ {
+			simplex[k] = along(best.x, simplex[k].x, -0.5)
+		}
+	}
+}
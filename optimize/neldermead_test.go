@@ -0,0 +1,30 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestNelderMead(t *testing.T) {
+	r := NelderMead(rosen, nd.Array(-1.2, 1), 1000, 1e-8)
+	if !r.Converged || !r.X.Equals(nd.Array(1, 1)) || r.Grad != nil {
+		t.Error("Expected convergence to [1, 1], got ", r.X, r.Message)
+	}
+
+	//not smooth
+	abs := func(x *nd.NdArray) float64 { return math.Abs(x.Get(0)-1) + math.Abs(x.Get(1)+2) }
+	r = NelderMead(abs, nd.Array(0, 0), 1000, 1e-8)
+	if !r.Converged || !r.X.Equals(nd.Array(1, -2)) {
+		t.Error("Expected [1, -2], got ", r.X, r.Message)
+	}
+	if r.FuncEvals < r.Iterations {
+		t.Error("Expected at least one evaluation per iteration, got ", r.FuncEvals, r.Iterations)
+	}
+
+	r = NelderMead(rosen, nd.Array(-1.2, 1), 10, 1e-8)
+	if r.Converged || r.Iterations != 10 {
+		t.Error("Expected to stop after 10 iterations, got ", r.Iterations)
+	}
+}
@@ -0,0 +1,253 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//pairs of steps and gradient changes kept by LBFGSB.
+const lbfgsMemory = 10
+
+//Minimize f from x0 by the BFGS quasi-Newton method, like scipy.optimize.minimize with method BFGS.
+//An approximation of the inverse Hessian is built from the gradient changes, and each step is taken
+//by a line search satisfying the strong Wolfe conditions. It stops when no element of the gradient
+//exceeds tol in magnitude, or after maxIter iterations. grad may be nil.
+func BFGS(f func(*nd.NdArray) float64, grad func(*nd.NdArray) *nd.NdArray, x0 *nd.NdArray, maxIter int, tol float64) *Result {
+	o := &objective{f: f, grad: grad}
+	x := start(x0)
+	n := len(x)
+	fx, g := o.value(x), o.gradient(x)
+
+	h := make([]float64, n*n)
+	identity := func() {
+		for i := range h {
+			h[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			h[i*n+i] = 1
+		}
+	}
+	identity()
+
+	for iter := 0; ; iter++ {
+		if infNorm(g) <= tol {
+			return o.result(x, fx, g, iter, true, msgGradient)
+		}
+		if iter >= maxIter {
+			return o.result(x, fx, g, iter, false, msgMaxIter)
+		}
+
+		d := make([]float64, n)
+		for i := 0; i < n; i++ {
+			d[i] = -dot(h[i*n:(i+1)*n], g)
+		}
+		if dot(d, g) >= 0 {
+			identity()
+			d = negate(g)
+		}
+		alpha0 := 1.0
+		if iter == 0 {
+			alpha0 = math.Min(1, 1/infNorm(g))
+		}
+		p, ok := wolfeSearch(o, x, d, fx, g, alpha0, 0.9)
+		if !ok {
+			return o.result(x, fx, g, iter, false, msgLineSearch)
+		}
+
+		s, y := make([]float64, n), make([]float64, n)
+		for i := range s {
+			s[i] = p.alpha * d[i]
+			y[i] = p.g[i] - g[i]
+		}
+		x, fx, g = step(x, p.alpha, d), p.f, p.g
+
+		sy := dot(s, y)
+		if sy <= 1e-10*math.Sqrt(dot(s, s)*dot(y, y)) {
+			continue
+		}
+		if iter == 0 {
+			//scale the initial approximation to the curvature seen along the first step
+			gamma := sy / dot(y, y)
+			for i := range h {
+				h[i] *= gamma
+			}
+		}
+		//H += (sy + y.H y) s s^T / sy^2 - (H y s^T + s (H y)^T) / sy
+		hy := make([]float64, n)
+		for i := range hy {
+			hy[i] = dot(h[i*n:(i+1)*n], y)
+		}
+		c := (sy + dot(y, hy)) / (sy * sy)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				h[i*n+j] += c*s[i]*s[j] - (hy[i]*s[j]+s[i]*hy[j])/sy
+			}
+		}
+	}
+}
+
+//Minimize f from x0 within the bounds lower <= x <= upper, like scipy.optimize.minimize with method L-BFGS-B.
+//lower and upper are 1d arrays like x0 with -Inf and +Inf for missing bounds, or nil for none at all.
+//The limited memory BFGS approximation from the last 10 steps gives the direction on the variables not held
+//at a bound by the gradient, and a backtracking search along the path projected onto the bounds gives the step.
+//It stops when no element of the projected gradient exceeds tol in magnitude, when f decreases by less than
+//a few rounding errors, or after maxIter iterations. grad may be nil.
+func LBFGSB(f func(*nd.NdArray) float64, grad func(*nd.NdArray) *nd.NdArray, x0, lower, upper *nd.NdArray, maxIter int, tol float64) *Result {
+	o := &objective{f: f, grad: grad}
+	x := start(x0)
+	n := len(x)
+	lo, hi := bound(lower, n, math.Inf(-1)), bound(upper, n, math.Inf(1))
+	for i := range x {
+		if lo[i] > hi[i] {
+			panic(fmt.Errorf("bounds: lower %v above upper %v", lo[i], hi[i]))
+		}
+		x[i] = math.Min(math.Max(x[i], lo[i]), hi[i])
+	}
+	project := func(v []float64) []float64 {
+		for i := range v {
+			v[i] = math.Min(math.Max(v[i], lo[i]), hi[i])
+		}
+		return v
+	}
+
+	fx, g := o.value(x), o.gradient(x)
+	var ss, ys [][]float64
+	for iter := 0; ; iter++ {
+		pg := project(step(x, -1, g))
+		for i := range pg {
+			pg[i] -= x[i]
+		}
+		if infNorm(pg) <= tol {
+			return o.result(x, fx, g, iter, true, msgGradient)
+		}
+		if iter >= maxIter {
+			return o.result(x, fx, g, iter, false, msgMaxIter)
+		}
+
+		//variables at a bound that the gradient pushes against stay there
+		free := make([]bool, n)
+		for i := range free {
+			free[i] = !(x[i] <= lo[i] && g[i] > 0) && !(x[i] >= hi[i] && g[i] < 0)
+		}
+		d := twoLoop(g, ss, ys, free)
+		if dot(d, g) >= 0 {
+			ss, ys = nil, nil
+			d = twoLoop(g, nil, nil, free)
+		}
+
+		alpha := 1.0
+		if len(ss) == 0 {
+			alpha = math.Min(1, 1/infNorm(d))
+		}
+		var xn []float64
+		fn := math.Inf(1)
+		for k := 0; k < lineSearchLimit; k++ {
+			xn = project(step(x, alpha, d))
+			diff := make([]float64, n)
+			for i := range diff {
+				diff[i] = xn[i] - x[i]
+			}
+			fn = o.value(xn)
+			if fn <= fx+armijo*dot(g, diff) {
+				break
+			}
+			alpha /= 2
+		}
+		if !(fn < fx) {
+			return o.result(x, fx, g, iter, false, msgLineSearch)
+		}
+
+		gn := o.gradient(xn)
+		s, y := make([]float64, n), make([]float64, n)
+		for i := range s {
+			s[i] = xn[i] - x[i]
+			y[i] = gn[i] - g[i]
+		}
+		if dot(s, y) > 1e-10*dot(y, y) {
+			ss, ys = append(ss, s), append(ys, y)
+			if len(ss) > lbfgsMemory {
+				ss, ys = ss[1:], ys[1:]
+			}
+		}
+
+		stalled := fx-fn <= 10*(math.Nextafter(1, 2)-1)*math.Max(1, math.Max(math.Abs(fx), math.Abs(fn)))
+		x, fx, g = xn, fn, gn
+		if stalled {
+			return o.result(x, fx, g, iter+1, true, "relative reduction of f below machine precision")
+		}
+	}
+}
+
+//the values of b, or n copies of missing when b is nil.
+func bound(b *nd.NdArray, n int, missing float64) []float64 {
+	if b == nil {
+		r := make([]float64, n)
+		for i := range r {
+			r[i] = missing
+		}
+		return r
+	}
+	if b.NDims() != 1 || b.Size() != n {
+		panic("shape error")
+	}
+
+	return b.Values()
+}
+
+//-H g on the free variables by the L-BFGS two loop recursion over the stored pairs restricted to them,
+//0 on the others. The initial approximation is scaled by the curvature of the last pair.
+func twoLoop(g []float64, ss, ys [][]float64, free []bool) []float64 {
+	masked := func(a, b []float64) float64 {
+		r := 0.0
+		for i, v := range a {
+			if free[i] {
+				r += v * b[i]
+			}
+		}
+		return r
+	}
+
+	q := make([]float64, len(g))
+	for i, v := range g {
+		if free[i] {
+			q[i] = v
+		}
+	}
+	k := len(ss)
+	rho, a := make([]float64, k), make([]float64, k)
+	for j := k - 1; j >= 0; j-- {
+		sy := masked(ss[j], ys[j])
+		if sy <= 0 {
+			continue
+		}
+		rho[j] = 1 / sy
+		a[j] = rho[j] * masked(ss[j], q)
+		for i := range q {
+			if free[i] {
+				q[i] -= a[j] * ys[j][i]
+			}
+		}
+	}
+	if k > 0 {
+		if sy, yy := masked(ss[k-1], ys[k-1]), masked(ys[k-1], ys[k-1]); sy > 0 && yy > 0 {
+			for i := range q {
+				q[i] *= sy / yy
+			}
+		}
+	}
+	for j := 0; j < k; j++ {
+		if rho[j] == 0 {
+			continue
+		}
+		b := rho[j] * masked(ys[j], q)
+		for i := range q {
+			if free[i] {
+				q[i] += (a[j] - b) * ss[j][i]
+			}
+		}
+	}
+
+	return negate(q)
+}
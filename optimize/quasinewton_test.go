@@ -0,0 +1,49 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestBFGS(t *testing.T) {
+	for _, grad := range []func(*nd.NdArray) *nd.NdArray{rosenGrad, nil} {
+		r := BFGS(rosen, grad, nd.Array(-1.2, 1, 0.5), 500, 1e-6)
+		if !r.Converged || !r.X.Equals(nd.Array(1, 1, 1)) {
+			t.Error("Expected convergence to [1, 1, 1], got ", r.X, r.Message)
+		}
+		if r.F > 1e-9 || r.Iterations == 0 || r.FuncEvals == 0 {
+			t.Error("Expected a minimum of 0 with counts, got ", r.F, r.Iterations, r.FuncEvals)
+		}
+		if (grad == nil) != (r.GradEvals == 0) {
+			t.Error("Expected gradient calls only with a gradient, got ", r.GradEvals)
+		}
+	}
+
+	r := BFGS(rosen, rosenGrad, nd.Array(-1.2, 1), 3, 1e-6)
+	if r.Converged || r.Iterations != 3 || r.Message != msgMaxIter {
+		t.Error("Expected to stop after 3 iterations, got ", r.Iterations, r.Message)
+	}
+}
+
+func TestLBFGSB(t *testing.T) {
+	r := LBFGSB(rosen, rosenGrad, nd.Array(-1.2, 1, 0.5), nil, nil, 1000, 1e-6)
+	if !r.Converged || !r.X.Equals(nd.Array(1, 1, 1)) {
+		t.Error("Expected convergence to [1, 1, 1], got ", r.X, r.Message)
+	}
+
+	//the bound x0 <= 0.5 is active, then x1 = x0^2 minimizes
+	inf := math.Inf(1)
+	r = LBFGSB(rosen, nil, nd.Array(-1, 2), nd.Array(-inf, -inf), nd.Array(0.5, inf), 1000, 1e-6)
+	if !r.Converged || !r.X.Equals(nd.Array(0.5, 0.25)) {
+		t.Error("Expected [0.5, 0.25], got ", r.X, r.Message)
+	}
+
+	//the start is moved into the bounds, which hold every element at its lower bound
+	quadratic := func(x *nd.NdArray) float64 { return x.Get(0)*x.Get(0) + x.Get(1)*x.Get(1) }
+	r = LBFGSB(quadratic, nil, nd.Array(-3, 5), nd.Array(1, 2), nd.Array(4, 6), 100, 1e-8)
+	if !r.Converged || !r.X.Equals(nd.Array(1, 2)) {
+		t.Error("Expected [1, 2], got ", r.X, r.Message)
+	}
+}
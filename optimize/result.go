@@ -0,0 +1,132 @@
+//Package optimize minimizes functions and finds their roots. The multivariate methods work on 1d
+//*nd.NdArray parameter vectors: Nelder-Mead, BFGS, L-BFGS-B with bounds, nonlinear conjugate gradients,
+//Levenberg-Marquardt least squares with CurveFit, and Root for systems of equations. Brent, Brentq
+//and Newton handle functions of one variable.
+//
+//Like the iterative fits of stats/regression, every method takes the most iterations and a tolerance,
+//and reports in its result whether it converged, instead of failing. Invalid arguments panic.
+//Gradients and Jacobians may be nil, they are then estimated by central differences.
+package optimize
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/calculus"
+	"github.com/ledao/ndarray/nd"
+)
+
+//Outcome of a multivariate method.
+type Result struct {
+	//the best point found and the objective there, half the sum of squared residuals for least squares
+	X *nd.NdArray
+	F float64
+	//gradient at X, nil for Nelder-Mead
+	Grad       *nd.NdArray
+	Iterations int
+	//calls of the objective, including those that estimate derivatives, and of the gradient or Jacobian
+	FuncEvals, GradEvals int
+	Converged            bool
+	//why the method stopped
+	Message string
+}
+
+//Outcome of a method for functions of one variable.
+type ScalarResult struct {
+	X, F       float64
+	Iterations int
+	FuncEvals  int
+	Converged  bool
+	Message    string
+}
+
+const (
+	msgGradient   = "gradient below tolerance"
+	msgMaxIter    = "maximum iterations reached"
+	msgLineSearch = "line search failed to decrease the objective"
+)
+
+//Scalar objective with its gradient, counting the calls.
+type objective struct {
+	f         func(*nd.NdArray) float64
+	grad      func(*nd.NdArray) *nd.NdArray
+	nf, ngrad int
+}
+
+func (o *objective) value(x []float64) float64 {
+	o.nf++
+	return o.f(nd.Array(x...))
+}
+
+func (o *objective) gradient(x []float64) []float64 {
+	if o.grad == nil {
+		jac := calculus.Jacobian(func(p *nd.NdArray) *nd.NdArray {
+			return nd.Array(o.value(p.Values()))
+		}, nd.Array(x...))
+		return jac.Values()
+	}
+	o.ngrad++
+	g := o.grad(nd.Array(x...))
+	if g.Size() != len(x) {
+		panic("shape error")
+	}
+
+	return append([]float64(nil), g.Values()...)
+}
+
+func (o *objective) result(x []float64, f float64, g []float64, iterations int, converged bool, message string) *Result {
+	r := &Result{
+		X: nd.Array(x...), F: f, Iterations: iterations,
+		FuncEvals: o.nf, GradEvals: o.ngrad, Converged: converged, Message: message,
+	}
+	if g != nil {
+		r.Grad = nd.Array(g...)
+	}
+
+	return r
+}
+
+//the values of x0, which must be a 1d array.
+func start(x0 *nd.NdArray) []float64 {
+	if x0.NDims() != 1 || x0.Size() == 0 {
+		panic("shape error")
+	}
+
+	return append([]float64(nil), x0.Values()...)
+}
+
+func dot(a, b []float64) float64 {
+	s := 0.0
+	for i, v := range a {
+		s += v * b[i]
+	}
+
+	return s
+}
+
+func infNorm(a []float64) float64 {
+	r := 0.0
+	for _, v := range a {
+		r = math.Max(r, math.Abs(v))
+	}
+
+	return r
+}
+
+//x + alpha d.
+func step(x []float64, alpha float64, d []float64) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = v + alpha*d[i]
+	}
+
+	return r
+}
+
+func negate(a []float64) []float64 {
+	r := make([]float64, len(a))
+	for i, v := range a {
+		r[i] = -v
+	}
+
+	return r
+}
@@ -0,0 +1,47 @@
+package optimize
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Rosenbrock's function, minimal at [1, 1, ...], and its gradient.
+func rosen(x *nd.NdArray) float64 {
+	xs := x.Values()
+	s := 0.0
+	for i := 0; i+1 < len(xs); i++ {
+		a, b := xs[i+1]-xs[i]*xs[i], 1-xs[i]
+		s += 100*a*a + b*b
+	}
+	return s
+}
+
+func rosenGrad(x *nd.NdArray) *nd.NdArray {
+	xs := x.Values()
+	g := nd.Zeros(len(xs))
+	gs := g.Values()
+	for i := 0; i+1 < len(xs); i++ {
+		a := xs[i+1] - xs[i]*xs[i]
+		gs[i] += -400*a*xs[i] - 2*(1-xs[i])
+		gs[i+1] += 200 * a
+	}
+	return g
+}
+
+func TestObjective(t *testing.T) {
+	o := &objective{f: rosen}
+	g := o.gradient([]float64{0, 0})
+	if !nd.Array(g...).Equals(nd.Array(-2, 0)) {
+		t.Error("Expected [-2, 0], got ", g)
+	}
+	if o.nf != 5 || o.ngrad != 0 {
+		t.Error("Expected 5 calls of f for the central differences, got ", o.nf, o.ngrad)
+	}
+
+	o = &objective{f: rosen, grad: rosenGrad}
+	g = o.gradient([]float64{-1, 2})
+	if !nd.Array(g...).Equals(nd.Array(396, 200)) || o.ngrad != 1 {
+		t.Error("Expected [396, 200] from 1 call, got ", g, o.ngrad)
+	}
+}
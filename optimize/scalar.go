@@ -0,0 +1,229 @@
+package optimize
+
+import (
+	"fmt"
+	"math"
+)
+
+//Minimize f on [a, b] by Brent's method, like scipy.optimize.minimize_scalar with method bounded:
+//golden section steps, replaced by parabolic interpolation when it behaves. It stops when the minimum
+//is located within about tol, or after maxIter iterations. A minimum at an end is approached, never
+//evaluated at the end itself.
+func Brent(f func(float64) float64, a, b, tol float64, maxIter int) *ScalarResult {
+	if !(a < b) {
+		panic(fmt.Errorf("interval: [%v, %v] must not be empty", a, b))
+	}
+	golden := (3 - math.Sqrt(5)) / 2
+	eps := math.Sqrt(math.Nextafter(1, 2) - 1)
+
+	res := &ScalarResult{}
+	eval := func(x float64) float64 {
+		res.FuncEvals++
+		return f(x)
+	}
+	//x the best point, w the second best, v the previous w
+	x := a + golden*(b-a)
+	w, v := x, x
+	fx := eval(x)
+	fw, fv := fx, fx
+	//the last two steps
+	d, e := 0.0, 0.0
+	for ; ; res.Iterations++ {
+		mid := (a + b) / 2
+		tol1 := eps*math.Abs(x) + tol/3
+		tol2 := 2 * tol1
+		if math.Abs(x-mid) <= tol2-(b-a)/2 {
+			res.X, res.F, res.Converged, res.Message = x, fx, true, "interval below tolerance"
+			return res
+		}
+		if res.Iterations >= maxIter {
+			res.X, res.F, res.Message = x, fx, msgMaxIter
+			return res
+		}
+
+		parabolic := false
+		if math.Abs(e) > tol1 {
+			//parabola through x, w and v
+			r := (x - w) * (fx - fv)
+			q := (x - v) * (fx - fw)
+			p := (x-v)*q - (x-w)*r
+			q = 2 * (q - r)
+			if q > 0 {
+				p = -p
+			}
+			q = math.Abs(q)
+			//accepted if inside the interval and less than half the step before last
+			if math.Abs(p) < math.Abs(q*e/2) && p > q*(a-x) && p < q*(b-x) {
+				e, d = d, p/q
+				parabolic = true
+				if u := x + d; u-a < tol2 || b-u < tol2 {
+					d = math.Copysign(tol1, mid-x)
+				}
+			}
+		}
+		if !parabolic {
+			if x < mid {
+				e = b - x
+			} else {
+				e = a - x
+			}
+			d = golden * e
+		}
+
+		u := x + d
+		if math.Abs(d) < tol1 {
+			u = x + math.Copysign(tol1, d)
+		}
+		fu := eval(u)
+		if fu <= fx {
+			if u < x {
+				b = x
+			} else {
+				a = x
+			}
+			v, fv, w, fw, x, fx = w, fw, x, fx, u, fu
+			continue
+		}
+		if u < x {
+			a = u
+		} else {
+			b = u
+		}
+		if fu <= fw || w == x {
+			v, fv, w, fw = w, fw, u, fu
+		} else if fu <= fv || v == x || v == w {
+			v, fv = u, fu
+		}
+	}
+}
+
+//Root of f in [a, b] by Brent's method, like scipy.optimize.brentq: bisection, replaced by secant or
+//inverse quadratic interpolation steps when they fall well inside the bracket. f(a) and f(b) must have
+//opposite signs. It stops when the root is bracketed within tol plus a few rounding errors, or after maxIter
+//iterations.
+func Brentq(f func(float64) float64, a, b, tol float64, maxIter int) *ScalarResult {
+	res := &ScalarResult{}
+	eval := func(x float64) float64 {
+		res.FuncEvals++
+		return f(x)
+	}
+	fa, fb := eval(a), eval(b)
+	done := func(x, fx float64, converged bool, message string) *ScalarResult {
+		res.X, res.F, res.Converged, res.Message = x, fx, converged, message
+		return res
+	}
+	switch {
+	case fa == 0:
+		return done(a, fa, true, "exact root")
+	case fb == 0:
+		return done(b, fb, true, "exact root")
+	case math.Signbit(fa) == math.Signbit(fb):
+		panic(fmt.Errorf("f(%v) = %v and f(%v) = %v must have opposite signs", a, fa, b, fb))
+	}
+
+	eps := math.Nextafter(1, 2) - 1
+	//b the best estimate, a the previous one, c the other end of the bracket
+	c, fc := a, fa
+	d, e := b-a, b-a
+	for ; ; res.Iterations++ {
+		if math.Signbit(fb) == math.Signbit(fc) {
+			c, fc = a, fa
+			d, e = b-a, b-a
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol1 := 2*eps*math.Abs(b) + tol/2
+		m := (c - b) / 2
+		if math.Abs(m) <= tol1 || fb == 0 {
+			return done(b, fb, true, "root bracketed within tolerance")
+		}
+		if res.Iterations >= maxIter {
+			return done(b, fb, false, msgMaxIter)
+		}
+
+		if math.Abs(e) >= tol1 && math.Abs(fa) > math.Abs(fb) {
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				//secant
+				p, q = 2*m*s, 1-s
+			} else {
+				//inverse quadratic interpolation
+				q, r := fa/fc, fb/fc
+				p = s * (2*m*q*(q-r) - (b-a)*(r-1))
+				q = (q - 1) * (r - 1) * (s - 1)
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if 2*p < math.Min(3*m*q-math.Abs(tol1*q), math.Abs(e*q)) {
+				e, d = d, p/q
+			} else {
+				d, e = m, m
+			}
+		} else {
+			d, e = m, m
+		}
+
+		a, fa = b, fb
+		if math.Abs(d) > tol1 {
+			b += d
+		} else {
+			b += math.Copysign(tol1, m)
+		}
+		fb = eval(b)
+	}
+}
+
+//Root of f near x0 by Newton's method, like scipy.optimize.newton, or by the secant method when fprime is nil,
+//starting from x0 and a point 0.01% away. It stops when a step is below tol, or after maxIter iterations, and
+//fails where the derivative vanishes.
+func Newton(f, fprime func(float64) float64, x0, tol float64, maxIter int) *ScalarResult {
+	res := &ScalarResult{}
+	eval := func(x float64) float64 {
+		res.FuncEvals++
+		return f(x)
+	}
+	done := func(x, fx float64, converged bool, message string) *ScalarResult {
+		res.X, res.F, res.Converged, res.Message = x, fx, converged, message
+		return res
+	}
+
+	x, fx := x0, eval(x0)
+	//the previous point of the secant method
+	xp, fp := 0.0, 0.0
+	if fprime == nil {
+		xp, fp = x, fx
+		x = x0*(1+1e-4) + math.Copysign(1e-4, x0)
+		fx = eval(x)
+	}
+	for ; ; res.Iterations++ {
+		if fx == 0 {
+			return done(x, fx, true, "exact root")
+		}
+		if res.Iterations >= maxIter {
+			return done(x, fx, false, msgMaxIter)
+		}
+
+		slope := 0.0
+		if fprime != nil {
+			slope = fprime(x)
+		} else {
+			slope = (fx - fp) / (x - xp)
+		}
+		if slope == 0 || math.IsNaN(slope) {
+			return done(x, fx, false, "derivative is zero")
+		}
+		d := fx / slope
+		xp, fp = x, fx
+		x -= d
+		fx = eval(x)
+		if math.Abs(d) <= tol {
+			return done(x, fx, true, "step below tolerance")
+		}
+	}
+}
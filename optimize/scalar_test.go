@@ -0,0 +1,60 @@
+package optimize
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBrent(t *testing.T) {
+	r := Brent(func(x float64) float64 { return (x - 2) * (x - 2) * (x + 1) }, 0, 5, 1e-8, 100)
+	if !r.Converged || math.Abs(r.X-2) > 1e-7 || math.Abs(r.F) > 1e-12 {
+		t.Error("Expected the minimum at 2, got ", r.X, r.F)
+	}
+	r = Brent(math.Cos, 0, 2*math.Pi, 1e-10, 100)
+	if !r.Converged || math.Abs(r.X-math.Pi) > 1e-8 || r.FuncEvals > 30 {
+		t.Error("Expected the minimum at pi in few evaluations, got ", r.X, r.FuncEvals)
+	}
+	//at the end of the interval
+	r = Brent(func(x float64) float64 { return x }, 1, 2, 1e-6, 100)
+	if !r.Converged || math.Abs(r.X-1) > 1e-5 {
+		t.Error("Expected the minimum near 1, got ", r.X)
+	}
+}
+
+func TestBrentq(t *testing.T) {
+	r := Brentq(func(x float64) float64 { return x*x*x - 2*x - 5 }, 2, 3, 1e-12, 100)
+	if !r.Converged || math.Abs(r.X-2.0945514815423265) > 1e-11 {
+		t.Error("Expected 2.0945514815423265, got ", r.X)
+	}
+	r = Brentq(math.Sin, 3, 4, 1e-14, 100)
+	if !r.Converged || math.Abs(r.X-math.Pi) > 1e-13 || r.FuncEvals > 12 {
+		t.Error("Expected pi in few evaluations, got ", r.X, r.FuncEvals)
+	}
+	r = Brentq(math.Sin, 0, 1, 1e-14, 100)
+	if !r.Converged || r.X != 0 {
+		t.Error("Expected the root at the end 0, got ", r.X)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic without a sign change")
+		}
+	}()
+	Brentq(math.Cos, -1, 1, 1e-12, 100)
+}
+
+func TestNewton(t *testing.T) {
+	f := func(x float64) float64 { return x*x - 2 }
+	r := Newton(f, func(x float64) float64 { return 2 * x }, 1, 1e-12, 50)
+	if !r.Converged || math.Abs(r.X-math.Sqrt2) > 1e-12 || r.Iterations > 8 {
+		t.Error("Expected sqrt 2 in a few iterations, got ", r.X, r.Iterations)
+	}
+	r = Newton(f, nil, 1, 1e-12, 50)
+	if !r.Converged || math.Abs(r.X-math.Sqrt2) > 1e-12 {
+		t.Error("Expected sqrt 2 by secants, got ", r.X)
+	}
+	r = Newton(f, func(x float64) float64 { return 2 * x }, 0, 1e-12, 50)
+	if r.Converged || r.Message != "derivative is zero" {
+		t.Error("Expected a zero derivative, got ", r.Message)
+	}
+}
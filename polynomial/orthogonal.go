@@ -0,0 +1,128 @@
+package polynomial
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//A family of polynomials orthogonal on [-1, 1].
+type Family int
+
+const (
+	//T[0] = 1, T[1] = t, T[k+1] = 2 t T[k] - T[k-1]
+	Chebyshev Family = iota
+	//P[0] = 1, P[1] = t, (k + 1) P[k+1] = (2 k + 1) t P[k] - k P[k-1]
+	Legendre
+)
+
+//Sum of Coef[k] times the k-th polynomial of Family, lowest degree first, in t,
+//where the domain [Lo, Hi] of x is mapped linearly to t in [-1, 1].
+type Series struct {
+	Family Family
+	Coef   []float64
+	Lo, Hi float64
+}
+
+//Least squares fit of a series of degree deg to the points (x[i], y[i]), like numpy.polynomial.Chebyshev.fit.
+//The domain is the range of x, and weights are as in Polyfit. The basis is much better conditioned than
+//the powers of x, so high degrees fit accurately. With fewer than deg + 1 distinct x, the higher coefficients are 0.
+func Fit(family Family, x, y *nd.NdArray, deg int, weights *nd.NdArray) *Series {
+	if deg < 0 {
+		panic(fmt.Errorf("degree: %v must not be negative", deg))
+	}
+	checkSample(x, y, weights)
+	if x.Size() < deg+1 {
+		panic(fmt.Errorf("points: %v, at least %v needed for degree %v", x.Size(), deg+1, deg))
+	}
+
+	s := &Series{Family: family, Lo: math.Inf(1), Hi: math.Inf(-1)}
+	for _, v := range x.Values() {
+		s.Lo, s.Hi = math.Min(s.Lo, v), math.Max(s.Hi, v)
+	}
+	if s.Lo == s.Hi {
+		s.Lo, s.Hi = s.Lo-1, s.Hi+1
+	}
+	coef, _ := weightedFit(x, y, weights, deg+1, func(v float64, row []float64) {
+		s.basis(s.toUnit(v), row)
+	})
+	s.Coef = coef.Values()
+
+	return s
+}
+
+//Values of the series at the elements of x.
+func (s *Series) At(x *nd.NdArray) *nd.NdArray {
+	row := make([]float64, len(s.Coef))
+	return x.Map(func(v float64) float64 {
+		s.basis(s.toUnit(v), row)
+		r := 0.0
+		for k, c := range s.Coef {
+			r += c * row[k]
+		}
+		return r
+	})
+}
+
+//The series as a polynomial in x, in the power basis of Polyval.
+func (s *Series) Poly() *nd.NdArray {
+	//sum of the coefficients times the basis polynomials in t
+	inT := nd.Zeros(1)
+	prev, cur := nd.Array(1), nd.Array(1, 0)
+	for k, c := range s.Coef {
+		b := prev
+		if k > 0 {
+			b = cur
+		}
+		inT = Polyadd(inT, b.Map(func(v float64) float64 { return c * v }))
+		if k > 0 {
+			prev, cur = cur, s.next(k, prev, cur)
+		}
+	}
+
+	//t = a x + b, substituted by Horner's scheme
+	a := 2 / (s.Hi - s.Lo)
+	t := nd.Array(a, -a*s.Lo-1)
+	r := nd.Zeros(1)
+	for _, c := range inT.Values() {
+		r = Polyadd(Polymul(r, t), nd.Array(c))
+	}
+
+	return nd.Array(trim(r.Values())...)
+}
+
+func (s *Series) toUnit(x float64) float64 {
+	return (2*x - s.Lo - s.Hi) / (s.Hi - s.Lo)
+}
+
+//the values of the first len(row) polynomials of the family at t, into row.
+func (s *Series) basis(t float64, row []float64) {
+	for k := range row {
+		switch {
+		case k == 0:
+			row[k] = 1
+		case k == 1:
+			row[k] = t
+		case s.Family == Chebyshev:
+			row[k] = 2*t*row[k-1] - row[k-2]
+		case s.Family == Legendre:
+			row[k] = (float64(2*k-1)*t*row[k-1] - float64(k-1)*row[k-2]) / float64(k)
+		default:
+			panic(fmt.Errorf("family: %v unknown", s.Family))
+		}
+	}
+}
+
+//the polynomial of degree k + 1 from those of degrees k - 1 and k, in the power basis of t.
+func (s *Series) next(k int, prev, cur *nd.NdArray) *nd.NdArray {
+	switch s.Family {
+	case Chebyshev:
+		return Polysub(Polymul(nd.Array(2, 0), cur), prev)
+	case Legendre:
+		r := Polysub(Polymul(nd.Array(float64(2*k+1), 0), cur), Polymul(nd.Array(float64(k)), prev))
+		return Polymul(r, nd.Array(1/float64(k+1)))
+	}
+
+	panic(fmt.Errorf("family: %v unknown", s.Family))
+}
@@ -0,0 +1,62 @@
+package polynomial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestSeriesAt(t *testing.T) {
+	//T3 = 4t^3 - 3t and P2 = (3t^2 - 1) / 2 on [-1, 1]
+	x := nd.Array(-1, -0.5, 0, 0.3, 1)
+	cheb := &Series{Family: Chebyshev, Coef: []float64{0, 0, 0, 1}, Lo: -1, Hi: 1}
+	want := x.Map(func(v float64) float64 { return 4*v*v*v - 3*v })
+	if !cheb.At(x).Equals(want) {
+		t.Error("Expected ", want, ", got ", cheb.At(x))
+	}
+	leg := &Series{Family: Legendre, Coef: []float64{0, 0, 1}, Lo: -1, Hi: 1}
+	want = x.Map(func(v float64) float64 { return (3*v*v - 1) / 2 })
+	if !leg.At(x).Equals(want) {
+		t.Error("Expected ", want, ", got ", leg.At(x))
+	}
+
+	//the domain [0, 4] maps 3 to t = 0.5
+	s := &Series{Family: Chebyshev, Coef: []float64{1, 2, 3}, Lo: 0, Hi: 4}
+	if !s.At(nd.Array(3)).Equals(nd.Array(1 + 2*0.5 + 3*(2*0.25-1))) {
+		t.Error("Expected 0.5, got ", s.At(nd.Array(3)))
+	}
+}
+
+func TestFit(t *testing.T) {
+	x := nd.Arange(50).Map(func(v float64) float64 { return v * 10 / 49 })
+	f := func(v float64) float64 { return math.Sin(v) }
+	for _, family := range []Family{Chebyshev, Legendre} {
+		s := Fit(family, x, x.Map(f), 20, nil)
+		if s.Lo != 0 || s.Hi != 10 {
+			t.Error("Expected the domain [0, 10], got ", s.Lo, s.Hi)
+		}
+		at := nd.Array(0.05, 2.5, 7.3, 9.9)
+		if !s.At(at).Equals(at.Map(f)) {
+			t.Error("Expected ", at.Map(f), ", got ", s.At(at))
+		}
+	}
+
+	//polynomials are fitted exactly and converted back
+	p := nd.Array(0.5, -1, 2, 4)
+	y := Polyval(p, x)
+	for _, family := range []Family{Chebyshev, Legendre} {
+		s := Fit(family, x, y, 3, nil)
+		if !s.Poly().Equals(p) {
+			t.Error("Expected ", p, ", got ", s.Poly())
+		}
+	}
+
+	//repeated points, a line through the means
+	for _, family := range []Family{Chebyshev, Legendre} {
+		s := Fit(family, nd.Array(0, 0, 1, 1), nd.Array(1, 2, 3, 5), 2, nil)
+		if s.Coef[2] != 0 || !s.At(nd.Array(0, 1)).Equals(nd.Array(1.5, 4)) {
+			t.Error("Expected a line through [1.5, 4], got ", s.Coef)
+		}
+	}
+}
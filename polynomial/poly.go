@@ -0,0 +1,261 @@
+//Package polynomial fits, evaluates and manipulates polynomials held in *nd.NdArray.
+//The Poly functions use the power basis with the coefficients highest power first, like numpy.poly1d:
+//[1, -2, 3] is x^2 - 2x + 3. Series use orthogonal bases, which are better conditioned for fits of
+//high degree.
+package polynomial
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Values of the polynomial p at the elements of x, by Horner's scheme.
+func Polyval(p, x *nd.NdArray) *nd.NdArray {
+	cs := coefficients(p)
+	return x.Map(func(v float64) float64 {
+		r := 0.0
+		for _, c := range cs {
+			r = r*v + c
+		}
+		return r
+	})
+}
+
+//Least squares fit of a polynomial of degree deg to the points (x[i], y[i]), like numpy.polyfit.
+//weights, nil for none, multiply the residuals, use 1 / sigma for errors of standard deviation sigma.
+//It returns the coefficients and their covariance matrix, scaled by the residual sum of squares over
+//the n - deg - 1 degrees of freedom, which is NaN when there are none. x needs at least deg + 1 elements.
+//With fewer than deg + 1 distinct x the covariance is NaN, and the fit has the lowest degree possible,
+//the coefficients of the higher powers being 0.
+func Polyfit(x, y *nd.NdArray, deg int, weights *nd.NdArray) (*nd.NdArray, *nd.NdArray) {
+	if deg < 0 {
+		panic(fmt.Errorf("degree: %v must not be negative", deg))
+	}
+	checkSample(x, y, weights)
+	if x.Size() < deg+1 {
+		panic(fmt.Errorf("points: %v, at least %v needed for degree %v", x.Size(), deg+1, deg))
+	}
+
+	//increasing powers for weightedFit, reversed after
+	coef, cov := weightedFit(x, y, weights, deg+1, func(v float64, row []float64) {
+		p := 1.0
+		for j := range row {
+			row[j] = p
+			p *= v
+		}
+	})
+	m := deg + 1
+	cs := coef.Values()
+	for i := 0; i < m/2; i++ {
+		cs[i], cs[m-1-i] = cs[m-1-i], cs[i]
+	}
+	reversed := nd.Zeros(m, m)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			reversed.Set(cov.Get(m-1-i, m-1-j), i, j)
+		}
+	}
+
+	return coef, reversed
+}
+
+//Real and imaginary parts of the roots of p, the eigenvalues of its companion matrix,
+//in no particular order. Leading zeros are ignored, and trailing zeros give roots at 0.
+func Roots(p *nd.NdArray) (*nd.NdArray, *nd.NdArray) {
+	cs := trim(coefficients(p))
+	zeros := 0
+	for len(cs) > 1 && cs[len(cs)-1] == 0 {
+		cs = cs[:len(cs)-1]
+		zeros++
+	}
+
+	n := len(cs) - 1
+	re, im := make([]float64, 0, n+zeros), make([]float64, 0, n+zeros)
+	if n > 0 {
+		//first row -cs[1:] / cs[0], ones on the subdiagonal
+		companion := nd.Zeros(n, n)
+		for j := 0; j < n; j++ {
+			companion.Set(-cs[j+1]/cs[0], 0, j)
+			if j > 0 {
+				companion.Set(1, j, j-1)
+			}
+		}
+		r, i := companion.Eigvals()
+		re, im = append(re, r.Values()...), append(im, i.Values()...)
+	}
+	for k := 0; k < zeros; k++ {
+		re, im = append(re, 0), append(im, 0)
+	}
+
+	return nd.Array(re...), nd.Array(im...)
+}
+
+//m-th derivative of p.
+func Polyder(p *nd.NdArray, m int) *nd.NdArray {
+	if m < 0 {
+		panic(fmt.Errorf("order: %v must not be negative", m))
+	}
+	cs := coefficients(p)
+	for ; m > 0; m-- {
+		if len(cs) == 1 {
+			return nd.Zeros(1)
+		}
+		n := len(cs) - 1
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = cs[i] * float64(n-i)
+		}
+		cs = d
+	}
+
+	return nd.Array(cs...)
+}
+
+//m-th antiderivative of p, each integration adding the constant k.
+func Polyint(p *nd.NdArray, m int, k float64) *nd.NdArray {
+	if m < 0 {
+		panic(fmt.Errorf("order: %v must not be negative", m))
+	}
+	cs := coefficients(p)
+	for ; m > 0; m-- {
+		n := len(cs)
+		r := make([]float64, n+1)
+		for i, c := range cs {
+			r[i] = c / float64(n-i)
+		}
+		r[n] = k
+		cs = r
+	}
+
+	return nd.Array(cs...)
+}
+
+//Sum of the polynomials a and b.
+func Polyadd(a, b *nd.NdArray) *nd.NdArray {
+	as, bs := coefficients(a), coefficients(b)
+	if len(as) < len(bs) {
+		as, bs = bs, as
+	}
+	r := append([]float64(nil), as...)
+	offset := len(as) - len(bs)
+	for i, c := range bs {
+		r[offset+i] += c
+	}
+
+	return nd.Array(r...)
+}
+
+//Difference a - b of the polynomials.
+func Polysub(a, b *nd.NdArray) *nd.NdArray {
+	return Polyadd(a, b.Map(func(v float64) float64 { return -v }))
+}
+
+//Product of the polynomials a and b.
+func Polymul(a, b *nd.NdArray) *nd.NdArray {
+	as, bs := coefficients(a), coefficients(b)
+	r := make([]float64, len(as)+len(bs)-1)
+	for i, x := range as {
+		for j, y := range bs {
+			r[i+j] += x * y
+		}
+	}
+
+	return nd.Array(r...)
+}
+
+//Quotient and remainder of the division of u by v, u = q v + r with r of lower degree than v.
+//Leading zeros of the remainder are removed.
+func Polydiv(u, v *nd.NdArray) (*nd.NdArray, *nd.NdArray) {
+	us, vs := coefficients(u), trim(coefficients(v))
+	if vs[0] == 0 {
+		panic("division by the zero polynomial")
+	}
+
+	r := append([]float64(nil), us...)
+	n := len(us) - len(vs) + 1
+	if n < 1 {
+		return nd.Zeros(1), nd.Array(trim(r)...)
+	}
+	q := make([]float64, n)
+	for i := range q {
+		q[i] = r[i] / vs[0]
+		for j, c := range vs {
+			r[i+j] -= q[i] * c
+		}
+	}
+
+	//the leading terms cancel up to rounding
+	scale := 0.0
+	for _, c := range us {
+		scale = math.Max(scale, math.Abs(c))
+	}
+	rem := r[n:]
+	for len(rem) > 1 && math.Abs(rem[0]) <= 1e-14*scale {
+		rem = rem[1:]
+	}
+
+	return nd.Array(q...), nd.Array(rem...)
+}
+
+//the values of the 1d array p.
+func coefficients(p *nd.NdArray) []float64 {
+	if p.NDims() != 1 || p.Size() == 0 {
+		panic("shape error")
+	}
+
+	return p.Values()
+}
+
+//cs without its leading zeros, keeping one element.
+func trim(cs []float64) []float64 {
+	for len(cs) > 1 && cs[0] == 0 {
+		cs = cs[1:]
+	}
+
+	return cs
+}
+
+func checkSample(x, y, weights *nd.NdArray) {
+	if x.NDims() != 1 || y.NDims() != 1 || x.Size() != y.Size() {
+		panic("shape error")
+	}
+	if weights != nil && (weights.NDims() != 1 || weights.Size() != x.Size()) {
+		panic("shape error")
+	}
+}
+
+//Weighted least squares fit of y on the p functions that basis evaluates at x into row,
+//returning the coefficients and their covariance scaled by the residual variance, by nd.LstsqCov.
+//When the design is rank deficient, with fewer distinct x than p, the covariance is NaN and the coefficients
+//from the first dependent function on are 0, so basis lists the functions by increasing degree.
+func weightedFit(x, y, weights *nd.NdArray, p int, basis func(v float64, row []float64)) (*nd.NdArray, *nd.NdArray) {
+	n := x.Size()
+	lhs, rhs := nd.Zeros(n, p), y.Clone()
+	ls, rs := lhs.Values(), rhs.Values()
+	for i, v := range x.Values() {
+		row := ls[i*p : (i+1)*p]
+		basis(v, row)
+		if weights != nil {
+			w := weights.Get(i)
+			for j := range row {
+				row[j] *= w
+			}
+			rs[i] *= w
+		}
+	}
+
+	coef, cov := lhs.LstsqCov(rhs)
+	residuals := rhs.Sub(nd.MatMul(lhs, coef))
+	rss := 0.0
+	for _, e := range residuals.Values() {
+		rss += e * e
+	}
+	sigma2 := rss / float64(n-p)
+	if n == p {
+		sigma2 = math.NaN()
+	}
+
+	return coef, cov.Map(func(v float64) float64 { return v * sigma2 })
+}
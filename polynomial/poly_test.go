@@ -0,0 +1,137 @@
+package polynomial
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestPolyval(t *testing.T) {
+	p := nd.Array(1, -2, 3)
+	x := nd.Array(0, 1, 2, -1, 0.5, 3).Reshape(2, 3)
+	want := nd.Array(3, 2, 3, 6, 2.25, 6).Reshape(2, 3)
+	if !Polyval(p, x).Equals(want) {
+		t.Error("Expected ", want, ", got ", Polyval(p, x))
+	}
+}
+
+func TestPolyfit(t *testing.T) {
+	//exact data are reproduced
+	x := nd.Array(-2, -1, 0, 1, 2, 3)
+	y := Polyval(nd.Array(0.5, -1, 2, 4), x)
+	coef, _ := Polyfit(x, y, 3, nil)
+	if !coef.Equals(nd.Array(0.5, -1, 2, 4)) {
+		t.Error("Expected [0.5, -1, 2, 4], got ", coef)
+	}
+
+	//simple linear regression: slope and intercept 1.1, residual variance 2.7 / 2
+	coef, cov := Polyfit(nd.Array(0, 1, 2, 3), nd.Array(1, 3, 2, 5), 1, nil)
+	if !coef.Equals(nd.Array(1.1, 1.1)) {
+		t.Error("Expected [1.1, 1.1], got ", coef)
+	}
+	if !cov.Equals(nd.Array(0.27, -0.405, -0.405, 0.945).Reshape(2, 2)) {
+		t.Error("Expected [[0.27, -0.405], [-0.405, 0.945]], got ", cov)
+	}
+
+	//a point of weight 0 is ignored
+	coef, _ = Polyfit(nd.Array(0, 1, 2, 3), nd.Array(1, 3, 5, 100), 1, nd.Array(1, 1, 1, 0))
+	if !coef.Equals(nd.Array(2, 1)) {
+		t.Error("Expected [2, 1], got ", coef)
+	}
+
+	//no degrees of freedom left for the covariance
+	_, cov = Polyfit(nd.Array(0, 1), nd.Array(1, 3), 1, nil)
+	if !math.IsNaN(cov.Get(0, 0)) {
+		t.Error("Expected NaN, got ", cov)
+	}
+
+	//repeated points, a line through the means at 0 and 1 for degree 2
+	coef, cov = Polyfit(nd.Array(0, 0, 1, 1), nd.Array(1, 2, 3, 5), 2, nil)
+	if !coef.Equals(nd.Array(0, 2.5, 1.5)) {
+		t.Error("Expected [0, 2.5, 1.5], got ", coef)
+	}
+	for _, v := range cov.Values() {
+		if !math.IsNaN(v) {
+			t.Error("Expected a NaN covariance, got ", cov)
+			break
+		}
+	}
+}
+
+func TestRoots(t *testing.T) {
+	sorted := func(p *nd.NdArray) ([]float64, []float64) {
+		re, im := Roots(p)
+		rs, is := re.Values(), im.Values()
+		for i := range rs {
+			for j := i + 1; j < len(rs); j++ {
+				if rs[j] < rs[i]-1e-9 || (math.Abs(rs[j]-rs[i]) <= 1e-9 && is[j] < is[i]) {
+					rs[i], rs[j] = rs[j], rs[i]
+					is[i], is[j] = is[j], is[i]
+				}
+			}
+		}
+		return rs, is
+	}
+
+	//(x - 1)(x + 2)(x - 3) x^2, with a leading zero
+	re, im := sorted(nd.Array(0, 1, -2, -5, 6, 0, 0))
+	if !nd.Array(re...).Equals(nd.Array(-2, 0, 0, 1, 3)) || !nd.Array(im...).Equals(nd.Zeros(5)) {
+		t.Error("Expected [-2, 0, 0, 1, 3], got ", re, im)
+	}
+	//x^2 + 2x + 5 = 0 at -1 +- 2i
+	re, im = sorted(nd.Array(1, 2, 5))
+	if !nd.Array(re...).Equals(nd.Array(-1, -1)) || !nd.Array(im...).Equals(nd.Array(-2, 2)) {
+		t.Error("Expected [-1 - 2i, -1 + 2i], got ", re, im)
+	}
+	re, _ = sorted(nd.Array(3))
+	if len(re) != 0 {
+		t.Error("Expected no roots, got ", re)
+	}
+}
+
+func TestPolyderPolyint(t *testing.T) {
+	p := nd.Array(4, 3, 2, 1)
+	if !Polyder(p, 1).Equals(nd.Array(12, 6, 2)) {
+		t.Error("Expected [12, 6, 2], got ", Polyder(p, 1))
+	}
+	if !Polyder(p, 2).Equals(nd.Array(24, 6)) {
+		t.Error("Expected [24, 6], got ", Polyder(p, 2))
+	}
+	if !Polyder(p, 5).Equals(nd.Zeros(1)) {
+		t.Error("Expected [0], got ", Polyder(p, 5))
+	}
+	if !Polyint(p, 1, 5).Equals(nd.Array(1, 1, 1, 1, 5)) {
+		t.Error("Expected [1, 1, 1, 1, 5], got ", Polyint(p, 1, 5))
+	}
+	if !Polyder(Polyint(p, 2, 1), 2).Equals(p) {
+		t.Error("Expected ", p, ", got ", Polyder(Polyint(p, 2, 1), 2))
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := nd.Array(1, 2, 3), nd.Array(4, 5)
+	if !Polyadd(a, b).Equals(nd.Array(1, 6, 8)) {
+		t.Error("Expected [1, 6, 8], got ", Polyadd(a, b))
+	}
+	if !Polysub(b, a).Equals(nd.Array(-1, 2, 2)) {
+		t.Error("Expected [-1, 2, 2], got ", Polysub(b, a))
+	}
+	if !Polymul(a, b).Equals(nd.Array(4, 13, 22, 15)) {
+		t.Error("Expected [4, 13, 22, 15], got ", Polymul(a, b))
+	}
+
+	//(x^3 + 2x^2 - 3x + 4) = (x - 1)(x^2 + 3x) + 4
+	q, r := Polydiv(nd.Array(1, 2, -3, 4), nd.Array(1, -1))
+	if !q.Equals(nd.Array(1, 3, 0)) || !r.Equals(nd.Array(4)) {
+		t.Error("Expected [1, 3, 0] and [4], got ", q, r)
+	}
+	q, r = Polydiv(nd.Array(4, 13, 22, 15), a)
+	if !q.Equals(b) || !r.Equals(nd.Zeros(1)) {
+		t.Error("Expected ", b, " and [0], got ", q, r)
+	}
+	q, r = Polydiv(b, a)
+	if !q.Equals(nd.Zeros(1)) || !r.Equals(b) {
+		t.Error("Expected [0] and ", b, ", got ", q, r)
+	}
+}
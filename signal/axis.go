@@ -0,0 +1,28 @@
+package signal
+
+import (
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Apply f to every lane of a along its last axis, f maps the lane to the m elements of out
+//and must not change the lane. The result has the shape of a with m elements along the last axis.
+func mapLast(a *nd.NdArray, m int, f func(lane, out []float64)) *nd.NdArray {
+	shape := append([]int(nil), a.Shape()...)
+	last := len(shape) - 1
+	n := shape[last]
+	shape[last] = m
+	r := nd.Zeros(shape...)
+
+	as, rs := a.Values(), r.Values()
+	for i := 0; i < util.ProductOfIntSlice(shape[:last]); i++ {
+		f(as[i*n:(i+1)*n], rs[i*m:(i+1)*m])
+	}
+
+	return r
+}
+
+//length of the last axis of a.
+func lastLen(a *nd.NdArray) int {
+	return a.Shape()[a.NDims()-1]
+}
@@ -0,0 +1,345 @@
+//Package signal processes sampled signals held in *nd.NdArray: convolution, filtering and spectra.
+package signal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/fft"
+	"github.com/ledao/ndarray/util"
+)
+
+//Which part of the full convolution is returned.
+type Mode int
+
+const (
+	//every output where the inputs overlap, n + k - 1 elements along an axis
+	Full Mode = iota
+	//the middle of the full output, with the shape of the first input
+	Same
+	//only the outputs where one input lies entirely within the other, |n - k| + 1 elements along an axis
+	Valid
+)
+
+//How an N-d convolution extends the first input beyond its edges, in Full and Same modes.
+type Boundary int
+
+const (
+	//pad with a fill value
+	Fill Boundary = iota
+	//wrap around periodically
+	Wrap
+	//mirror at the edges, repeating the edge elements
+	Symmetric
+)
+
+//Discrete linear convolution of the 1d arrays a and v, like numpy.convolve.
+//The arrays are swapped when v is longer, so Same gives max(n, k) elements.
+//Large inputs are convolved through FFTs, small ones directly.
+func Convolve(a, v *nd.NdArray, mode Mode) *nd.NdArray {
+	if a.NDims() != 1 || v.NDims() != 1 {
+		panic("shape error")
+	}
+	if v.Size() > a.Size() {
+		a, v = v, a
+	}
+
+	return ConvolveND(a, v, mode, Fill, 0)
+}
+
+//Cross correlation of the 1d arrays a and v, r[k] = sum a[n + k] v[n], like numpy.correlate.
+//It is the convolution of a with v reversed.
+func Correlate(a, v *nd.NdArray, mode Mode) *nd.NdArray {
+	if a.NDims() != 1 || v.NDims() != 1 {
+		panic("shape error")
+	}
+
+	return Convolve(a, flip(v), mode)
+}
+
+//Convolution of the 2d arrays a and v, like scipy.signal.convolve2d.
+//Outside its edges a is extended as given by boundary, with fillValue for Fill.
+func Convolve2D(a, v *nd.NdArray, mode Mode, boundary Boundary, fillValue float64) *nd.NdArray {
+	if a.NDims() != 2 || v.NDims() != 2 {
+		panic("shape error")
+	}
+
+	return ConvolveND(a, v, mode, boundary, fillValue)
+}
+
+//Cross correlation of the 2d arrays a and v, the convolution of a with v flipped along both axes.
+func Correlate2D(a, v *nd.NdArray, mode Mode, boundary Boundary, fillValue float64) *nd.NdArray {
+	if a.NDims() != 2 || v.NDims() != 2 {
+		panic("shape error")
+	}
+
+	return ConvolveND(a, flip(v), mode, boundary, fillValue)
+}
+
+//Cross correlation of the N-d arrays a and v, the convolution of a with v flipped along every axis.
+func CorrelateND(a, v *nd.NdArray, mode Mode, boundary Boundary, fillValue float64) *nd.NdArray {
+	return ConvolveND(a, flip(v), mode, boundary, fillValue)
+}
+
+//Convolution of the N-d arrays a and v, which have the same number of dimensions.
+//In Full and Same modes a is extended outside its edges as given by boundary, with fillValue for Fill.
+//In Valid mode the boundary does not matter, and one array must be at least as large as the other
+//along every axis.
+func ConvolveND(a, v *nd.NdArray, mode Mode, boundary Boundary, fillValue float64) *nd.NdArray {
+	as, vs := a.Shape(), v.Shape()
+	if len(as) != len(vs) || a.Size() == 0 || v.Size() == 0 {
+		panic("shape error")
+	}
+
+	dims := len(as)
+	start, shape := make([]int, dims), make([]int, dims)
+	if mode == Valid {
+		larger, smaller := true, true
+		for d := range as {
+			larger = larger && as[d] >= vs[d]
+			smaller = smaller && as[d] <= vs[d]
+		}
+		if !larger && !smaller {
+			panic(fmt.Errorf("shapes: %v and %v, neither is at least as large as the other", as, vs))
+		}
+		for d := range as {
+			start[d] = int(math.Min(float64(as[d]), float64(vs[d]))) - 1
+			shape[d] = int(math.Abs(float64(as[d]-vs[d]))) + 1
+		}
+		return crop(convolveFull(a, v), start, shape)
+	}
+
+	//the full convolution of a extended by k - 1 on each side holds that of the extended signal
+	//from k - 1 on
+	x := a
+	padded := boundary != Fill || fillValue != 0
+	if padded {
+		x = pad(a, vs, boundary, fillValue)
+	}
+	for d := range as {
+		switch mode {
+		case Full:
+			shape[d] = as[d] + vs[d] - 1
+		case Same:
+			start[d] = (vs[d] - 1) / 2
+			shape[d] = as[d]
+		default:
+			panic(fmt.Errorf("mode: %v unknown", mode))
+		}
+		if padded {
+			start[d] += vs[d] - 1
+		}
+	}
+
+	return crop(convolveFull(x, v), start, shape)
+}
+
+//full convolution, through FFTs when that is expected to be cheaper.
+func convolveFull(a, v *nd.NdArray) *nd.NdArray {
+	if useFFT(a.Shape(), v.Shape()) {
+		return fftConvolve(a, v)
+	}
+
+	return directConvolve(a, v)
+}
+
+//Whether the direct convolution takes more multiply-adds than three transforms of the padded length,
+//each counted as ten per element and level.
+func useFFT(as, vs []int) bool {
+	direct := float64(util.ProductOfIntSlice(as)) * float64(util.ProductOfIntSlice(vs))
+	l := 1.0
+	for d := range as {
+		l *= float64(fastLen(as[d] + vs[d] - 1))
+	}
+
+	return direct > 30*l*(math.Log2(l)+1)
+}
+
+func directConvolve(a, v *nd.NdArray) *nd.NdArray {
+	as, vs := a.Shape(), v.Shape()
+	shape := make([]int, len(as))
+	for d := range as {
+		shape[d] = as[d] + vs[d] - 1
+	}
+	r := nd.Zeros(shape...)
+	strides := stridesOf(shape)
+
+	//element i of a and j of v add to the element of r at the sum of their positions
+	aOff, vOff := offsets(as, strides), offsets(vs, strides)
+	rs, vv := r.Values(), v.Values()
+	for i, x := range a.Values() {
+		if x == 0 {
+			continue
+		}
+		out := rs[aOff[i]:]
+		for j, y := range vv {
+			out[vOff[j]] += x * y
+		}
+	}
+
+	return r
+}
+
+//full convolution as the inverse transform of the product of the transforms,
+//real along the last axis and complex along the others.
+func fftConvolve(a, v *nd.NdArray) *nd.NdArray {
+	as, vs := a.Shape(), v.Shape()
+	last := len(as) - 1
+	shape, lens := make([]int, len(as)), make([]int, len(as))
+	for d := range as {
+		shape[d] = as[d] + vs[d] - 1
+		lens[d] = fastLen(shape[d])
+	}
+
+	spectrum := func(x *nd.NdArray) *fft.ComplexArray {
+		s := fft.RFFT(x, lens[last], last)
+		for d := 0; d < last; d++ {
+			s = fft.FFT(s, lens[d], d)
+		}
+		return s
+	}
+	s := spectrum(a).Mul(spectrum(v))
+	for d := 0; d < last; d++ {
+		s = fft.IFFT(s, 0, d)
+	}
+
+	return crop(fft.IRFFT(s, lens[last], last), make([]int, len(as)), shape)
+}
+
+//smallest n' >= n whose prime factors are 2, 3 and 5.
+func fastLen(n int) int {
+	for ; ; n++ {
+		m := n
+		for _, f := range []int{2, 3, 5} {
+			for m%f == 0 {
+				m /= f
+			}
+		}
+		if m == 1 {
+			return n
+		}
+	}
+}
+
+//the block of a of the given shape starting at start.
+func crop(a *nd.NdArray, start, shape []int) *nd.NdArray {
+	strides := stridesOf(a.Shape())
+	base := 0
+	for d, s := range start {
+		base += s * strides[d]
+	}
+
+	r := nd.Zeros(shape...)
+	rs, as := r.Values(), a.Values()
+	for i, off := range offsets(shape, strides) {
+		rs[i] = as[base+off]
+	}
+
+	return r
+}
+
+//a extended by k - 1 elements on both sides of every axis, k the length of v along it.
+func pad(a *nd.NdArray, vs []int, boundary Boundary, fillValue float64) *nd.NdArray {
+	as := a.Shape()
+	shape := make([]int, len(as))
+	//source position along each axis of each padded position, -1 for the fill value
+	sources := make([][]int, len(as))
+	for d, n := range as {
+		k := vs[d]
+		shape[d] = n + 2*(k-1)
+		sources[d] = make([]int, shape[d])
+		for p := range sources[d] {
+			j := p - (k - 1)
+			switch boundary {
+			case Fill:
+				if j < 0 || j >= n {
+					j = -1
+				}
+			case Wrap:
+				j = (j%n + n) % n
+			case Symmetric:
+				//the mirrored signal has period 2n
+				j = (j%(2*n) + 2*n) % (2 * n)
+				if j >= n {
+					j = 2*n - 1 - j
+				}
+			default:
+				panic(fmt.Errorf("boundary: %v unknown", boundary))
+			}
+			sources[d][p] = j
+		}
+	}
+
+	r := nd.Zeros(shape...)
+	rs, vals := r.Values(), a.Values()
+	strides := stridesOf(as)
+	pos := make([]int, len(shape))
+	for i := range rs {
+		src := 0
+		for d, p := range pos {
+			j := sources[d][p]
+			if j < 0 {
+				src = -1
+				break
+			}
+			src += j * strides[d]
+		}
+		if src < 0 {
+			rs[i] = fillValue
+		} else {
+			rs[i] = vals[src]
+		}
+		next(pos, shape)
+	}
+
+	return r
+}
+
+//a reversed along every axis.
+func flip(a *nd.NdArray) *nd.NdArray {
+	r := a.Clone()
+	rs := r.Values()
+	for i, j := 0, len(rs)-1; i < j; i, j = i+1, j-1 {
+		rs[i], rs[j] = rs[j], rs[i]
+	}
+
+	return r
+}
+
+//row major strides of shape.
+func stridesOf(shape []int) []int {
+	strides := make([]int, len(shape))
+	s := 1
+	for d := len(shape) - 1; d >= 0; d-- {
+		strides[d] = s
+		s *= shape[d]
+	}
+
+	return strides
+}
+
+//for each element of an array of shape, in row major order, the sum of its position times strides.
+func offsets(shape, strides []int) []int {
+	offs := make([]int, util.ProductOfIntSlice(shape))
+	pos := make([]int, len(shape))
+	for i := range offs {
+		for d, p := range pos {
+			offs[i] += p * strides[d]
+		}
+		next(pos, shape)
+	}
+
+	return offs
+}
+
+//advance pos to the next position of shape in row major order.
+func next(pos, shape []int) {
+	for d := len(shape) - 1; d >= 0; d-- {
+		pos[d]++
+		if pos[d] < shape[d] {
+			return
+		}
+		pos[d] = 0
+	}
+}
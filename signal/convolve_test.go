@@ -0,0 +1,117 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestConvolve(t *testing.T) {
+	a, v := nd.Array(1, 2, 3), nd.Array(0, 1, 0.5)
+
+	if !Convolve(a, v, Full).Equals(nd.Array(0, 1, 2.5, 4, 1.5)) {
+		t.Error("Expected [0, 1, 2.5, 4, 1.5], got ", Convolve(a, v, Full))
+	}
+	if !Convolve(a, v, Same).Equals(nd.Array(1, 2.5, 4)) {
+		t.Error("Expected [1, 2.5, 4], got ", Convolve(a, v, Same))
+	}
+	if !Convolve(a, v, Valid).Equals(nd.Array(2.5)) {
+		t.Error("Expected [2.5], got ", Convolve(a, v, Valid))
+	}
+	//the longer array comes first
+	if !Convolve(nd.Array(1, 1), nd.Array(1, 2, 3, 4), Same).Equals(nd.Array(1, 3, 5, 7)) {
+		t.Error("Expected [1, 3, 5, 7], got ", Convolve(nd.Array(1, 1), nd.Array(1, 2, 3, 4), Same))
+	}
+
+	if !Correlate(a, v, Full).Equals(nd.Array(0.5, 2, 3.5, 3, 0)) {
+		t.Error("Expected [0.5, 2, 3.5, 3, 0], got ", Correlate(a, v, Full))
+	}
+	if !Correlate(a, v, Valid).Equals(nd.Array(3.5)) {
+		t.Error("Expected [3.5], got ", Correlate(a, v, Valid))
+	}
+	if !Correlate(nd.Array(1, 2), nd.Array(1, 2, 3), Full).Equals(nd.Array(3, 8, 5, 2)) {
+		t.Error("Expected [3, 8, 5, 2], got ", Correlate(nd.Array(1, 2), nd.Array(1, 2, 3), Full))
+	}
+}
+
+func TestConvolve2D(t *testing.T) {
+	a, v := nd.Array(1, 2, 3, 4).Reshape(2, 2), nd.Ones(2, 2)
+
+	full := nd.Array(1, 3, 2, 4, 10, 6, 3, 7, 4).Reshape(3, 3)
+	if !Convolve2D(a, v, Full, Fill, 0).Equals(full) {
+		t.Error("Expected ", full, ", got ", Convolve2D(a, v, Full, Fill, 0))
+	}
+	if !Convolve2D(a, v, Same, Fill, 0).Equals(nd.Array(1, 3, 4, 10).Reshape(2, 2)) {
+		t.Error("Expected [[1, 3], [4, 10]], got ", Convolve2D(a, v, Same, Fill, 0))
+	}
+	if !Convolve2D(a, v, Valid, Wrap, 0).Equals(nd.Array(10).Reshape(1, 1)) {
+		t.Error("Expected [[10]], got ", Convolve2D(a, v, Valid, Wrap, 0))
+	}
+
+	corr := nd.Array(4, 11, 6, 14, 30, 14, 6, 11, 4).Reshape(3, 3)
+	if !Correlate2D(a, a, Full, Fill, 0).Equals(corr) {
+		t.Error("Expected ", corr, ", got ", Correlate2D(a, a, Full, Fill, 0))
+	}
+	if !CorrelateND(a, a, Full, Fill, 0).Equals(corr) {
+		t.Error("Expected ", corr, ", got ", CorrelateND(a, a, Full, Fill, 0))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for Valid with crossed shapes")
+		}
+	}()
+	Convolve2D(nd.Ones(3, 1), nd.Ones(1, 3), Valid, Fill, 0)
+}
+
+func TestConvolveBoundary(t *testing.T) {
+	a, v := nd.Array(1, 2, 3), nd.Array(1, 1)
+
+	if !ConvolveND(a, v, Full, Wrap, 0).Equals(nd.Array(4, 3, 5, 4)) {
+		t.Error("Expected [4, 3, 5, 4], got ", ConvolveND(a, v, Full, Wrap, 0))
+	}
+	if !ConvolveND(a, v, Same, Wrap, 0).Equals(nd.Array(4, 3, 5)) {
+		t.Error("Expected [4, 3, 5], got ", ConvolveND(a, v, Same, Wrap, 0))
+	}
+	if !ConvolveND(a, v, Full, Symmetric, 0).Equals(nd.Array(2, 3, 5, 6)) {
+		t.Error("Expected [2, 3, 5, 6], got ", ConvolveND(a, v, Full, Symmetric, 0))
+	}
+	if !ConvolveND(a, v, Full, Fill, 10).Equals(nd.Array(11, 3, 5, 13)) {
+		t.Error("Expected [11, 3, 5, 13], got ", ConvolveND(a, v, Full, Fill, 10))
+	}
+
+	//a 3x3 box filter on a constant image keeps it constant with Wrap and Symmetric
+	img := nd.Ones(4, 5).Map(func(float64) float64 { return 2 })
+	box := nd.Ones(3, 3).Map(func(float64) float64 { return 1.0 / 9 })
+	for _, b := range []Boundary{Wrap, Symmetric} {
+		if !Convolve2D(img, box, Same, b, 0).Equals(img) {
+			t.Error("Expected a constant image, got ", Convolve2D(img, box, Same, b, 0))
+		}
+	}
+	//kernels longer than the signal wrap more than once
+	if !ConvolveND(nd.Array(1, 2), nd.Ones(5), Same, Wrap, 0).Equals(nd.Array(7, 8)) {
+		t.Error("Expected [7, 8], got ", ConvolveND(nd.Array(1, 2), nd.Ones(5), Same, Wrap, 0))
+	}
+}
+
+func TestFFTConvolve(t *testing.T) {
+	g := random.NewGenerator(8)
+	pairs := [][2]*nd.NdArray{
+		{g.Normal(0, 1, 1000), g.Normal(0, 1, 700)},
+		{g.Normal(0, 1, 37), g.Normal(0, 1, 5)},
+		{g.Normal(0, 1, 6, 9, 7), g.Normal(0, 1, 3, 4, 2)},
+	}
+	for _, p := range pairs {
+		if !fftConvolve(p[0], p[1]).Equals(directConvolve(p[0], p[1])) {
+			t.Error("Expected the FFT and direct convolutions of shapes ", p[0].Shape(), p[1].Shape(), " to agree")
+		}
+	}
+
+	if !useFFT([]int{1000}, []int{700}) || useFFT([]int{1000}, []int{5}) {
+		t.Error("Expected the FFT path for long kernels only")
+	}
+	if fastLen(97) != 100 || fastLen(1) != 1 || fastLen(121) != 125 {
+		t.Error("Expected 100, 1 and 125, got ", fastLen(97), fastLen(1), fastLen(121))
+	}
+}
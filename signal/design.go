@@ -0,0 +1,355 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The frequencies a filter passes.
+type BandType int
+
+const (
+	Lowpass BandType = iota
+	Highpass
+	Bandpass
+	Bandstop
+)
+
+//Filter given by its zeros, poles and gain, H(z) = K prod(z - Z) / prod(z - P).
+//The designs are digital, and analog in the intermediate steps.
+type ZPK struct {
+	Z, P []complex128
+	K    float64
+}
+
+//The design functions follow scipy.signal: wn holds the critical frequencies as fractions of the
+//Nyquist frequency, one for Lowpass and Highpass, and the lower and upper edges for Bandpass and Bandstop.
+//The analog prototype is mapped to the band, then to a digital filter by the bilinear transform with
+//prewarped frequencies. Band filters have twice the order.
+
+//Butterworth filter, maximally flat in the passband, -3 dB at the critical frequencies.
+func Butter(order int, band BandType, wn ...float64) *ZPK {
+	checkOrder(order)
+	p := make([]complex128, order)
+	for i := range p {
+		m := float64(2*i - order + 1)
+		p[i] = -cmplx.Exp(complex(0, math.Pi*m/float64(2*order)))
+	}
+
+	return digital(&ZPK{P: p, K: 1}, band, wn)
+}
+
+//Chebyshev type I filter, with rp dB of equiripple in the passband, down by rp dB at the critical frequencies.
+func Cheby1(order int, rp float64, band BandType, wn ...float64) *ZPK {
+	checkOrder(order)
+	if !(rp > 0) {
+		panic(fmt.Errorf("ripple: %v must be positive", rp))
+	}
+
+	eps := math.Sqrt(math.Pow(10, 0.1*rp) - 1)
+	mu := math.Asinh(1/eps) / float64(order)
+	p := make([]complex128, order)
+	k := complex(1, 0)
+	for i := range p {
+		theta := math.Pi * float64(2*i-order+1) / float64(2*order)
+		p[i] = -cmplx.Sinh(complex(mu, theta))
+		k *= -p[i]
+	}
+	gain := real(k)
+	if order%2 == 0 {
+		gain /= math.Sqrt(1 + eps*eps)
+	}
+
+	return digital(&ZPK{P: p, K: gain}, band, wn)
+}
+
+//Chebyshev type II filter, with equiripple in the stopband at least rs dB down,
+//which is first reached at the critical frequencies.
+func Cheby2(order int, rs float64, band BandType, wn ...float64) *ZPK {
+	checkOrder(order)
+	if !(rs > 0) {
+		panic(fmt.Errorf("attenuation: %v must be positive", rs))
+	}
+
+	de := 1 / math.Sqrt(math.Pow(10, 0.1*rs)-1)
+	mu := math.Asinh(1/de) / float64(order)
+	z := []complex128{}
+	for i := 0; i < order; i++ {
+		//no zero at infinity for the middle pole of odd orders
+		m := 2*i - order + 1
+		if m != 0 {
+			z = append(z, -cmplx.Conj(1i/complex(math.Sin(float64(m)*math.Pi/float64(2*order)), 0)))
+		}
+	}
+	p := make([]complex128, order)
+	k := complex(1, 0)
+	for i := range p {
+		e := -cmplx.Exp(complex(0, math.Pi*float64(2*i-order+1)/float64(2*order)))
+		p[i] = 1 / complex(math.Sinh(mu)*real(e), math.Cosh(mu)*imag(e))
+		k *= -p[i]
+	}
+	for _, v := range z {
+		k /= -v
+	}
+
+	return digital(&ZPK{Z: z, P: p, K: real(k)}, band, wn)
+}
+
+//Coefficients of the transfer function b(z) / a(z), highest power first, for LFilter and FiltFilt.
+func (f *ZPK) TF() (*nd.NdArray, *nd.NdArray) {
+	b, a := poly(f.Z), poly(f.P)
+	for i := range b {
+		b[i] *= f.K
+	}
+
+	return nd.Array(b...), nd.Array(a...)
+}
+
+//Second order sections for SOSFilt, shape [sections, 6]. Poles closer to the unit circle come
+//in later sections, each paired with its conjugate and the nearest zeros, and the gain is in the first.
+func (f *ZPK) SOS() *nd.NdArray {
+	z, p := append([]complex128(nil), f.Z...), append([]complex128(nil), f.P...)
+	n := len(p)
+	if len(z) > n {
+		n = len(z)
+	}
+	n = (n + 1) / 2
+	//pad with roots at the origin to two per section
+	for len(z) < 2*n {
+		z = append(z, 0)
+	}
+	for len(p) < 2*n {
+		p = append(p, 0)
+	}
+
+	sos := nd.Zeros(n, 6)
+	for s := n - 1; s >= 0; s-- {
+		var p1, p2, z1, z2 complex128
+		p1, p = takeNearest(p, false, func(c complex128) float64 { return math.Abs(1 - cmplx.Abs(c)) })
+		if isReal(p1) {
+			p2, p = takeNearest(p, true, func(c complex128) float64 { return math.Abs(1 - cmplx.Abs(c)) })
+		} else {
+			p2, p = takeNearest(p, false, func(c complex128) float64 { return cmplx.Abs(c - cmplx.Conj(p1)) })
+		}
+		z1, z = takeNearest(z, false, func(c complex128) float64 { return cmplx.Abs(c - p1) })
+		if isReal(z1) {
+			z2, z = takeNearest(z, true, func(c complex128) float64 { return cmplx.Abs(c - p1) })
+		} else {
+			z2, z = takeNearest(z, false, func(c complex128) float64 { return cmplx.Abs(c - cmplx.Conj(z1)) })
+		}
+
+		gain := 1.0
+		if s == 0 {
+			gain = f.K
+		}
+		row := []float64{1, -real(z1 + z2), real(z1 * z2), 1, -real(p1 + p2), real(p1 * p2)}
+		for j, v := range row {
+			if j < 3 {
+				v *= gain
+			}
+			sos.Set(v, s, j)
+		}
+	}
+
+	return sos
+}
+
+func checkOrder(order int) {
+	if order < 1 {
+		panic(fmt.Errorf("order: %v must be positive", order))
+	}
+}
+
+//the analog lowpass prototype with cutoff 1 rad/s mapped to band and made digital.
+func digital(proto *ZPK, band BandType, wn []float64) *ZPK {
+	count := 1
+	if band == Bandpass || band == Bandstop {
+		count = 2
+	}
+	if len(wn) != count {
+		panic(fmt.Errorf("critical frequencies: %v, %v expected", wn, count))
+	}
+	if count == 2 && !(wn[0] < wn[1]) {
+		panic(fmt.Errorf("critical frequencies: %v must be increasing", wn))
+	}
+	//frequencies of the analog filter that the bilinear transform with fs = 2 maps to wn
+	warped := make([]float64, count)
+	for i, w := range wn {
+		if !(w > 0 && w < 1) {
+			panic(fmt.Errorf("critical frequency: %v out of (0, 1)", w))
+		}
+		warped[i] = 4 * math.Tan(math.Pi*w/2)
+	}
+
+	var analog *ZPK
+	switch band {
+	case Lowpass:
+		analog = proto.lowpass(warped[0])
+	case Highpass:
+		analog = proto.highpass(warped[0])
+	case Bandpass:
+		analog = proto.bandpass(math.Sqrt(warped[0]*warped[1]), warped[1]-warped[0])
+	case Bandstop:
+		analog = proto.bandstop(math.Sqrt(warped[0]*warped[1]), warped[1]-warped[0])
+	default:
+		panic(fmt.Errorf("band: %v unknown", band))
+	}
+
+	return analog.bilinear(2)
+}
+
+//excess of poles over zeros of an analog filter, its zeros at infinity.
+func (f *ZPK) degree() int {
+	return len(f.P) - len(f.Z)
+}
+
+//s -> s / wo.
+func (f *ZPK) lowpass(wo float64) *ZPK {
+	return &ZPK{
+		Z: scaleRoots(f.Z, complex(wo, 0)),
+		P: scaleRoots(f.P, complex(wo, 0)),
+		K: f.K * math.Pow(wo, float64(f.degree())),
+	}
+}
+
+//s -> wo / s, the zeros at infinity move to the origin.
+func (f *ZPK) highpass(wo float64) *ZPK {
+	r := &ZPK{K: f.K * real(prodNeg(f.Z)/prodNeg(f.P))}
+	for _, v := range f.Z {
+		r.Z = append(r.Z, complex(wo, 0)/v)
+	}
+	for _, v := range f.P {
+		r.P = append(r.P, complex(wo, 0)/v)
+	}
+	for i := 0; i < f.degree(); i++ {
+		r.Z = append(r.Z, 0)
+	}
+
+	return r
+}
+
+//s -> (s^2 + wo^2) / (s bw), each root splits in two and the zeros at infinity
+//move half to the origin.
+func (f *ZPK) bandpass(wo, bw float64) *ZPK {
+	r := &ZPK{
+		Z: splitRoots(scaleRoots(f.Z, complex(bw/2, 0)), wo),
+		P: splitRoots(scaleRoots(f.P, complex(bw/2, 0)), wo),
+		K: f.K * math.Pow(bw, float64(f.degree())),
+	}
+	for i := 0; i < f.degree(); i++ {
+		r.Z = append(r.Z, 0)
+	}
+
+	return r
+}
+
+//s -> s bw / (s^2 + wo^2), the zeros at infinity move to +-i wo.
+func (f *ZPK) bandstop(wo, bw float64) *ZPK {
+	hz, hp := make([]complex128, len(f.Z)), make([]complex128, len(f.P))
+	for i, v := range f.Z {
+		hz[i] = complex(bw/2, 0) / v
+	}
+	for i, v := range f.P {
+		hp[i] = complex(bw/2, 0) / v
+	}
+	r := &ZPK{Z: splitRoots(hz, wo), P: splitRoots(hp, wo), K: f.K * real(prodNeg(f.Z)/prodNeg(f.P))}
+	for i := 0; i < f.degree(); i++ {
+		r.Z = append(r.Z, complex(0, wo), complex(0, -wo))
+	}
+
+	return r
+}
+
+//s -> 2 fs (z - 1) / (z + 1), the zeros at infinity move to -1.
+func (f *ZPK) bilinear(fs float64) *ZPK {
+	fs2 := complex(2*fs, 0)
+	r := &ZPK{}
+	num, den := complex(1, 0), complex(1, 0)
+	for _, v := range f.Z {
+		r.Z = append(r.Z, (fs2+v)/(fs2-v))
+		num *= fs2 - v
+	}
+	for _, v := range f.P {
+		r.P = append(r.P, (fs2+v)/(fs2-v))
+		den *= fs2 - v
+	}
+	for i := 0; i < f.degree(); i++ {
+		r.Z = append(r.Z, -1)
+	}
+	r.K = f.K * real(num/den)
+
+	return r
+}
+
+func scaleRoots(roots []complex128, s complex128) []complex128 {
+	r := make([]complex128, len(roots))
+	for i, v := range roots {
+		r[i] = v * s
+	}
+
+	return r
+}
+
+//the roots r +- sqrt(r^2 - wo^2) of each r, all + roots first.
+func splitRoots(roots []complex128, wo float64) []complex128 {
+	r := make([]complex128, 2*len(roots))
+	for i, v := range roots {
+		d := cmplx.Sqrt(v*v - complex(wo*wo, 0))
+		r[i] = v + d
+		r[len(roots)+i] = v - d
+	}
+
+	return r
+}
+
+//prod(-roots), 1 for no roots.
+func prodNeg(roots []complex128) complex128 {
+	r := complex(1, 0)
+	for _, v := range roots {
+		r *= -v
+	}
+
+	return r
+}
+
+//real coefficients of prod(z - roots), highest power first.
+func poly(roots []complex128) []float64 {
+	c := []complex128{1}
+	for _, v := range roots {
+		c = append(c, 0)
+		for i := len(c) - 1; i > 0; i-- {
+			c[i] -= v * c[i-1]
+		}
+	}
+	r := make([]float64, len(c))
+	for i, v := range c {
+		r[i] = real(v)
+	}
+
+	return r
+}
+
+func isReal(c complex128) bool {
+	return math.Abs(imag(c)) <= 1e-10*(1+cmplx.Abs(c))
+}
+
+//Remove the root with the smallest dist, among the real ones when realOnly is true and there are some.
+func takeNearest(roots []complex128, realOnly bool, dist func(complex128) float64) (complex128, []complex128) {
+	best := -1
+	for _, filter := range []bool{realOnly, false} {
+		for i, v := range roots {
+			if (!filter || isReal(v)) && (best < 0 || dist(v) < dist(roots[best])) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			break
+		}
+	}
+	v := roots[best]
+
+	return v, append(roots[:best], roots[best+1:]...)
+}
@@ -0,0 +1,131 @@
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//|H| of b(z) / a(z) at the fraction w of the Nyquist frequency.
+func magnitude(b, a *nd.NdArray, w float64) float64 {
+	eval := func(c *nd.NdArray) complex128 {
+		v := complex(0, 0)
+		for k, x := range c.Values() {
+			v += complex(x, 0) * cmplx.Exp(complex(0, -math.Pi*w*float64(k)))
+		}
+		return v
+	}
+
+	return cmplx.Abs(eval(b) / eval(a))
+}
+
+func TestButter(t *testing.T) {
+	b, a := Butter(2, Lowpass, 0.5).TF()
+	if !b.Equals(nd.Array(0.292893219, 0.585786438, 0.292893219)) || !a.Equals(nd.Array(1, 0, 0.171572875)) {
+		t.Error("Expected [0.2929, 0.5858, 0.2929] / [1, 0, 0.1716], got ", b, a)
+	}
+	b, a = Butter(4, Lowpass, 0.2).TF()
+	if !b.Equals(nd.Array(0.004824343, 0.019297373, 0.028946060, 0.019297373, 0.004824343)) ||
+		!a.Equals(nd.Array(1, -2.369513008, 2.313988414, -1.054665405, 0.187379492)) {
+		t.Error("Expected the 4th order Butterworth lowpass at 0.2, got ", b, a)
+	}
+
+	cases := []struct {
+		band   BandType
+		wn     []float64
+		unit   []float64
+		cutoff []float64
+		zero   []float64
+	}{
+		{Lowpass, []float64{0.3}, []float64{0}, []float64{0.3}, []float64{1}},
+		{Highpass, []float64{0.3}, []float64{1}, []float64{0.3}, []float64{0}},
+		{Bandpass, []float64{0.2, 0.5}, []float64{}, []float64{0.2, 0.5}, []float64{0, 1}},
+		{Bandstop, []float64{0.2, 0.5}, []float64{0, 1}, []float64{0.2, 0.5}, []float64{}},
+	}
+	for _, c := range cases {
+		for _, order := range []int{3, 4} {
+			b, a := Butter(order, c.band, c.wn...).TF()
+			if a.Size() != order*len(c.wn)+1 {
+				t.Error("Expected order ", order*len(c.wn), " for band ", c.band, ", got ", a.Size()-1)
+			}
+			for _, w := range c.unit {
+				if m := magnitude(b, a, w); math.Abs(m-1) > 1e-9 {
+					t.Error("Expected a gain of 1 at ", w, " for band ", c.band, ", got ", m)
+				}
+			}
+			for _, w := range c.cutoff {
+				if m := magnitude(b, a, w); math.Abs(m-math.Sqrt(0.5)) > 1e-9 {
+					t.Error("Expected -3 dB at ", w, " for band ", c.band, ", got ", m)
+				}
+			}
+			for _, w := range c.zero {
+				if m := magnitude(b, a, w); m > 1e-6 {
+					t.Error("Expected a gain of 0 at ", w, " for band ", c.band, ", got ", m)
+				}
+			}
+		}
+	}
+}
+
+func TestChebyshev(t *testing.T) {
+	//type I: down by rp at the cutoff, and at 0 for even orders
+	for _, order := range []int{3, 4} {
+		b, a := Cheby1(order, 1, Lowpass, 0.25).TF()
+		ripple := math.Pow(10, -1.0/20)
+		if m := magnitude(b, a, 0.25); math.Abs(m-ripple) > 1e-9 {
+			t.Error("Expected ", ripple, " at the cutoff, got ", m)
+		}
+		dc := 1.0
+		if order%2 == 0 {
+			dc = ripple
+		}
+		if m := magnitude(b, a, 0); math.Abs(m-dc) > 1e-9 {
+			t.Error("Expected ", dc, " at 0, got ", m)
+		}
+	}
+
+	//type II: flat at 0 and down by rs at the stopband edge and beyond
+	for _, order := range []int{3, 4} {
+		b, a := Cheby2(order, 40, Highpass, 0.4).TF()
+		if m := magnitude(b, a, 1); math.Abs(m-1) > 1e-9 {
+			t.Error("Expected 1 at Nyquist, got ", m)
+		}
+		for _, w := range []float64{0, 0.1, 0.2, 0.3, 0.4} {
+			if m := magnitude(b, a, w); m > 0.01+1e-9 {
+				t.Error("Expected at most -40 dB at ", w, ", got ", m)
+			}
+		}
+		if m := magnitude(b, a, 0.4); math.Abs(m-0.01) > 1e-9 {
+			t.Error("Expected -40 dB at the edge, got ", m)
+		}
+	}
+}
+
+func TestSOS(t *testing.T) {
+	for _, f := range []*ZPK{Butter(5, Lowpass, 0.3), Cheby1(4, 0.5, Bandpass, 0.1, 0.4), Cheby2(3, 30, Bandstop, 0.3, 0.6)} {
+		b, a := f.TF()
+		sos := f.SOS()
+		if sos.Shape()[0] != (len(f.P)+1)/2 {
+			t.Error("Expected ", (len(f.P)+1)/2, " sections, got ", sos.Shape()[0])
+		}
+		for _, w := range []float64{0, 0.15, 0.35, 0.5, 0.8} {
+			m := 1.0
+			for s := 0; s < sos.Shape()[0]; s++ {
+				row := sos.Values()[s*6 : s*6+6]
+				m *= magnitude(nd.Array(row[:3]...), nd.Array(row[3:]...), w)
+			}
+			if math.Abs(m-magnitude(b, a, w)) > 1e-9 {
+				t.Error("Expected the sections to give ", magnitude(b, a, w), " at ", w, ", got ", m)
+			}
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a band edge beyond Nyquist")
+		}
+	}()
+	Butter(2, Bandpass, 0.5, 1.2)
+}
@@ -0,0 +1,147 @@
+package signal
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The filters run along the last axis of x, each lane separately, and start from rest unless said otherwise.
+
+//Filter x with the rational transfer function b(z) / a(z), like scipy.signal.lfilter:
+//a[0] y[n] = b[0] x[n] + ... + b[M] x[n-M] - a[1] y[n-1] - ... - a[N] y[n-N].
+func LFilter(b, a, x *nd.NdArray) *nd.NdArray {
+	bs, as := normalizeTF(b, a)
+	return mapLast(x, lastLen(x), func(lane, out []float64) {
+		lfilter(bs, as, lane, out, nil)
+	})
+}
+
+//Zero phase filtering: x is filtered forwards then backwards with LFilter, which squares the magnitude
+//response and cancels the phase. As scipy.signal.filtfilt does, the lanes are extended at both ends by
+//odd reflection of 3 max(len(a), len(b)) elements, which they must be longer than, and each pass starts
+//in the steady state of its first input element.
+func FiltFilt(b, a, x *nd.NdArray) *nd.NdArray {
+	bs, as := normalizeTF(b, a)
+	n := lastLen(x)
+	padLen := 3 * len(as)
+	if n <= padLen {
+		panic(fmt.Errorf("length: %v must be larger than the padding %v", n, padLen))
+	}
+	zi := lfilterZi(bs, as)
+
+	return mapLast(x, n, func(lane, out []float64) {
+		//2 x[0] - x[padLen], ..., 2 x[0] - x[1], x, 2 x[n-1] - x[n-2], ..., 2 x[n-1] - x[n-1-padLen]
+		ext := make([]float64, n+2*padLen)
+		for i := 0; i < padLen; i++ {
+			ext[i] = 2*lane[0] - lane[padLen-i]
+			ext[n+padLen+i] = 2*lane[n-1] - lane[n-2-i]
+		}
+		copy(ext[padLen:], lane)
+
+		y := make([]float64, len(ext))
+		lfilter(bs, as, ext, y, scaled(zi, ext[0]))
+		reverse(y)
+		lfilter(bs, as, y, ext, scaled(zi, y[0]))
+		reverse(ext)
+		copy(out, ext[padLen:padLen+n])
+	})
+}
+
+//Filter x with a cascade of second order sections, which is more accurate than LFilter for high orders.
+//sos has shape [sections, 6], each row being the coefficients b0, b1, b2, a0, a1, a2 of a section.
+func SOSFilt(sos, x *nd.NdArray) *nd.NdArray {
+	if sos.NDims() != 2 || sos.Shape()[1] != 6 {
+		panic("shape error")
+	}
+	sections := make([][2][]float64, sos.Shape()[0])
+	for s := range sections {
+		row := sos.Values()[s*6 : s*6+6]
+		b, a := normalizeTF(nd.Array(row[:3]...), nd.Array(row[3:]...))
+		sections[s] = [2][]float64{b, a}
+	}
+
+	return mapLast(x, lastLen(x), func(lane, out []float64) {
+		copy(out, lane)
+		for _, s := range sections {
+			lfilter(s[0], s[1], out, out, nil)
+		}
+	})
+}
+
+//b and a padded with zeros to the same length and divided by a[0].
+func normalizeTF(b, a *nd.NdArray) ([]float64, []float64) {
+	if b.NDims() != 1 || a.NDims() != 1 || b.Size() == 0 || a.Size() == 0 {
+		panic("shape error")
+	}
+	if a.Get(0) == 0 {
+		panic("a[0] must not be 0")
+	}
+
+	n := b.Size()
+	if a.Size() > n {
+		n = a.Size()
+	}
+	bs, as := make([]float64, n), make([]float64, n)
+	copy(bs, b.Values())
+	copy(as, a.Values())
+	a0 := as[0]
+	for i := range as {
+		bs[i] /= a0
+		as[i] /= a0
+	}
+
+	return bs, as
+}
+
+//Direct form II transposed, with a[0] = 1 and len(b) = len(a). y may be x.
+//zi holds the len(a) - 1 initial delays, nil for rest.
+func lfilter(b, a, x, y, zi []float64) {
+	n := len(a)
+	//z[n-1] stays 0, so that the update needs no special case for the last delay
+	z := make([]float64, n)
+	copy(z, zi)
+	for i, v := range x {
+		out := b[0]*v + z[0]
+		for k := 1; k < n; k++ {
+			z[k-1] = b[k]*v + z[k] - a[k]*out
+		}
+		y[i] = out
+	}
+}
+
+//Delays of lfilter in the steady state of a unit step, like scipy.signal.lfilter_zi:
+//the solution of (I - A.T) zi = b[1:] - a[1:] b[0], with A the companion matrix of a.
+func lfilterZi(b, a []float64) []float64 {
+	n := len(a) - 1
+	if n == 0 {
+		return nil
+	}
+
+	m, rhs := nd.Eye(n), nd.Zeros(n)
+	for i := 0; i < n; i++ {
+		//the first row of A is -a[1:], the subdiagonal is ones
+		m.Set(m.Get(i, 0)+a[i+1], i, 0)
+		if i > 0 {
+			m.Set(m.Get(i-1, i)-1, i-1, i)
+		}
+		rhs.Set(b[i+1]-a[i+1]*b[0], i)
+	}
+
+	return m.Solve(rhs).Values()
+}
+
+func scaled(v []float64, s float64) []float64 {
+	r := make([]float64, len(v))
+	for i, e := range v {
+		r[i] = e * s
+	}
+
+	return r
+}
+
+func reverse(v []float64) {
+	for i, j := 0, len(v)-1; i < j; i, j = i+1, j-1 {
+		v[i], v[j] = v[j], v[i]
+	}
+}
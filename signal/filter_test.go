@@ -0,0 +1,85 @@
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestLFilter(t *testing.T) {
+	impulse := nd.Array(1, 0, 0, 0)
+	if !LFilter(nd.Array(1), nd.Array(1, -0.5), impulse).Equals(nd.Array(1, 0.5, 0.25, 0.125)) {
+		t.Error("Expected [1, 0.5, 0.25, 0.125], got ", LFilter(nd.Array(1), nd.Array(1, -0.5), impulse))
+	}
+	//a[0] scales everything
+	if !LFilter(nd.Array(2, 2), nd.Array(4), nd.Array(1, 2, 3)).Equals(nd.Array(0.5, 1.5, 2.5)) {
+		t.Error("Expected [0.5, 1.5, 2.5], got ", LFilter(nd.Array(2, 2), nd.Array(4), nd.Array(1, 2, 3)))
+	}
+
+	//along the last axis
+	x := nd.Array(1, 2, 3, 4, 5, 6).Reshape(2, 3)
+	if !LFilter(nd.Array(1, -1), nd.Array(1), x).Equals(nd.Array(1, 1, 1, 4, 1, 1).Reshape(2, 3)) {
+		t.Error("Expected [[1, 1, 1], [4, 1, 1]], got ", LFilter(nd.Array(1, -1), nd.Array(1), x))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a[0] = 0")
+		}
+	}()
+	LFilter(nd.Array(1), nd.Array(0, 1), x)
+}
+
+func TestLFilterZi(t *testing.T) {
+	b, a := Butter(3, Lowpass, 0.25).TF()
+	bs, as := normalizeTF(b, a)
+	//starting in the steady state, a step stays at the DC gain of 1
+	y := make([]float64, 20)
+	lfilter(bs, as, nd.Ones(20).Values(), y, lfilterZi(bs, as))
+	if !nd.Array(y...).Equals(nd.Ones(20)) {
+		t.Error("Expected ones, got ", y)
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	b, a := Butter(4, Lowpass, 0.2).TF()
+
+	//a constant passes unchanged
+	c := nd.Ones(50).Map(func(float64) float64 { return 3 })
+	if !FiltFilt(b, a, c).Equals(c) {
+		t.Error("Expected a constant 3, got ", FiltFilt(b, a, c))
+	}
+
+	//a slow sinusoid passes without the delay of LFilter
+	x := nd.Arange(400).Map(func(v float64) float64 { return math.Sin(2 * math.Pi * 0.01 * v) })
+	y := FiltFilt(b, a, x)
+	lagged := LFilter(b, a, x)
+	worst, lagWorst := 0.0, 0.0
+	for i := 50; i < 350; i++ {
+		worst = math.Max(worst, math.Abs(y.Get(i)-x.Get(i)))
+		lagWorst = math.Max(lagWorst, math.Abs(lagged.Get(i)-x.Get(i)))
+	}
+	if worst > 1e-3 || lagWorst < 0.05 {
+		t.Error("Expected filtfilt within 1e-3 and lfilter off by its delay, got ", worst, lagWorst)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for lanes shorter than the padding")
+		}
+	}()
+	FiltFilt(b, a, nd.Ones(15))
+}
+
+func TestSOSFilt(t *testing.T) {
+	g := random.NewGenerator(9)
+	x := g.Normal(0, 1, 3, 200)
+	f := Butter(6, Bandpass, 0.1, 0.3)
+	b, a := f.TF()
+
+	if !SOSFilt(f.SOS(), x).Equals(LFilter(b, a, x)) {
+		t.Error("Expected SOSFilt and LFilter to agree")
+	}
+}
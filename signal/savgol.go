@@ -0,0 +1,73 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Savitzky-Golay filter along the last axis of x, like scipy.signal.savgol_filter with mode "interp":
+//each element is replaced by the deriv-th derivative at its position of the least squares polynomial of
+//degree polyorder through the window of window elements centered on it, for samples spaced by delta.
+//Within window / 2 of the ends, the polynomial through the first or last window is used instead.
+//window must be odd and larger than polyorder, and at most the length of the lanes.
+func Savgol(x *nd.NdArray, window, polyorder, deriv int, delta float64) *nd.NdArray {
+	n := lastLen(x)
+	if window%2 == 0 || window < 1 || window > n {
+		panic(fmt.Errorf("window: %v must be odd and in [1, %v]", window, n))
+	}
+	if polyorder < 0 || polyorder >= window {
+		panic(fmt.Errorf("polyorder: %v must be in [0, %v)", polyorder, window))
+	}
+	if deriv < 0 {
+		panic(fmt.Errorf("deriv: %v must not be negative", deriv))
+	}
+
+	half := window / 2
+	//row i of the pseudo inverse gives the i-th coefficient of the fitted polynomial in t = k - half
+	vander := nd.Zeros(window, polyorder+1)
+	for k := 0; k < window; k++ {
+		for j := 0; j <= polyorder; j++ {
+			vander.Set(math.Pow(float64(k-half), float64(j)), k, j)
+		}
+	}
+	fit := nd.MatMul(vander.T(), vander).Solve(vander.T())
+
+	//the deriv-th derivative at t of the polynomial with coefficients c
+	evaluate := func(c []float64, t float64) float64 {
+		v := 0.0
+		for j := polyorder; j >= deriv; j-- {
+			f := 1.0
+			for i := j - deriv + 1; i <= j; i++ {
+				f *= float64(i)
+			}
+			v = v*t + c[j]*f
+		}
+		return v / math.Pow(delta, float64(deriv))
+	}
+	coefs := func(w []float64) []float64 {
+		return nd.MatMul(fit, nd.Array(w...)).Values()
+	}
+
+	//at t = 0 only the coefficient deriv counts, so the interior is a correlation with weights
+	weights := make([]float64, window)
+	if deriv <= polyorder {
+		for k := range weights {
+			weights[k] = evaluate(fit.NthCol(k).Values(), 0)
+		}
+	}
+
+	return mapLast(x, n, func(lane, out []float64) {
+		for i := half; i < n-half; i++ {
+			for k, w := range weights {
+				out[i] += w * lane[i-half+k]
+			}
+		}
+		first, last := coefs(lane[:window]), coefs(lane[n-window:])
+		for i := 0; i < half; i++ {
+			out[i] = evaluate(first, float64(i-half))
+			out[n-1-i] = evaluate(last, float64(half-i))
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestSavgol(t *testing.T) {
+	x := nd.Array(2, 2, 5, 2, 1, 0, 1, 4, 9)
+
+	expected := nd.Array(1.657142857, 3.171428571, 3.542857143, 2.857142857, 0.657142857, 0.171428571, 1, 4, 9)
+	if !Savgol(x, 5, 2, 0, 1).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", Savgol(x, 5, 2, 0, 1))
+	}
+
+	//derivatives of a quadratic are exact, also at the ends
+	q := nd.Arange(10).Map(func(v float64) float64 { return 0.5 * (v * 0.1) * (v * 0.1) })
+	if !Savgol(q, 5, 2, 1, 0.1).Equals(nd.Arange(10).Map(func(v float64) float64 { return v * 0.1 })) {
+		t.Error("Expected the slope t, got ", Savgol(q, 5, 2, 1, 0.1))
+	}
+	if !Savgol(q, 7, 3, 2, 0.1).Equals(nd.Ones(10)) {
+		t.Error("Expected a second derivative of 1, got ", Savgol(q, 7, 3, 2, 0.1))
+	}
+	if !Savgol(q, 5, 2, 3, 0.1).Equals(nd.Zeros(10)) {
+		t.Error("Expected a third derivative of 0, got ", Savgol(q, 5, 2, 3, 0.1))
+	}
+
+	//lanes along the last axis
+	rows := nd.VStack(x, x.Map(func(v float64) float64 { return 2 * v }))
+	if !Savgol(rows, 5, 2, 0, 1).Equals(nd.VStack(expected, expected.Map(func(v float64) float64 { return 2 * v }))) {
+		t.Error("Expected both rows smoothed, got ", Savgol(rows, 5, 2, 0, 1))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an even window")
+		}
+	}()
+	Savgol(x, 4, 2, 0, 1)
+}
@@ -0,0 +1,140 @@
+package signal
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/fft"
+)
+
+//Remove from every lane along the last axis of x its mean, or its least squares line when linear is true.
+func Detrend(x *nd.NdArray, linear bool) *nd.NdArray {
+	return mapLast(x, lastLen(x), func(lane, out []float64) {
+		detrend(lane, out, linear)
+	})
+}
+
+//Power spectral density of x along its last axis by Welch's method, like scipy.signal.welch with its
+//defaults: the lanes are cut into segments of window.Size() elements overlapping by noverlap,
+//each segment has its mean removed and is multiplied by window, and the one sided periodograms
+//of the segments are averaged. fs is the sampling frequency, the result is in units^2 / Hz.
+//It returns the frequencies, and the densities with the last axis of x replaced by them.
+func Welch(x, window *nd.NdArray, fs float64, noverlap int) (*nd.NdArray, *nd.NdArray) {
+	p := newPeriodogram(lastLen(x), window, fs, noverlap)
+	psd := mapLast(x, p.nfreq, func(lane, out []float64) {
+		for s := 0; s < p.segments; s++ {
+			for k, v := range p.segment(lane, s) {
+				out[k] += v / float64(p.segments)
+			}
+		}
+	})
+
+	return fft.RFFTFreq(p.nperseg, 1/fs), psd
+}
+
+//Spectrogram of x along its last axis, the one sided periodograms of the segments of Welch
+//without averaging. It returns the frequencies, the times of the middles of the segments, and the
+//densities, with the last axis of x replaced by two axes, one for the frequencies and one for the times.
+func Spectrogram(x, window *nd.NdArray, fs float64, noverlap int) (*nd.NdArray, *nd.NdArray, *nd.NdArray) {
+	p := newPeriodogram(lastLen(x), window, fs, noverlap)
+	sxx := mapLast(x, p.nfreq*p.segments, func(lane, out []float64) {
+		for s := 0; s < p.segments; s++ {
+			for k, v := range p.segment(lane, s) {
+				out[k*p.segments+s] = v
+			}
+		}
+	})
+
+	times := nd.Zeros(p.segments)
+	for s := 0; s < p.segments; s++ {
+		times.Set((float64(s*p.step)+float64(p.nperseg)/2)/fs, s)
+	}
+	shape := append([]int(nil), x.Shape()[:x.NDims()-1]...)
+
+	return fft.RFFTFreq(p.nperseg, 1/fs), times, sxx.Reshape(append(shape, p.nfreq, p.segments)...)
+}
+
+//One sided periodograms of the windowed segments of a lane.
+type periodogram struct {
+	window                         []float64
+	nperseg, step, segments, nfreq int
+	//density scale 1 / (fs sum(window^2))
+	scale float64
+	plan  *fft.Plan
+}
+
+func newPeriodogram(n int, window *nd.NdArray, fs float64, noverlap int) *periodogram {
+	if window.NDims() != 1 || window.Size() == 0 || window.Size() > n {
+		panic(fmt.Errorf("window: %v elements must be in [1, %v]", window.Size(), n))
+	}
+	nperseg := window.Size()
+	if noverlap < 0 || noverlap >= nperseg {
+		panic(fmt.Errorf("noverlap: %v must be in [0, %v)", noverlap, nperseg))
+	}
+	if !(fs > 0) {
+		panic(fmt.Errorf("sampling frequency: %v must be positive", fs))
+	}
+
+	p := &periodogram{
+		window:  window.Values(),
+		nperseg: nperseg,
+		step:    nperseg - noverlap,
+		nfreq:   nperseg/2 + 1,
+		plan:    fft.NewPlan(nperseg),
+	}
+	p.segments = (n-nperseg)/p.step + 1
+	sumSq := 0.0
+	for _, w := range p.window {
+		sumSq += w * w
+	}
+	p.scale = 1 / (fs * sumSq)
+
+	return p
+}
+
+//density of segment s of lane at the nfreq non negative frequencies.
+func (p *periodogram) segment(lane []float64, s int) []float64 {
+	seg := make([]float64, p.nperseg)
+	detrend(lane[s*p.step:s*p.step+p.nperseg], seg, false)
+	buf := make([]complex128, p.nperseg)
+	for i, v := range seg {
+		buf[i] = complex(v*p.window[i], 0)
+	}
+	p.plan.Forward(buf, buf)
+
+	density := make([]float64, p.nfreq)
+	for k := range density {
+		v := buf[k]
+		density[k] = (real(v)*real(v) + imag(v)*imag(v)) * p.scale
+		//the negative frequencies fold onto the positive ones, except for 0 and the Nyquist frequency
+		if k > 0 && !(p.nperseg%2 == 0 && k == p.nfreq-1) {
+			density[k] *= 2
+		}
+	}
+
+	return density
+}
+
+//lane minus its mean or its least squares line into out.
+func detrend(lane, out []float64, linear bool) {
+	n := float64(len(lane))
+	mean, tMean := 0.0, (n-1)/2
+	for _, v := range lane {
+		mean += v / n
+	}
+	slope := 0.0
+	if linear {
+		sxy, sxx := 0.0, 0.0
+		for i, v := range lane {
+			t := float64(i) - tMean
+			sxy += t * (v - mean)
+			sxx += t * t
+		}
+		if sxx > 0 {
+			slope = sxy / sxx
+		}
+	}
+	for i, v := range lane {
+		out[i] = v - mean - slope*(float64(i)-tMean)
+	}
+}
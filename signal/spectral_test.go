@@ -0,0 +1,91 @@
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestDetrend(t *testing.T) {
+	x := nd.Array(1, 3, 2, 6)
+	if !Detrend(x, false).Equals(nd.Array(-2, 0, -1, 3)) {
+		t.Error("Expected [-2, 0, -1, 3], got ", Detrend(x, false))
+	}
+	line := nd.Arange(5).Map(func(v float64) float64 { return 3 - 2*v })
+	if !Detrend(line, true).Equals(nd.Zeros(5)) {
+		t.Error("Expected zeros, got ", Detrend(line, true))
+	}
+	if !Detrend(nd.Array(1, 2, 0, 5, 7, 3).Reshape(2, 3), false).Equals(nd.Array(0, 1, -1, 0, 2, -2).Reshape(2, 3)) {
+		t.Error("Expected [[0, 1, -1], [0, 2, -2]], got ", Detrend(nd.Array(1, 2, 0, 5, 7, 3).Reshape(2, 3), false))
+	}
+}
+
+func TestWelch(t *testing.T) {
+	g := random.NewGenerator(6)
+	fs := 100.0
+	//white noise of variance 4 has a one sided density of 2 * 4 / fs
+	noise := g.Normal(0, 2, 20000)
+	f, psd := Welch(noise, Hann(128, false), fs, 64)
+	if f.Size() != 65 || f.Get(64) != 50 || psd.Size() != 65 {
+		t.Error("Expected 65 frequencies up to 50, got ", f.Size(), f.Get(64))
+	}
+	mean := 0.0
+	for k := 1; k < 64; k++ {
+		mean += psd.Get(k) / 63
+	}
+	if math.Abs(mean-0.08) > 0.004 {
+		t.Error("Expected a density of about 0.08, got ", mean)
+	}
+
+	//a sinusoid at 12.5 Hz peaks in its bin, and the density integrates to its power
+	tone := nd.Arange(4096).Map(func(v float64) float64 { return 3 * math.Sin(2*math.Pi*12.5*v/fs) })
+	f, psd = Welch(tone, Hann(256, false), fs, 128)
+	if peak := psd.ArgMax()[0]; f.Get(peak) != 12.5 {
+		t.Error("Expected a peak at 12.5 Hz, got ", f.Get(peak))
+	}
+	if power := psd.SumAll() * (f.Get(1) - f.Get(0)); math.Abs(power-4.5) > 0.05 {
+		t.Error("Expected a power of 4.5, got ", power)
+	}
+}
+
+func TestSpectrogram(t *testing.T) {
+	fs := 8.0
+	//4 Hz in the first half, 1 Hz in the second
+	x := nd.Arange(64).Map(func(v float64) float64 {
+		if v < 32 {
+			return math.Cos(2 * math.Pi * 2 * v / fs)
+		}
+		return math.Cos(2 * math.Pi * 1 * v / fs)
+	})
+
+	f, times, sxx := Spectrogram(x, Hann(16, false), fs, 8)
+	if !f.Equals(nd.Arange(9).Map(func(v float64) float64 { return v / 2 })) {
+		t.Error("Expected frequencies 0, 0.5, ..., 4, got ", f)
+	}
+	if !times.Equals(nd.Array(1, 2, 3, 4, 5, 6, 7)) {
+		t.Error("Expected times 1, ..., 7, got ", times)
+	}
+	if sxx.Shape()[0] != 9 || sxx.Shape()[1] != 7 {
+		t.Error("Expected shape [9 7], got ", sxx.Shape())
+	}
+	if sxx.NthCol(0).ArgMax()[0] != 4 || sxx.NthCol(6).ArgMax()[0] != 2 {
+		t.Error("Expected peaks at 2 Hz then 1 Hz, got ", sxx.NthCol(0).ArgMax(), sxx.NthCol(6).ArgMax())
+	}
+
+	//the average over time is Welch
+	_, psd := Welch(x, Hann(16, false), fs, 8)
+	for k := 0; k < 9; k++ {
+		if math.Abs(sxx.NthRow(k).SumAll()/7-psd.Get(k)) > 1e-12 {
+			t.Error("Expected the mean of row ", k, " to be ", psd.Get(k))
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for noverlap as large as the window")
+		}
+	}()
+	Spectrogram(x, Hann(16, false), fs, 16)
+}
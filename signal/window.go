@@ -0,0 +1,82 @@
+package signal
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The windows have n points. Symmetric windows, with sym true, suit filter design. Periodic windows,
+//with sym false, are the first n points of the symmetric window of n + 1 points and suit spectral analysis,
+//as in Welch and Spectrogram.
+
+//Hann window 0.5 - 0.5 cos(2 Pi k / (n - 1)).
+func Hann(n int, sym bool) *nd.NdArray {
+	return cosineWindow(n, sym, 0.5, 0.5)
+}
+
+//Hamming window 0.54 - 0.46 cos(2 Pi k / (n - 1)).
+func Hamming(n int, sym bool) *nd.NdArray {
+	return cosineWindow(n, sym, 0.54, 0.46)
+}
+
+//Blackman window 0.42 - 0.5 cos(2 Pi k / (n - 1)) + 0.08 cos(4 Pi k / (n - 1)).
+func Blackman(n int, sym bool) *nd.NdArray {
+	return cosineWindow(n, sym, 0.42, 0.5, 0.08)
+}
+
+//Kaiser window I0(beta sqrt(1 - (2 k / (n - 1) - 1)^2)) / I0(beta), beta trades the main lobe width
+//for the side lobe level.
+func Kaiser(n int, beta float64, sym bool) *nd.NdArray {
+	return window(n, sym, func(k, m int) float64 {
+		x := 2*float64(k)/float64(m-1) - 1
+		return besselI0(beta*math.Sqrt(math.Max(0, 1-x*x))) / besselI0(beta)
+	})
+}
+
+//sum of (-1)^j coefs[j] cos(2 Pi j k / (m - 1)).
+func cosineWindow(n int, sym bool, coefs ...float64) *nd.NdArray {
+	return window(n, sym, func(k, m int) float64 {
+		v, sign := 0.0, 1.0
+		for j, c := range coefs {
+			v += sign * c * math.Cos(2*math.Pi*float64(j*k)/float64(m-1))
+			sign = -sign
+		}
+		return v
+	})
+}
+
+//the n points of a window given by f(k, m) on the m points of the symmetric window.
+func window(n int, sym bool, f func(k, m int) float64) *nd.NdArray {
+	if n < 1 {
+		panic(fmt.Errorf("length: %v must be positive", n))
+	}
+	if n == 1 {
+		return nd.Ones(1)
+	}
+	m := n
+	if !sym {
+		m++
+	}
+
+	w := nd.Zeros(n)
+	ws := w.Values()
+	for k := range ws {
+		ws[k] = f(k, m)
+	}
+
+	return w
+}
+
+//Modified Bessel function of the first kind of order 0, from its power series sum ((x / 2)^k / k!)^2.
+func besselI0(x float64) float64 {
+	term, sum := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; term > 1e-17*sum; k++ {
+		term *= q / float64(k*k)
+		sum += term
+	}
+
+	return sum
+}
@@ -0,0 +1,39 @@
+package signal
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestWindows(t *testing.T) {
+	if !Hann(5, true).Equals(nd.Array(0, 0.5, 1, 0.5, 0)) {
+		t.Error("Expected [0, 0.5, 1, 0.5, 0], got ", Hann(5, true))
+	}
+	if !Hann(4, false).Equals(nd.Array(0, 0.5, 1, 0.5)) {
+		t.Error("Expected [0, 0.5, 1, 0.5], got ", Hann(4, false))
+	}
+	if !Hamming(5, true).Equals(nd.Array(0.08, 0.54, 1, 0.54, 0.08)) {
+		t.Error("Expected [0.08, 0.54, 1, 0.54, 0.08], got ", Hamming(5, true))
+	}
+	if !Blackman(5, true).Equals(nd.Array(0, 0.34, 1, 0.34, 0)) {
+		t.Error("Expected [0, 0.34, 1, 0.34, 0], got ", Blackman(5, true))
+	}
+	kaiser := nd.Array(0.001332514, 0.340393622, 1, 0.340393622, 0.001332514)
+	if !Kaiser(5, 8.6, true).Equals(kaiser) {
+		t.Error("Expected ", kaiser, ", got ", Kaiser(5, 8.6, true))
+	}
+	if !Kaiser(6, 8.6, false).Equals(nd.Array(0.001332514, 0.130401947, 0.630411927, 1, 0.630411927, 0.130401947)) {
+		t.Error("Expected the first 6 points of the 7 point window, got ", Kaiser(6, 8.6, false))
+	}
+	if !Kaiser(4, 0, true).Equals(nd.Ones(4)) || !Hann(1, true).Equals(nd.Ones(1)) {
+		t.Error("Expected windows of ones, got ", Kaiser(4, 0, true), Hann(1, true))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for an empty window")
+		}
+	}()
+	Hann(0, true)
+}
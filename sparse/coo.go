@@ -0,0 +1,150 @@
+package sparse
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Sparse matrix in coordinate format, elements are kept as (row, col, value) triplets.
+//It is cheap to build incrementally, duplicated entries are summed on conversion.
+type COO struct {
+	rows, cols int
+	row        []int
+	col        []int
+	data       []float64
+}
+
+func NewCOO(rows, cols int) *COO {
+	if rows < 0 || cols < 0 {
+		panic(fmt.Errorf("shape: [%v %v] < 0", rows, cols))
+	}
+	return &COO{
+		rows: rows,
+		cols: cols,
+	}
+}
+
+//Build a COO matrix from triplets, row, col and data must have the same length.
+func NewCOOFromTriplets(rows, cols int, row, col []int, data []float64) *COO {
+	if len(row) != len(col) || len(row) != len(data) {
+		panic("shape error")
+	}
+	m := NewCOO(rows, cols)
+	for k := range data {
+		m.Set(data[k], row[k], col[k])
+	}
+
+	return m
+}
+
+//Add v at (i, j), values already at (i, j) are not overwritten but accumulated.
+func (m *COO) Set(v float64, i, j int) {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("index out of range")
+	}
+	m.row = append(m.row, i)
+	m.col = append(m.col, j)
+	m.data = append(m.data, v)
+}
+
+func (m *COO) Shape() []int {
+	return []int{m.rows, m.cols}
+}
+
+//Number of stored entries, duplicates included.
+func (m *COO) NNZ() int {
+	return len(m.data)
+}
+
+func (m *COO) ToCSR() *CSR {
+	indptr, indices, data := compress(m.rows, m.row, m.col, m.data)
+	return &CSR{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+func (m *COO) ToCSC() *CSC {
+	indptr, indices, data := compress(m.cols, m.col, m.row, m.data)
+	return &CSC{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+func (m *COO) ToDense() *nd.NdArray {
+	tn := nd.Zeros(m.rows, m.cols)
+	values := tn.Values()
+	for k, v := range m.data {
+		values[m.row[k]*m.cols+m.col[k]] += v
+	}
+
+	return tn
+}
+
+//compress triplets by major index, minor indices are sorted and duplicates summed.
+func compress(n int, major, minor []int, values []float64) ([]int, []int, []float64) {
+	indptr := make([]int, n+1)
+	for _, i := range major {
+		indptr[i+1]++
+	}
+	for i := 0; i < n; i++ {
+		indptr[i+1] += indptr[i]
+	}
+
+	next := make([]int, n)
+	copy(next, indptr[:n])
+	indices := make([]int, len(values))
+	data := make([]float64, len(values))
+	for k, i := range major {
+		indices[next[i]] = minor[k]
+		data[next[i]] = values[k]
+		next[i]++
+	}
+
+	//sort each line and merge duplicated entries in place
+	outIndptr := make([]int, n+1)
+	nnz := 0
+	for i := 0; i < n; i++ {
+		line := lineSorter{indices[indptr[i]:indptr[i+1]], data[indptr[i]:indptr[i+1]]}
+		sort.Sort(line)
+		for k := range line.indices {
+			if k > 0 && line.indices[k] == indices[nnz-1] {
+				data[nnz-1] += line.data[k]
+				continue
+			}
+			indices[nnz] = line.indices[k]
+			data[nnz] = line.data[k]
+			nnz++
+		}
+		outIndptr[i+1] = nnz
+	}
+
+	return outIndptr, indices[:nnz], data[:nnz]
+}
+
+type lineSorter struct {
+	indices []int
+	data    []float64
+}
+
+func (s lineSorter) Len() int {
+	return len(s.indices)
+}
+
+func (s lineSorter) Less(i, j int) bool {
+	return s.indices[i] < s.indices[j]
+}
+
+func (s lineSorter) Swap(i, j int) {
+	s.indices[i], s.indices[j] = s.indices[j], s.indices[i]
+	s.data[i], s.data[j] = s.data[j], s.data[i]
+}
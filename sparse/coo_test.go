@@ -0,0 +1,51 @@
+package sparse
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestCOOToDense(t *testing.T) {
+	m := NewCOOFromTriplets(2, 3, []int{0, 1, 0, 1}, []int{2, 0, 2, 1}, []float64{1, 2, 3, 4})
+
+	if m.NNZ() != 4 {
+		t.Error("Expected 4, got ", m.NNZ())
+	}
+
+	if !m.ToDense().Equals(nd.Array(0, 0, 4, 2, 4, 0).Reshape(2, 3)) {
+		t.Error("Expected [[0,0,4],[2,4,0]], got ", m.ToDense())
+	}
+}
+
+func TestCOOToCSR(t *testing.T) {
+	m := NewCOO(3, 3)
+	m.Set(1, 2, 2)
+	m.Set(2, 0, 1)
+	m.Set(3, 2, 0)
+	m.Set(4, 0, 1)
+
+	csr := m.ToCSR()
+	if csr.NNZ() != 3 {
+		t.Error("Expected 3, got ", csr.NNZ())
+	}
+	if !csr.ToDense().Equals(m.ToDense()) {
+		t.Error("Expected ", m.ToDense(), ", got ", csr.ToDense())
+	}
+
+	csc := m.ToCSC()
+	if csc.NNZ() != 3 {
+		t.Error("Expected 3, got ", csc.NNZ())
+	}
+	if !csc.ToDense().Equals(m.ToDense()) {
+		t.Error("Expected ", m.ToDense(), ", got ", csc.ToDense())
+	}
+
+	defer func() {
+		p := recover()
+		if p != "index out of range" {
+			t.Error("Expected 'index out of range', got ", p)
+		}
+	}()
+	m.Set(1, 3, 0)
+}
@@ -0,0 +1,123 @@
+package sparse
+
+import (
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Compressed sparse column matrix.
+//The row indices of column j are indices[indptr[j]:indptr[j+1]], sorted ascending,
+//and their values are data[indptr[j]:indptr[j+1]].
+type CSC struct {
+	rows, cols int
+	indptr     []int
+	indices    []int
+	data       []float64
+}
+
+func (m *CSC) Shape() []int {
+	return []int{m.rows, m.cols}
+}
+
+//Number of stored elements.
+func (m *CSC) NNZ() int {
+	return len(m.data)
+}
+
+func (m *CSC) Get(i, j int) float64 {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("index out of range")
+	}
+	start, end := m.indptr[j], m.indptr[j+1]
+	k := start + sort.SearchInts(m.indices[start:end], i)
+	if k < end && m.indices[k] == i {
+		return m.data[k]
+	}
+
+	return 0
+}
+
+func (m *CSC) ToDense() *nd.NdArray {
+	tn := nd.Zeros(m.rows, m.cols)
+	values := tn.Values()
+	for j := 0; j < m.cols; j++ {
+		for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+			values[m.indices[k]*m.cols+j] = m.data[k]
+		}
+	}
+
+	return tn
+}
+
+func (m *CSC) ToCSR() *CSR {
+	indptr, indices, data := transposeCompressed(m.cols, m.rows, m.indptr, m.indices, m.data)
+	return &CSR{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+func (m *CSC) T() *CSC {
+	indptr, indices, data := transposeCompressed(m.cols, m.rows, m.indptr, m.indices, m.data)
+	return &CSC{
+		rows:    m.cols,
+		cols:    m.rows,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+//Return the jth column as a dense 1d array.
+func (m *CSC) NthCol(j int) *nd.NdArray {
+	if j < 0 || j >= m.cols {
+		panic("index out of range")
+	}
+	tn := nd.Zeros(m.rows)
+	values := tn.Values()
+	for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+		values[m.indices[k]] = m.data[k]
+	}
+
+	return tn
+}
+
+//Sparse-dense product.
+//if that's shape is [n], a 1d array of shape [m] is returned;
+//if that's shape is [n, k], a matrix of shape [m, k] is returned.
+func (m *CSC) Dot(that *nd.NdArray) *nd.NdArray {
+	if that.NDims() == 1 && that.Shape()[0] == m.cols {
+		tn := nd.Zeros(m.rows)
+		x, y := that.Values(), tn.Values()
+		for j := 0; j < m.cols; j++ {
+			for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+				y[m.indices[k]] += m.data[k] * x[j]
+			}
+		}
+
+		return tn
+	}
+
+	if that.NDims() == 2 && that.Shape()[0] == m.cols {
+		n := that.Shape()[1]
+		tn := nd.Zeros(m.rows, n)
+		x, y := that.Values(), tn.Values()
+		for j := 0; j < m.cols; j++ {
+			row := x[j*n : (j+1)*n]
+			for k := m.indptr[j]; k < m.indptr[j+1]; k++ {
+				v, i := m.data[k], m.indices[k]
+				for c, w := range row {
+					y[i*n+c] += v * w
+				}
+			}
+		}
+
+		return tn
+	}
+
+	panic("shape error")
+}
@@ -0,0 +1,38 @@
+package sparse
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestCSCGet(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	m := FromDense(a).ToCSC()
+
+	if m.Get(0, 2) != 2 || m.Get(1, 0) != 0 {
+		t.Error("Expected 2 and 0, got ", m.Get(0, 2), m.Get(1, 0))
+	}
+	if !m.NthCol(2).Equals(a.NthCol(2)) {
+		t.Error("Expected ", a.NthCol(2), ", got ", m.NthCol(2))
+	}
+	if !m.ToCSR().ToDense().Equals(a) {
+		t.Error("Expected ", a, ", got ", m.ToCSR().ToDense())
+	}
+	if !m.T().ToDense().Equals(a.T()) {
+		t.Error("Expected ", a.T(), ", got ", m.T().ToDense())
+	}
+}
+
+func TestCSCDot(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	b := nd.Arange(6).Reshape(3, 2)
+	m := FromDense(a).ToCSC()
+
+	if !m.Dot(b).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", m.Dot(b))
+	}
+	if !m.Dot(nd.Array(1, 2, 3)).Equals(nd.Array(7, 9)) {
+		t.Error("Expected [7, 9], got ", m.Dot(nd.Array(1, 2, 3)))
+	}
+}
@@ -0,0 +1,361 @@
+package sparse
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Compressed sparse row matrix.
+//The column indices of row i are indices[indptr[i]:indptr[i+1]], sorted ascending,
+//and their values are data[indptr[i]:indptr[i+1]].
+type CSR struct {
+	rows, cols int
+	indptr     []int
+	indices    []int
+	data       []float64
+}
+
+//Convert a dense ndarray to CSR, every element != 0 is kept.
+//A 1d array of shape [n] is treated as a matrix of shape [1, n].
+func FromDense(a *nd.NdArray) *CSR {
+	if a.NDims() == 1 {
+		a = a.Reshape(1, a.Shape()[0])
+	}
+	if a.NDims() != 2 {
+		panic("shape error")
+	}
+
+	rows, cols := a.Shape()[0], a.Shape()[1]
+	m := &CSR{rows: rows, cols: cols, indptr: make([]int, rows+1)}
+	values := a.Values()
+	for i := 0; i < rows; i++ {
+		for j, v := range values[i*cols : (i+1)*cols] {
+			if v != 0 {
+				m.indices = append(m.indices, j)
+				m.data = append(m.data, v)
+			}
+		}
+		m.indptr[i+1] = len(m.data)
+	}
+
+	return m
+}
+
+func (m *CSR) Shape() []int {
+	return []int{m.rows, m.cols}
+}
+
+//Number of stored elements.
+func (m *CSR) NNZ() int {
+	return len(m.data)
+}
+
+func (m *CSR) Get(i, j int) float64 {
+	if i < 0 || i >= m.rows || j < 0 || j >= m.cols {
+		panic("index out of range")
+	}
+	start, end := m.indptr[i], m.indptr[i+1]
+	k := start + sort.SearchInts(m.indices[start:end], j)
+	if k < end && m.indices[k] == j {
+		return m.data[k]
+	}
+
+	return 0
+}
+
+func (m *CSR) ToDense() *nd.NdArray {
+	tn := nd.Zeros(m.rows, m.cols)
+	values := tn.Values()
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			values[i*m.cols+m.indices[k]] = m.data[k]
+		}
+	}
+
+	return tn
+}
+
+func (m *CSR) ToCOO() *COO {
+	coo := NewCOO(m.rows, m.cols)
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			coo.Set(m.data[k], i, m.indices[k])
+		}
+	}
+
+	return coo
+}
+
+func (m *CSR) ToCSC() *CSC {
+	indptr, indices, data := transposeCompressed(m.rows, m.cols, m.indptr, m.indices, m.data)
+	return &CSC{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+func (m *CSR) Clone() *CSR {
+	return &CSR{
+		rows:    m.rows,
+		cols:    m.cols,
+		indptr:  append([]int{}, m.indptr...),
+		indices: append([]int{}, m.indices...),
+		data:    append([]float64{}, m.data...),
+	}
+}
+
+func (m *CSR) T() *CSR {
+	indptr, indices, data := transposeCompressed(m.rows, m.cols, m.indptr, m.indices, m.data)
+	return &CSR{
+		rows:    m.cols,
+		cols:    m.rows,
+		indptr:  indptr,
+		indices: indices,
+		data:    data,
+	}
+}
+
+//Select the rows specified by is, forming a new CSR matrix.
+func (m *CSR) GetRows(is ...int) *CSR {
+	tn := &CSR{
+		rows:   len(is),
+		cols:   m.cols,
+		indptr: make([]int, len(is)+1),
+	}
+	for r, i := range is {
+		if i < 0 || i >= m.rows {
+			panic("index out of range")
+		}
+		tn.indices = append(tn.indices, m.indices[m.indptr[i]:m.indptr[i+1]]...)
+		tn.data = append(tn.data, m.data[m.indptr[i]:m.indptr[i+1]]...)
+		tn.indptr[r+1] = len(tn.data)
+	}
+
+	return tn
+}
+
+//Rows in [start, end), forming a new CSR matrix.
+func (m *CSR) RowSlice(start, end int) *CSR {
+	if start < 0 || end > m.rows || start > end {
+		panic(fmt.Errorf("row slice [%v:%v] out of range [0:%v]", start, end, m.rows))
+	}
+	is := make([]int, end-start)
+	for i := range is {
+		is[i] = start + i
+	}
+
+	return m.GetRows(is...)
+}
+
+//Return the ith row as a dense 1d array.
+func (m *CSR) NthRow(i int) *nd.NdArray {
+	if i < 0 || i >= m.rows {
+		panic("index out of range")
+	}
+	tn := nd.Zeros(m.cols)
+	values := tn.Values()
+	for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+		values[m.indices[k]] = m.data[k]
+	}
+
+	return tn
+}
+
+//Sparse-dense product.
+//if that's shape is [n], a 1d array of shape [m] is returned;
+//if that's shape is [n, k], a matrix of shape [m, k] is returned.
+func (m *CSR) Dot(that *nd.NdArray) *nd.NdArray {
+	if that.NDims() == 1 && that.Shape()[0] == m.cols {
+		tn := nd.Zeros(m.rows)
+		x, y := that.Values(), tn.Values()
+		for i := 0; i < m.rows; i++ {
+			sum := 0.0
+			for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+				sum += m.data[k] * x[m.indices[k]]
+			}
+			y[i] = sum
+		}
+
+		return tn
+	}
+
+	if that.NDims() == 2 && that.Shape()[0] == m.cols {
+		n := that.Shape()[1]
+		tn := nd.Zeros(m.rows, n)
+		x, y := that.Values(), tn.Values()
+		for i := 0; i < m.rows; i++ {
+			for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+				v, row := m.data[k], x[m.indices[k]*n:(m.indices[k]+1)*n]
+				for j, w := range row {
+					y[i*n+j] += v * w
+				}
+			}
+		}
+
+		return tn
+	}
+
+	panic("shape error")
+}
+
+//Sparse-sparse product, the result is also sparse.
+func (m *CSR) DotSparse(that *CSR) *CSR {
+	if m.cols != that.rows {
+		panic("shape error")
+	}
+
+	tn := &CSR{
+		rows:   m.rows,
+		cols:   that.cols,
+		indptr: make([]int, m.rows+1),
+	}
+	//dense accumulator of one output row, marker tells whether a column is already touched
+	acc := make([]float64, that.cols)
+	marker := make([]int, that.cols)
+	for j := range marker {
+		marker[j] = -1
+	}
+	for i := 0; i < m.rows; i++ {
+		start := len(tn.indices)
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			v, r := m.data[k], m.indices[k]
+			for l := that.indptr[r]; l < that.indptr[r+1]; l++ {
+				j := that.indices[l]
+				if marker[j] != i {
+					marker[j] = i
+					acc[j] = 0
+					tn.indices = append(tn.indices, j)
+				}
+				acc[j] += v * that.data[l]
+			}
+		}
+		sort.Ints(tn.indices[start:])
+		for _, j := range tn.indices[start:] {
+			tn.data = append(tn.data, acc[j])
+		}
+		tn.indptr[i+1] = len(tn.indices)
+	}
+
+	return tn
+}
+
+//Elementwise addition of two sparse matrices with the same shape.
+func (m *CSR) Add(that *CSR) *CSR {
+	return m.binaryOp(that, func(a, b float64) float64 { return a + b }, true)
+}
+
+//Elementwise subtraction of two sparse matrices with the same shape.
+func (m *CSR) Sub(that *CSR) *CSR {
+	return m.binaryOp(that, func(a, b float64) float64 { return a - b }, true)
+}
+
+//Elementwise multiplication of two sparse matrices with the same shape,
+//only positions stored in both matrices can be non-zero.
+func (m *CSR) MulBit(that *CSR) *CSR {
+	return m.binaryOp(that, func(a, b float64) float64 { return a * b }, false)
+}
+
+//Multiply all elements by v.
+func (m *CSR) Scale(v float64) *CSR {
+	tn := m.Clone()
+	for k := range tn.data {
+		tn.data[k] *= v
+	}
+
+	return tn
+}
+
+//Apply f to the stored elements only, f is assumed to map 0 to 0.
+func (m *CSR) Map(f func(e float64) float64) *CSR {
+	tn := m.Clone()
+	for k := range tn.data {
+		tn.data[k] = f(tn.data[k])
+	}
+
+	return tn
+}
+
+//Sum of all elements.
+func (m *CSR) SumAll() float64 {
+	sum := 0.0
+	for _, v := range m.data {
+		sum += v
+	}
+
+	return sum
+}
+
+//merge the rows of m and that with op, union chooses between union and intersection of the patterns.
+func (m *CSR) binaryOp(that *CSR, op func(a, b float64) float64, union bool) *CSR {
+	if m.rows != that.rows || m.cols != that.cols {
+		panic("shape error")
+	}
+
+	tn := &CSR{
+		rows:   m.rows,
+		cols:   m.cols,
+		indptr: make([]int, m.rows+1),
+	}
+	push := func(j int, v float64) {
+		if v != 0 {
+			tn.indices = append(tn.indices, j)
+			tn.data = append(tn.data, v)
+		}
+	}
+	for i := 0; i < m.rows; i++ {
+		p, pEnd := m.indptr[i], m.indptr[i+1]
+		q, qEnd := that.indptr[i], that.indptr[i+1]
+		for p < pEnd || q < qEnd {
+			switch {
+			case q >= qEnd || (p < pEnd && m.indices[p] < that.indices[q]):
+				if union {
+					push(m.indices[p], op(m.data[p], 0))
+				}
+				p++
+			case p >= pEnd || that.indices[q] < m.indices[p]:
+				if union {
+					push(that.indices[q], op(0, that.data[q]))
+				}
+				q++
+			default:
+				push(m.indices[p], op(m.data[p], that.data[q]))
+				p++
+				q++
+			}
+		}
+		tn.indptr[i+1] = len(tn.data)
+	}
+
+	return tn
+}
+
+//transpose a compressed structure of n major lines and k minor lines.
+func transposeCompressed(n, k int, indptr, indices []int, data []float64) ([]int, []int, []float64) {
+	tIndptr := make([]int, k+1)
+	for _, j := range indices {
+		tIndptr[j+1]++
+	}
+	for j := 0; j < k; j++ {
+		tIndptr[j+1] += tIndptr[j]
+	}
+
+	next := make([]int, k)
+	copy(next, tIndptr[:k])
+	tIndices := make([]int, len(indices))
+	tData := make([]float64, len(data))
+	for i := 0; i < n; i++ {
+		for p := indptr[i]; p < indptr[i+1]; p++ {
+			j := indices[p]
+			tIndices[next[j]] = i
+			tData[next[j]] = data[p]
+			next[j]++
+		}
+	}
+
+	return tIndptr, tIndices, tData
+}
@@ -0,0 +1,121 @@
+package sparse
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestFromDense(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	m := FromDense(a)
+
+	if m.NNZ() != 3 {
+		t.Error("Expected 3, got ", m.NNZ())
+	}
+	if m.Get(0, 2) != 2 || m.Get(1, 1) != 0 {
+		t.Error("Expected 2 and 0, got ", m.Get(0, 2), m.Get(1, 1))
+	}
+	if !m.ToDense().Equals(a) {
+		t.Error("Expected ", a, ", got ", m.ToDense())
+	}
+
+	v := FromDense(nd.Array(0, 5, 0))
+	if v.Shape()[0] != 1 || v.Shape()[1] != 3 || v.NNZ() != 1 {
+		t.Error("Expected [1 3] with 1 element, got ", v.Shape(), v.NNZ())
+	}
+
+	//tiny values are kept
+	small := nd.Array(1e-9, 0, 0, -1e-6).Reshape(2, 2)
+	if s := FromDense(small); s.NNZ() != 2 || s.Get(0, 0) != 1e-9 || s.Get(1, 1) != -1e-6 {
+		t.Error("Expected 1e-9 and -1e-6 kept, got ", s.ToDense())
+	}
+}
+
+func TestCSRT(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	m := FromDense(a)
+
+	if !m.T().ToDense().Equals(a.T()) {
+		t.Error("Expected ", a.T(), ", got ", m.T().ToDense())
+	}
+	if !m.ToCSC().ToDense().Equals(a) {
+		t.Error("Expected ", a, ", got ", m.ToCSC().ToDense())
+	}
+	if !m.ToCOO().ToDense().Equals(a) {
+		t.Error("Expected ", a, ", got ", m.ToCOO().ToDense())
+	}
+}
+
+func TestCSRGetRows(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3, 4, 5, 0).Reshape(3, 3)
+	m := FromDense(a)
+
+	if !m.GetRows(2, 0).ToDense().Equals(a.GetRows(2, 0)) {
+		t.Error("Expected ", a.GetRows(2, 0), ", got ", m.GetRows(2, 0).ToDense())
+	}
+	if !m.RowSlice(1, 3).ToDense().Equals(a.GetRows(1, 2)) {
+		t.Error("Expected ", a.GetRows(1, 2), ", got ", m.RowSlice(1, 3).ToDense())
+	}
+	if !m.NthRow(2).Equals(a.NthRow(2)) {
+		t.Error("Expected ", a.NthRow(2), ", got ", m.NthRow(2))
+	}
+}
+
+func TestCSRDot(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	b := nd.Arange(6).Reshape(3, 2)
+	m := FromDense(a)
+
+	if !m.Dot(b).Equals(a.Dot(b)) {
+		t.Error("Expected ", a.Dot(b), ", got ", m.Dot(b))
+	}
+
+	v := nd.Array(1, 2, 3)
+	if !m.Dot(v).Equals(nd.Array(7, 9)) {
+		t.Error("Expected [7, 9], got ", m.Dot(v))
+	}
+
+	s := FromDense(b).DotSparse(FromDense(a))
+	if !s.ToDense().Equals(b.Dot(a)) {
+		t.Error("Expected ", b.Dot(a), ", got ", s.ToDense())
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	m.Dot(nd.Array(1, 2))
+}
+
+func TestCSRElementwise(t *testing.T) {
+	a := nd.Array(1, 0, 2, 0, 0, 3).Reshape(2, 3)
+	b := nd.Array(0, 1, 2, 0, 4, 0).Reshape(2, 3)
+	ma, mb := FromDense(a), FromDense(b)
+
+	sum := ma.Add(mb)
+	if !sum.ToDense().Equals(a.Add(b)) {
+		t.Error("Expected ", a.Add(b), ", got ", sum.ToDense())
+	}
+
+	diff := ma.Sub(mb)
+	if diff.NNZ() != 4 || !diff.ToDense().Equals(a.Sub(b)) {
+		t.Error("Expected ", a.Sub(b), ", got ", diff.ToDense())
+	}
+
+	prod := ma.MulBit(mb)
+	if prod.NNZ() != 1 || !prod.ToDense().Equals(a.MulBit(b)) {
+		t.Error("Expected ", a.MulBit(b), ", got ", prod.ToDense())
+	}
+
+	if !ma.Scale(2).ToDense().Equals(a.Mul(nd.Array(2))) {
+		t.Error("Expected ", a.Mul(nd.Array(2)), ", got ", ma.Scale(2).ToDense())
+	}
+
+	sq := ma.Map(func(e float64) float64 { return e * e })
+	if sq.NNZ() != 3 || sq.SumAll() != 14 {
+		t.Error("Expected 3 elements summing to 14, got ", sq.NNZ(), sq.SumAll())
+	}
+}
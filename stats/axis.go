@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"fmt"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//Pass a negative axis to reduce over all elements of the array.
+const AllAxes = -1
+
+//apply f to every lane of a along axis, f writes nout results to out and may reorder lane.
+//The result has the shape of a without axis, prepended by nout when nout > 1.
+//When axis < 0 the whole flattened array is one lane. An empty result shape becomes [1].
+func alongAxis(a *nd.NdArray, axis int, nout int, f func(lane []float64, out []float64)) *nd.NdArray {
+	return alongAxisOf([]*nd.NdArray{a}, axis, nout, func(lanes [][]float64, out []float64) {
+		f(lanes[0], out)
+	})
+}
+
+//like alongAxis, but f gets the matching lanes of several arrays with the same shape.
+func alongAxisOf(arrays []*nd.NdArray, axis int, nout int, f func(lanes [][]float64, out []float64)) *nd.NdArray {
+	shape := arrays[0].Shape()
+	for _, b := range arrays[1:] {
+		if !util.EqualOfIntSlice(shape, b.Shape()) {
+			panic("shape error")
+		}
+	}
+	if axis >= len(shape) {
+		panic(fmt.Errorf("axis: %v out of range for shape %v", axis, shape))
+	}
+
+	var rest []int
+	outer, n, inner := 1, util.ProductOfIntSlice(shape), 1
+	if axis >= 0 {
+		rest = append(append(rest, shape[:axis]...), shape[axis+1:]...)
+		outer = util.ProductOfIntSlice(shape[:axis])
+		n = shape[axis]
+		inner = util.ProductOfIntSlice(shape[axis+1:])
+	}
+
+	count := outer * inner
+	res := make([]float64, nout*count)
+	lanes := make([][]float64, len(arrays))
+	for k := range lanes {
+		lanes[k] = make([]float64, n)
+	}
+	out := make([]float64, nout)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for k, b := range arrays {
+				values := b.Values()
+				for j := range lanes[k] {
+					lanes[k][j] = values[(o*n+j)*inner+i]
+				}
+			}
+			f(lanes, out)
+			for q, v := range out {
+				res[q*count+o*inner+i] = v
+			}
+		}
+	}
+
+	resShape := rest
+	if nout > 1 {
+		resShape = append([]int{nout}, rest...)
+	}
+	if len(resShape) == 0 {
+		resShape = []int{1}
+	}
+
+	return nd.Array(res...).Reshape(resShape...)
+}
+
+//apply f to every lane of a along axis, f writes one result per element of lane to out.
+//The result has the shape of a. When axis < 0 the whole flattened array is one lane.
+func mapAlongAxis(a *nd.NdArray, axis int, f func(lane []float64, out []float64)) *nd.NdArray {
+	shape := a.Shape()
+	if axis >= len(shape) {
+		panic(fmt.Errorf("axis: %v out of range for shape %v", axis, shape))
+	}
+
+	outer, n, inner := 1, util.ProductOfIntSlice(shape), 1
+	if axis >= 0 {
+		outer = util.ProductOfIntSlice(shape[:axis])
+		n = shape[axis]
+		inner = util.ProductOfIntSlice(shape[axis+1:])
+	}
+
+	values := a.Values()
+	res := make([]float64, len(values))
+	lane, out := make([]float64, n), make([]float64, n)
+	for o := 0; o < outer; o++ {
+		for i := 0; i < inner; i++ {
+			for j := range lane {
+				lane[j] = values[(o*n+j)*inner+i]
+			}
+			f(lane, out)
+			for j, v := range out {
+				res[(o*n+j)*inner+i] = v
+			}
+		}
+	}
+
+	return nd.Array(res...).Reshape(shape...)
+}
@@ -73,31 +73,7 @@ func Sum(a *nd.NdArray) *nd.NdArray {
 //if a's shape is [m, n],
 //    then the std of each row will be returned in a 1d array;
 func Std(a *nd.NdArray) *nd.NdArray {
-	if len(a.Shape()) == 1 {
-		mean := Mean(a).Get(0)
-		sum := 0.0
-		for _, v := range a.Values() {
-			sum += (v - mean) * (v - mean)
-		}
-		std := math.Sqrt(sum / float64(len(a.Values())))
-		return nd.Array(std)
-	}
-
-	if len(a.Shape()) == 2 {
-		stds := make([]float64, a.Shape()[0])
-		for i := 0; i < a.Shape()[0]; i++ {
-			mean := Mean(a.NthRow(i)).Get(0)
-			sum := 0.0
-			for j := 0; j < a.Shape()[1]; j++ {
-				sum += (a.Get(i, j) - mean) * (a.Get(i, j) - mean)
-			}
-
-			stds[i] = math.Sqrt(sum / float64(a.Shape()[1]))
-		}
-		return nd.Array(stds...)
-	}
-
-	panic("shape error")
+	return StdDdof(a, rowAxis(a), 0)
 }
 
 //if a's shape is [m],
@@ -105,28 +81,17 @@ func Std(a *nd.NdArray) *nd.NdArray {
 //if a's shape is [m, n],
 //    then the variance of each row will be returned in a 1d array;
 func Var(a *nd.NdArray) *nd.NdArray {
+	return VarDdof(a, rowAxis(a), 0)
+}
+
+//the axis along which the functions of this file reduce: the elements of a 1d array,
+//or each row of a matrix.
+func rowAxis(a *nd.NdArray) int {
 	if len(a.Shape()) == 1 {
-		mean := Mean(a).Get(0)
-		sum := 0.0
-		for _, v := range a.Values() {
-			sum += (v - mean) * (v - mean)
-		}
-		std := sum / float64(len(a.Values()))
-		return nd.Array(std)
+		return 0
 	}
-
 	if len(a.Shape()) == 2 {
-		stds := make([]float64, a.Shape()[0])
-		for i := 0; i < a.Shape()[0]; i++ {
-			mean := Mean(a.NthRow(i)).Get(0)
-			sum := 0.0
-			for j := 0; j < a.Shape()[1]; j++ {
-				sum += (a.Get(i, j) - mean) * (a.Get(i, j) - mean)
-			}
-
-			stds[i] = sum / float64(a.Shape()[1])
-		}
-		return nd.Array(stds...)
+		return 1
 	}
 
 	panic("shape error")
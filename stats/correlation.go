@@ -0,0 +1,206 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Covariance matrix of the variables in x.
+//If rowvar is true each row of x is a variable and each column an observation, otherwise the reverse.
+//A 1d x is a single variable. The sums are divided by sum(weights) - ddof.
+//weights is nil for equal weights, otherwise a 1d array of frequency weights, one per observation.
+//The result has shape [k, k] for k variables.
+func Cov(x *nd.NdArray, rowvar bool, ddof int, weights *nd.NdArray) *nd.NdArray {
+	vars := variables(x, rowvar)
+	k, n := len(vars), 0
+	if k > 0 {
+		n = len(vars[0])
+	}
+
+	w := make([]float64, n)
+	for i := range w {
+		w[i] = 1
+	}
+	if weights != nil {
+		if weights.NDims() != 1 || weights.Size() != n {
+			panic("shape error")
+		}
+		copy(w, weights.Values())
+	}
+	wsum := 0.0
+	for _, v := range w {
+		wsum += v
+	}
+
+	//center every variable on its weighted mean
+	centered := make([][]float64, k)
+	for i, v := range vars {
+		mean := laneMean(v, w)
+		centered[i] = make([]float64, n)
+		for j := range v {
+			centered[i][j] = v[j] - mean
+		}
+	}
+
+	cov := nd.Zeros(k, k)
+	for i := 0; i < k; i++ {
+		for j := i; j < k; j++ {
+			sum := 0.0
+			for o := 0; o < n; o++ {
+				sum += w[o] * centered[i][o] * centered[j][o]
+			}
+			c := math.NaN()
+			if wsum-float64(ddof) > 0 {
+				c = sum / (wsum - float64(ddof))
+			}
+			cov.Set(c, i, j)
+			cov.Set(c, j, i)
+		}
+	}
+
+	return cov
+}
+
+//Pearson correlation coefficients of the variables in x, see Cov for rowvar.
+func Corrcoef(x *nd.NdArray, rowvar bool) *nd.NdArray {
+	return covToCorr(Cov(x, rowvar, 0, nil))
+}
+
+//Spearman rank correlation coefficients of the variables in x, see Cov for rowvar.
+//It is the Pearson correlation of the ranks, ties get the average of their ranks.
+func Spearman(x *nd.NdArray, rowvar bool) *nd.NdArray {
+	vars := variables(x, rowvar)
+	ranks := nd.Empty()
+	for _, v := range vars {
+		ranks.PushEles(rankAverage(v)...)
+	}
+	if len(vars) == 0 {
+		return nd.Zeros(0, 0)
+	}
+
+	return Corrcoef(ranks.Reshape(len(vars), len(vars[0])), true)
+}
+
+//Kendall's tau-b rank correlation coefficients of the variables in x, see Cov for rowvar.
+//tau-b adjusts for ties in either variable. Each pair of variables takes O(n^2) time.
+func KendallTau(x *nd.NdArray, rowvar bool) *nd.NdArray {
+	vars := variables(x, rowvar)
+	k := len(vars)
+	tau := nd.Zeros(k, k)
+	for i := 0; i < k; i++ {
+		for j := i; j < k; j++ {
+			t := kendallTauB(vars[i], vars[j])
+			tau.Set(t, i, j)
+			tau.Set(t, j, i)
+		}
+	}
+
+	return tau
+}
+
+func kendallTauB(x, y []float64) float64 {
+	var concordant, discordant, tiesX, tiesY float64
+	for i := range x {
+		for j := i + 1; j < len(x); j++ {
+			dx, dy := x[i]-x[j], y[i]-y[j]
+			switch {
+			case dx == 0 && dy == 0:
+			case dx == 0:
+				tiesX++
+			case dy == 0:
+				tiesY++
+			case (dx > 0) == (dy > 0):
+				concordant++
+			default:
+				discordant++
+			}
+		}
+	}
+
+	return (concordant - discordant) / math.Sqrt((concordant+discordant+tiesX)*(concordant+discordant+tiesY))
+}
+
+//normalize a covariance matrix to correlations, clipped to [-1, 1].
+func covToCorr(cov *nd.NdArray) *nd.NdArray {
+	k := cov.Shape()[0]
+	corr := nd.Zeros(k, k)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			c := cov.Get(i, j) / math.Sqrt(cov.Get(i, i)*cov.Get(j, j))
+			corr.Set(math.Max(-1, math.Min(1, c)), i, j)
+		}
+	}
+
+	return corr
+}
+
+//the variables of x as slices of observations.
+func variables(x *nd.NdArray, rowvar bool) [][]float64 {
+	if x.NDims() == 1 {
+		return [][]float64{x.Values()}
+	}
+	if x.NDims() != 2 {
+		panic("shape error")
+	}
+	if !rowvar {
+		x = x.T()
+	}
+
+	rows := x.HSplit()
+	vars := make([][]float64, len(rows))
+	for i, row := range rows {
+		vars[i] = row.Values()
+	}
+
+	return vars
+}
+
+//Ranks of the elements of a starting at 1, tied elements get the average of their ranks.
+//The result has the shape of a.
+func Rank(a *nd.NdArray) *nd.NdArray {
+	return nd.Array(rankAverage(a.Values())...).Reshape(a.Shape()...)
+}
+
+//ranks of s starting at 1, tied elements get the average of their ranks.
+func rankAverage(s []float64) []float64 {
+	idx := make([]int, len(s))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Sort(indexSorter{idx, s})
+
+	ranks := make([]float64, len(s))
+	for i := 0; i < len(idx); {
+		j := i
+		for j+1 < len(idx) && s[idx[j+1]] == s[idx[i]] {
+			j++
+		}
+		rank := float64(i+j)/2 + 1
+		for k := i; k <= j; k++ {
+			ranks[idx[k]] = rank
+		}
+		i = j + 1
+	}
+
+	return ranks
+}
+
+//sorts indices by the values they point to.
+type indexSorter struct {
+	idx    []int
+	values []float64
+}
+
+func (s indexSorter) Len() int {
+	return len(s.idx)
+}
+
+func (s indexSorter) Less(i, j int) bool {
+	return s.values[s.idx[i]] < s.values[s.idx[j]]
+}
+
+func (s indexSorter) Swap(i, j int) {
+	s.idx[i], s.idx[j] = s.idx[j], s.idx[i]
+}
@@ -0,0 +1,96 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestCov(t *testing.T) {
+	x := nd.Array(0, 1, 2, 2, 1, 0).Reshape(2, 3)
+	expected := nd.Array(1, -1, -1, 1).Reshape(2, 2)
+
+	if !Cov(x, true, 1, nil).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", Cov(x, true, 1, nil))
+	}
+	if !Cov(x.T(), false, 1, nil).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", Cov(x.T(), false, 1, nil))
+	}
+	if !Cov(nd.Array(1, 2, 3, 4), true, 0, nil).Equals(nd.Array(1.25).Reshape(1, 1)) {
+		t.Error("Expected [[1.25]], got ", Cov(nd.Array(1, 2, 3, 4), true, 0, nil))
+	}
+
+	//frequency weights give the same result as repeated observations
+	w := nd.Array(1, 2, 1)
+	repeated := nd.Array(0, 1, 1, 2, 2, 1, 1, 0).Reshape(2, 4)
+	if !Cov(x, true, 1, w).Equals(Cov(repeated, true, 1, nil)) {
+		t.Error("Expected ", Cov(repeated, true, 1, nil), ", got ", Cov(x, true, 1, w))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	Cov(x, true, 1, nd.Array(1, 2))
+}
+
+func TestCorrcoef(t *testing.T) {
+	x := nd.Array(1, 2, 3, 4, 2, 4, 6, 8, 4, 3, 2, 1).Reshape(3, 4)
+	c := Corrcoef(x, true)
+
+	if !c.Equals(nd.Array(1, 1, -1, 1, 1, -1, -1, -1, 1).Reshape(3, 3)) {
+		t.Error("Expected [[1,1,-1],[1,1,-1],[-1,-1,1]], got ", c)
+	}
+
+	y := nd.Array(1, 2, 3, 1, 3, 2).Reshape(2, 3)
+	if !Corrcoef(y, true).Equals(nd.Array(1, 0.5, 0.5, 1).Reshape(2, 2)) {
+		t.Error("Expected [[1,0.5],[0.5,1]], got ", Corrcoef(y, true))
+	}
+}
+
+func TestSpearman(t *testing.T) {
+	//monotonic but not linear
+	x := nd.Array(1, 2, 3, 4, 5, 1, 8, 27, 64, 125).Reshape(2, 5)
+
+	if !Spearman(x, true).Equals(nd.Ones(2, 2)) {
+		t.Error("Expected all ones, got ", Spearman(x, true))
+	}
+
+	y := nd.Array(1, 2, 2, 3, 1, 3, 2, 4).Reshape(2, 4)
+	if math.Abs(Spearman(y, true).Get(0, 1)-0.9486833) > 1e-6 {
+		t.Error("Expected 0.9486833, got ", Spearman(y, true).Get(0, 1))
+	}
+}
+
+func TestKendallTau(t *testing.T) {
+	x := nd.Array(1, 2, 3, 4, 5, 3, 1, 2, 5, 4).Reshape(2, 5)
+
+	if !KendallTau(x, true).Equals(nd.Array(1, 0.4, 0.4, 1).Reshape(2, 2)) {
+		t.Error("Expected [[1,0.4],[0.4,1]], got ", KendallTau(x, true))
+	}
+
+	//tau-b with ties: 4 concordant, 0 discordant, one tie in each variable
+	y := nd.Array(1, 2, 2, 3, 1, 1, 2, 3).Reshape(2, 4)
+	if math.Abs(KendallTau(y, true).Get(0, 1)-0.8) > 1e-12 {
+		t.Error("Expected 0.8, got ", KendallTau(y, true).Get(0, 1))
+	}
+}
+
+func TestRankAverage(t *testing.T) {
+	ranks := rankAverage([]float64{10, 30, 20, 20, 10})
+
+	if !nd.Array(ranks...).Equals(nd.Array(1.5, 5, 3.5, 3.5, 1.5)) {
+		t.Error("Expected [1.5, 5, 3.5, 3.5, 1.5], got ", ranks)
+	}
+}
+
+func TestRank(t *testing.T) {
+	a := nd.Array(10, 30, 20, 20, 10, 40).Reshape(2, 3)
+
+	if !Rank(a).Equals(nd.Array(1.5, 5, 3.5, 3.5, 1.5, 6).Reshape(2, 3)) {
+		t.Error("Expected [[1.5, 5, 3.5], [3.5, 1.5, 6]], got ", Rank(a))
+	}
+}
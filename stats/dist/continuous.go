@@ -0,0 +1,339 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Normal distribution with mean mu and standard deviation sigma.
+type Normal struct {
+	mu, sigma float64
+}
+
+func NewNormal(mu, sigma float64) *Normal {
+	if sigma <= 0 {
+		panic(fmt.Errorf("sigma: %v <= 0", sigma))
+	}
+	return &Normal{mu, sigma}
+}
+
+func (d *Normal) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Normal) logPDF(x float64) float64 {
+	z := (x - d.mu) / d.sigma
+	return -0.5*z*z - math.Log(d.sigma) - 0.5*math.Log(2*math.Pi)
+}
+
+func (d *Normal) cdf(x float64) float64 {
+	return 0.5 * math.Erfc(-(x-d.mu)/(d.sigma*math.Sqrt2))
+}
+
+func (d *Normal) quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return d.mu - d.sigma*math.Sqrt2*math.Erfcinv(2*p)
+}
+
+func (d *Normal) sample(g *random.Generator) float64 {
+	return d.mu + d.sigma*g.NormFloat64()
+}
+
+func (d *Normal) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Normal) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Normal) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Normal) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Normal) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//Distribution of exp(X) where X is normal with mean mu and standard deviation sigma.
+type LogNormal struct {
+	normal *Normal
+}
+
+func NewLogNormal(mu, sigma float64) *LogNormal {
+	return &LogNormal{NewNormal(mu, sigma)}
+}
+
+func (d *LogNormal) logPDF(x float64) float64 {
+	if x <= 0 {
+		return math.Inf(-1)
+	}
+	return d.normal.logPDF(math.Log(x)) - math.Log(x)
+}
+
+func (d *LogNormal) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *LogNormal) cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return d.normal.cdf(math.Log(x))
+}
+
+func (d *LogNormal) quantile(p float64) float64 {
+	return math.Exp(d.normal.quantile(p))
+}
+
+func (d *LogNormal) sample(g *random.Generator) float64 {
+	return math.Exp(d.normal.sample(g))
+}
+
+func (d *LogNormal) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *LogNormal) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *LogNormal) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *LogNormal) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *LogNormal) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//Exponential distribution with the given rate, whose mean is 1 / rate.
+type Exponential struct {
+	rate float64
+}
+
+func NewExponential(rate float64) *Exponential {
+	if rate <= 0 {
+		panic(fmt.Errorf("rate: %v <= 0", rate))
+	}
+	return &Exponential{rate}
+}
+
+func (d *Exponential) logPDF(x float64) float64 {
+	if x < 0 {
+		return math.Inf(-1)
+	}
+	return math.Log(d.rate) - d.rate*x
+}
+
+func (d *Exponential) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Exponential) cdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-d.rate * x)
+}
+
+func (d *Exponential) quantile(p float64) float64 {
+	if p < 0 || p > 1 {
+		return math.NaN()
+	}
+	return -math.Log1p(-p) / d.rate
+}
+
+func (d *Exponential) sample(g *random.Generator) float64 {
+	return -math.Log1p(-g.Float64()) / d.rate
+}
+
+func (d *Exponential) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Exponential) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Exponential) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Exponential) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Exponential) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//Gamma distribution with shape k and scale theta, whose mean is k * theta.
+type Gamma struct {
+	k, theta float64
+}
+
+func NewGamma(k, theta float64) *Gamma {
+	if k <= 0 || theta <= 0 {
+		panic(fmt.Errorf("k: %v or theta: %v <= 0", k, theta))
+	}
+	return &Gamma{k, theta}
+}
+
+func (d *Gamma) logPDF(x float64) float64 {
+	if x < 0 || (x == 0 && d.k > 1) {
+		return math.Inf(-1)
+	}
+	if x == 0 && d.k == 1 {
+		return -math.Log(d.theta)
+	}
+	lg, _ := math.Lgamma(d.k)
+	return (d.k-1)*math.Log(x) - x/d.theta - lg - d.k*math.Log(d.theta)
+}
+
+func (d *Gamma) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Gamma) cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return RegIncGamma(d.k, x/d.theta)
+}
+
+func (d *Gamma) quantile(p float64) float64 {
+	return invertCDF(d.cdf, p, 0, math.Inf(1), d.k*d.theta)
+}
+
+func (d *Gamma) sample(g *random.Generator) float64 {
+	return gammaVariate(g, d.k) * d.theta
+}
+
+func (d *Gamma) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Gamma) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Gamma) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Gamma) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Gamma) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//standard gamma variate with shape k, by Marsaglia and Tsang's method.
+func gammaVariate(g *random.Generator, k float64) float64 {
+	if k < 1 {
+		//boost: G(k) = G(k+1) * U^(1/k)
+		return gammaVariate(g, k+1) * math.Pow(g.Float64(), 1/k)
+	}
+
+	d := k - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := g.NormFloat64()
+		v := 1 + c*x
+		if v <= 0 {
+			continue
+		}
+		v = v * v * v
+		u := g.Float64()
+		if u < 1-0.0331*x*x*x*x || math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+//Beta distribution on [0, 1] with shape parameters a and b.
+type Beta struct {
+	a, b float64
+}
+
+func NewBeta(a, b float64) *Beta {
+	if a <= 0 || b <= 0 {
+		panic(fmt.Errorf("a: %v or b: %v <= 0", a, b))
+	}
+	return &Beta{a, b}
+}
+
+func (d *Beta) logPDF(x float64) float64 {
+	if x < 0 || x > 1 {
+		return math.Inf(-1)
+	}
+	//a term with a zero exponent is 0, also at the endpoint where its log is infinite
+	lx, l1x := 0.0, 0.0
+	if d.a != 1 {
+		lx = (d.a - 1) * math.Log(x)
+	}
+	if d.b != 1 {
+		l1x = (d.b - 1) * math.Log1p(-x)
+	}
+	return lx + l1x - LnBeta(d.a, d.b)
+}
+
+func (d *Beta) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Beta) cdf(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	return RegIncBeta(d.a, d.b, x)
+}
+
+func (d *Beta) quantile(p float64) float64 {
+	return invertCDF(d.cdf, p, 0, 1, 0.5)
+}
+
+func (d *Beta) sample(g *random.Generator) float64 {
+	x := gammaVariate(g, d.a)
+	y := gammaVariate(g, d.b)
+	return x / (x + y)
+}
+
+func (d *Beta) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Beta) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Beta) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Beta) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Beta) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//Chi-squared distribution with df degrees of freedom, which is Gamma(df/2, 2).
+type ChiSquared struct {
+	*Gamma
+}
+
+func NewChiSquared(df float64) *ChiSquared {
+	if df <= 0 {
+		panic(fmt.Errorf("df: %v <= 0", df))
+	}
+	return &ChiSquared{NewGamma(df/2, 2)}
+}
+
+//Student's t distribution with df degrees of freedom.
+type StudentT struct {
+	df float64
+}
+
+func NewStudentT(df float64) *StudentT {
+	if df <= 0 {
+		panic(fmt.Errorf("df: %v <= 0", df))
+	}
+	return &StudentT{df}
+}
+
+func (d *StudentT) logPDF(x float64) float64 {
+	return -(d.df+1)/2*math.Log1p(x*x/d.df) - 0.5*math.Log(d.df) - LnBeta(0.5, d.df/2)
+}
+
+func (d *StudentT) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *StudentT) cdf(x float64) float64 {
+	if math.IsInf(x, 0) {
+		if x > 0 {
+			return 1
+		}
+		return 0
+	}
+	tail := 0.5 * RegIncBeta(d.df/2, 0.5, d.df/(d.df+x*x))
+	if x > 0 {
+		return 1 - tail
+	}
+	return tail
+}
+
+func (d *StudentT) quantile(p float64) float64 {
+	return invertCDF(d.cdf, p, math.Inf(-1), math.Inf(1), 0)
+}
+
+func (d *StudentT) sample(g *random.Generator) float64 {
+	return g.NormFloat64() / math.Sqrt(2*gammaVariate(g, d.df/2)/d.df)
+}
+
+func (d *StudentT) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *StudentT) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *StudentT) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *StudentT) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *StudentT) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
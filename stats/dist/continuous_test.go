@@ -0,0 +1,142 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/stats"
+)
+
+func TestNormal(t *testing.T) {
+	var d Distribution = NewNormal(0, 1)
+
+	if !d.CDF(nd.Array(0, 1.96)).Equals(nd.Array(0.5, 0.9750021)) {
+		t.Error("Expected [0.5, 0.9750021], got ", d.CDF(nd.Array(0, 1.96)))
+	}
+	if !d.Quantile(nd.Array(0.975, 0.5)).Equals(nd.Array(1.959964, 0)) {
+		t.Error("Expected [1.959964, 0], got ", d.Quantile(nd.Array(0.975, 0.5)))
+	}
+	if !d.PDF(nd.Array(0)).Equals(nd.Array(1 / math.Sqrt(2*math.Pi))) {
+		t.Error("Expected [0.3989423], got ", d.PDF(nd.Array(0)))
+	}
+
+	s := NewNormal(3, 2).Sample(random.NewGenerator(1), 20000)
+	if mean := stats.Mean(s).Get(0); math.Abs(mean-3) > 0.05 {
+		t.Error("Expected mean near 3, got ", mean)
+	}
+}
+
+func TestLogNormalExponential(t *testing.T) {
+	ln := NewLogNormal(0, 1)
+	if !ln.CDF(nd.Array(1, -1)).Equals(nd.Array(0.5, 0)) {
+		t.Error("Expected [0.5, 0], got ", ln.CDF(nd.Array(1, -1)))
+	}
+	if !ln.Quantile(ln.CDF(nd.Array(2.5))).Equals(nd.Array(2.5)) {
+		t.Error("Expected [2.5], got ", ln.Quantile(ln.CDF(nd.Array(2.5))))
+	}
+
+	e := NewExponential(2)
+	if !e.LogPDF(nd.Array(1)).Equals(nd.Array(math.Log(2) - 2)) {
+		t.Error("Expected ", math.Log(2)-2, ", got ", e.LogPDF(nd.Array(1)))
+	}
+	if !e.Quantile(nd.Array(0.5)).Equals(nd.Array(math.Ln2 / 2)) {
+		t.Error("Expected ", math.Ln2/2, ", got ", e.Quantile(nd.Array(0.5)))
+	}
+
+	s := e.Sample(random.NewGenerator(2), 20000)
+	if mean := stats.Mean(s).Get(0); math.Abs(mean-0.5) > 0.02 {
+		t.Error("Expected mean near 0.5, got ", mean)
+	}
+}
+
+func TestGammaChiSquared(t *testing.T) {
+	g := NewGamma(2, 1)
+	if !g.CDF(nd.Array(1)).Equals(nd.Array(1 - 2/math.E)) {
+		t.Error("Expected ", 1-2/math.E, ", got ", g.CDF(nd.Array(1)))
+	}
+	if !g.CDF(g.Quantile(nd.Array(0.1, 0.9))).Equals(nd.Array(0.1, 0.9)) {
+		t.Error("Expected [0.1, 0.9], got ", g.CDF(g.Quantile(nd.Array(0.1, 0.9))))
+	}
+
+	c := NewChiSquared(3)
+	if !c.Quantile(nd.Array(0.95)).Equals(nd.Array(7.814728)) {
+		t.Error("Expected [7.814728], got ", c.Quantile(nd.Array(0.95)))
+	}
+
+	//shapes below 1 put quantiles close to 0
+	small := NewGamma(0.1, 1).Quantile(nd.Array(0.01, 0.02, 0.5)).Values()
+	for i, want := range []float64{6.073048362407866e-21, 6.2188015231056385e-18, 0.0005933911044602241} {
+		if math.Abs(small[i]-want) > 1e-9*want {
+			t.Error("Expected ", want, ", got ", small[i])
+		}
+	}
+
+	for _, k := range []float64{0.5, 4} {
+		s := NewGamma(k, 3).Sample(random.NewGenerator(3), 20000)
+		if mean := stats.Mean(s).Get(0); math.Abs(mean-3*k)/(3*k) > 0.03 {
+			t.Error("Expected mean near ", 3*k, ", got ", mean)
+		}
+	}
+}
+
+func TestBeta(t *testing.T) {
+	b := NewBeta(2, 3)
+	if !b.CDF(nd.Array(0.4, 2)).Equals(nd.Array(0.5248, 1)) {
+		t.Error("Expected [0.5248, 1], got ", b.CDF(nd.Array(0.4, 2)))
+	}
+	if !b.Quantile(nd.Array(0.5248)).Equals(nd.Array(0.4)) {
+		t.Error("Expected [0.4], got ", b.Quantile(nd.Array(0.5248)))
+	}
+	if !b.PDF(nd.Array(0.5)).Equals(nd.Array(1.5)) {
+		t.Error("Expected [1.5], got ", b.PDF(nd.Array(0.5)))
+	}
+
+	//the endpoints where an exponent is 0
+	if u := NewBeta(1, 1).PDF(nd.Array(0, 0.5, 1)); !u.Equals(nd.Array(1, 1, 1)) {
+		t.Error("Expected [1, 1, 1], got ", u)
+	}
+	if p := NewBeta(1, 3).PDF(nd.Array(0, 1)); !p.Equals(nd.Array(3, 0)) {
+		t.Error("Expected [3, 0], got ", p)
+	}
+	if p := NewBeta(3, 1).PDF(nd.Array(0, 1)); !p.Equals(nd.Array(0, 3)) {
+		t.Error("Expected [0, 3], got ", p)
+	}
+	if p := NewBeta(0.5, 1).PDF(nd.Array(0)).Get(0); !math.IsInf(p, 1) {
+		t.Error("Expected +Inf, got ", p)
+	}
+
+	//the cdf of Beta(a, 1) is x^a
+	small := NewBeta(0.1, 1).Quantile(nd.Array(0.01, 0.5)).Values()
+	for i, want := range []float64{1e-20, math.Pow(0.5, 10)} {
+		if math.Abs(small[i]-want) > 1e-9*want {
+			t.Error("Expected ", want, ", got ", small[i])
+		}
+	}
+
+	s := b.Sample(random.NewGenerator(4), 20000)
+	if mean := stats.Mean(s).Get(0); math.Abs(mean-0.4) > 0.01 {
+		t.Error("Expected mean near 0.4, got ", mean)
+	}
+}
+
+func TestStudentT(t *testing.T) {
+	cauchy := NewStudentT(1)
+	if !cauchy.CDF(nd.Array(1, -1)).Equals(nd.Array(0.75, 0.25)) {
+		t.Error("Expected [0.75, 0.25], got ", cauchy.CDF(nd.Array(1, -1)))
+	}
+
+	d := NewStudentT(10)
+	if !d.CDF(nd.Array(2.228139)).Equals(nd.Array(0.975)) {
+		t.Error("Expected [0.975], got ", d.CDF(nd.Array(2.228139)))
+	}
+	if !d.Quantile(nd.Array(0.025)).Equals(nd.Array(-2.228139)) {
+		t.Error("Expected [-2.228139], got ", d.Quantile(nd.Array(0.025)))
+	}
+
+	s := d.Sample(random.NewGenerator(5), 20000)
+	if v := stats.Var(s).Get(0); math.Abs(v-1.25) > 0.1 {
+		t.Error("Expected variance near 1.25, got ", v)
+	}
+}
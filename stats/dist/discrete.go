@@ -0,0 +1,340 @@
+package dist
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Poisson distribution with mean lambda.
+type Poisson struct {
+	lambda float64
+}
+
+func NewPoisson(lambda float64) *Poisson {
+	if lambda <= 0 {
+		panic(fmt.Errorf("lambda: %v <= 0", lambda))
+	}
+	return &Poisson{lambda}
+}
+
+func (d *Poisson) logPDF(x float64) float64 {
+	if x < 0 || x != math.Floor(x) {
+		return math.Inf(-1)
+	}
+	lf, _ := math.Lgamma(x + 1)
+	return x*math.Log(d.lambda) - d.lambda - lf
+}
+
+func (d *Poisson) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Poisson) cdf(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return RegIncGammaUpper(math.Floor(x)+1, d.lambda)
+}
+
+func (d *Poisson) quantile(p float64) float64 {
+	return discreteQuantile(d.cdf, p, 0, math.Inf(1), math.Floor(d.lambda))
+}
+
+func (d *Poisson) sample(g *random.Generator) float64 {
+	if d.lambda < 30 {
+		//inversion by sequential search
+		k, prob := 0.0, math.Exp(-d.lambda)
+		cum, u := prob, g.Float64()
+		for u > cum && prob > 0 {
+			k++
+			prob *= d.lambda / k
+			cum += prob
+		}
+		return k
+	}
+
+	//transformed rejection with squeeze (Hormann's PTRS)
+	slam := math.Sqrt(d.lambda)
+	loglam := math.Log(d.lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := g.Float64() - 0.5
+		v := g.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + d.lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lf, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -d.lambda+k*loglam-lf {
+			return k
+		}
+	}
+}
+
+func (d *Poisson) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Poisson) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Poisson) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Poisson) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Poisson) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//Binomial distribution, the number of successes in n trials with success probability p.
+type Binomial struct {
+	n int
+	p float64
+}
+
+func NewBinomial(n int, p float64) *Binomial {
+	if n < 0 || p < 0 || p > 1 {
+		panic(fmt.Errorf("n: %v < 0 or p: %v out of [0, 1]", n, p))
+	}
+	return &Binomial{n, p}
+}
+
+func (d *Binomial) logPDF(x float64) float64 {
+	n := float64(d.n)
+	if x < 0 || x > n || x != math.Floor(x) {
+		return math.Inf(-1)
+	}
+	if d.p == 0 || d.p == 1 {
+		if (d.p == 0 && x == 0) || (d.p == 1 && x == n) {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	return logChoose(n, x) + x*math.Log(d.p) + (n-x)*math.Log1p(-d.p)
+}
+
+func (d *Binomial) pdf(x float64) float64 {
+	return math.Exp(d.logPDF(x))
+}
+
+func (d *Binomial) cdf(x float64) float64 {
+	n := float64(d.n)
+	if x < 0 {
+		return 0
+	}
+	if x >= n || d.p == 0 {
+		return 1
+	}
+	if d.p == 1 {
+		return 0
+	}
+	k := math.Floor(x)
+	return RegIncBeta(n-k, k+1, 1-d.p)
+}
+
+func (d *Binomial) quantile(p float64) float64 {
+	return discreteQuantile(d.cdf, p, 0, float64(d.n), math.Floor(float64(d.n)*d.p))
+}
+
+func (d *Binomial) sample(g *random.Generator) float64 {
+	return float64(binomialVariate(g, d.n, d.p))
+}
+
+func (d *Binomial) PDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.pdf) }
+func (d *Binomial) LogPDF(x *nd.NdArray) *nd.NdArray   { return x.Map(d.logPDF) }
+func (d *Binomial) CDF(x *nd.NdArray) *nd.NdArray      { return x.Map(d.cdf) }
+func (d *Binomial) Quantile(p *nd.NdArray) *nd.NdArray { return p.Map(d.quantile) }
+func (d *Binomial) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	return sample(g, shape, d.sample)
+}
+
+//binomial variate, large n are reduced with beta distributed order statistics (Devroye)
+//until the mean is small enough for inversion.
+func binomialVariate(g *random.Generator, n int, p float64) int {
+	k := 0
+	for n > 0 && float64(n)*math.Min(p, 1-p) >= 30 {
+		i := (n + 1) / 2
+		x := gammaVariate(g, float64(i))
+		x = x / (x + gammaVariate(g, float64(n+1-i)))
+		if x <= p {
+			//the i smallest uniforms are successes
+			k += i
+			n -= i
+			p = (p - x) / (1 - x)
+		} else {
+			n = i - 1
+			p = p / x
+		}
+	}
+	if n == 0 || p == 0 {
+		return k
+	}
+	if p == 1 {
+		return k + n
+	}
+
+	//inversion by sequential search
+	q := 1 - p
+	prob := math.Pow(q, float64(n))
+	cum, u := prob, g.Float64()
+	j := 0
+	for u > cum && j < n {
+		prob *= float64(n-j) / float64(j+1) * p / q
+		j++
+		cum += prob
+	}
+
+	return k + j
+}
+
+//Multinomial distribution of the counts of n trials over len(probs) categories.
+//It is multivariate, so it has no CDF nor Quantile: the last dimension of x in PDF and LogPDF
+//holds the counts, and Sample adds a last dimension for them.
+type Multinomial struct {
+	n     int
+	probs []float64
+}
+
+func NewMultinomial(n int, probs *nd.NdArray) *Multinomial {
+	if n < 0 || probs.NDims() != 1 {
+		panic("shape error")
+	}
+	sum := 0.0
+	for _, p := range probs.Values() {
+		if p < 0 {
+			panic(fmt.Errorf("probability: %v < 0", p))
+		}
+		sum += p
+	}
+	if math.Abs(sum-1) > 1e-8 {
+		panic(fmt.Errorf("probabilities sum to %v != 1", sum))
+	}
+	return &Multinomial{n, append([]float64{}, probs.Values()...)}
+}
+
+func (d *Multinomial) logPDF(x []float64) float64 {
+	total := 0.0
+	lp, _ := math.Lgamma(float64(d.n) + 1)
+	for i, c := range x {
+		if c < 0 || c != math.Floor(c) {
+			return math.Inf(-1)
+		}
+		total += c
+		lc, _ := math.Lgamma(c + 1)
+		lp -= lc
+		if c > 0 {
+			lp += c * math.Log(d.probs[i])
+		}
+	}
+	if total != float64(d.n) {
+		return math.Inf(-1)
+	}
+
+	return lp
+}
+
+func (d *Multinomial) LogPDF(x *nd.NdArray) *nd.NdArray {
+	return mapLastAxis(x, len(d.probs), d.logPDF)
+}
+
+func (d *Multinomial) PDF(x *nd.NdArray) *nd.NdArray {
+	return d.LogPDF(x).Map(math.Exp)
+}
+
+func (d *Multinomial) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	k := len(d.probs)
+	tn := nd.Zeros(append(append([]int{}, shape...), k)...)
+	values := tn.Values()
+	for s := 0; s < len(values); s += k {
+		//sequential conditional binomials
+		left, rest := d.n, 1.0
+		for i := 0; i < k-1 && left > 0; i++ {
+			p := 0.0
+			if rest > 0 {
+				p = math.Min(1, d.probs[i]/rest)
+			}
+			c := binomialVariate(g, left, p)
+			values[s+i] = float64(c)
+			left -= c
+			rest -= d.probs[i]
+		}
+		values[s+k-1] += float64(left)
+	}
+
+	return tn
+}
+
+//logarithm of the binomial coefficient.
+func logChoose(n, k float64) float64 {
+	ln, _ := math.Lgamma(n + 1)
+	lk, _ := math.Lgamma(k + 1)
+	lnk, _ := math.Lgamma(n - k + 1)
+	return ln - lk - lnk
+}
+
+//smallest integer x in [lo, hi] with cdf(x) >= p, starting the search from start.
+func discreteQuantile(cdf func(x float64) float64, p, lo, hi, start float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	if p == 0 {
+		return lo
+	}
+	if p == 1 {
+		return hi
+	}
+
+	x := math.Max(lo, math.Min(hi, start))
+	//exponential search for a bracket, then bisection
+	step := 1.0
+	if cdf(x) >= p {
+		for x > lo && cdf(x-step) >= p {
+			x = math.Max(lo, x-step)
+			step *= 2
+		}
+		lo = math.Max(lo, x-step)
+		hi = x
+	} else {
+		for x < hi && cdf(x+step) < p {
+			x = math.Min(hi, x+step)
+			step *= 2
+		}
+		lo = x
+		hi = math.Min(hi, x+step)
+	}
+	if cdf(lo) >= p {
+		return lo
+	}
+	//invariant: cdf(lo) < p <= cdf(hi)
+	for hi-lo > 1 {
+		mid := math.Floor((lo + hi) / 2)
+		if cdf(mid) >= p {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	return hi
+}
+
+//apply f to every vector along the last axis of x, which must have length k.
+func mapLastAxis(x *nd.NdArray, k int, f func(v []float64) float64) *nd.NdArray {
+	shape := x.Shape()
+	if len(shape) == 0 || shape[len(shape)-1] != k {
+		panic("shape error")
+	}
+	values := x.Values()
+	out := make([]float64, len(values)/k)
+	for i := range out {
+		out[i] = f(values[i*k : (i+1)*k])
+	}
+	if len(shape) == 1 {
+		return nd.Array(out...)
+	}
+
+	return nd.Array(out...).Reshape(shape[:len(shape)-1]...)
+}
@@ -0,0 +1,76 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/stats"
+)
+
+func TestPoisson(t *testing.T) {
+	var d Distribution = NewPoisson(3)
+
+	if !d.PDF(nd.Array(2, 2.5, -1)).Equals(nd.Array(0.2240418, 0, 0)) {
+		t.Error("Expected [0.2240418, 0, 0], got ", d.PDF(nd.Array(2, 2.5, -1)))
+	}
+	if !d.CDF(nd.Array(2, 2.7)).Equals(nd.Array(0.4231901, 0.4231901)) {
+		t.Error("Expected [0.4231901, 0.4231901], got ", d.CDF(nd.Array(2, 2.7)))
+	}
+	if !d.Quantile(nd.Array(0.5, 0.42, 0.01)).Equals(nd.Array(3, 2, 0)) {
+		t.Error("Expected [3, 2, 0], got ", d.Quantile(nd.Array(0.5, 0.42, 0.01)))
+	}
+
+	for _, lambda := range []float64{3, 100} {
+		s := NewPoisson(lambda).Sample(random.NewGenerator(6), 20000)
+		if mean := stats.Mean(s).Get(0); math.Abs(mean-lambda)/lambda > 0.02 {
+			t.Error("Expected mean near ", lambda, ", got ", mean)
+		}
+		if v := stats.Var(s).Get(0); math.Abs(v-lambda)/lambda > 0.05 {
+			t.Error("Expected variance near ", lambda, ", got ", v)
+		}
+	}
+}
+
+func TestBinomial(t *testing.T) {
+	d := NewBinomial(10, 0.3)
+
+	if !d.PDF(nd.Array(3)).Equals(nd.Array(0.2668279)) {
+		t.Error("Expected [0.2668279], got ", d.PDF(nd.Array(3)))
+	}
+	if !d.CDF(nd.Array(3, 10, -1)).Equals(nd.Array(0.6496107, 1, 0)) {
+		t.Error("Expected [0.6496107, 1, 0], got ", d.CDF(nd.Array(3, 10, -1)))
+	}
+	if !d.Quantile(nd.Array(0.5, 1)).Equals(nd.Array(3, 10)) {
+		t.Error("Expected [3, 10], got ", d.Quantile(nd.Array(0.5, 1)))
+	}
+
+	s := NewBinomial(1000, 0.4).Sample(random.NewGenerator(7), 20000)
+	if mean := stats.Mean(s).Get(0); math.Abs(mean-400) > 1 {
+		t.Error("Expected mean near 400, got ", mean)
+	}
+	if v := stats.Var(s).Get(0); math.Abs(v-240)/240 > 0.05 {
+		t.Error("Expected variance near 240, got ", v)
+	}
+}
+
+func TestMultinomial(t *testing.T) {
+	d := NewMultinomial(2, nd.Array(0.5, 0.5))
+
+	if !d.PDF(nd.Array(1, 1, 2, 0, 1, 0).Reshape(3, 2)).Equals(nd.Array(0.5, 0.25, 0)) {
+		t.Error("Expected [0.5, 0.25, 0], got ", d.PDF(nd.Array(1, 1, 2, 0, 1, 0).Reshape(3, 2)))
+	}
+
+	m := NewMultinomial(50, nd.Array(0.2, 0.3, 0.5))
+	s := m.Sample(random.NewGenerator(8), 1000)
+	if s.Shape()[0] != 1000 || s.Shape()[1] != 3 {
+		t.Error("Expected [1000 3], got ", s.Shape())
+	}
+	if !stats.Sum(s).Equals(nd.Ones(1000).Mul(nd.Array(50))) {
+		t.Error("Expected every sample to sum to 50")
+	}
+	if mean := stats.Mean(s.T()); !mean.Sub(nd.Array(10, 15, 25)).Map(math.Abs).Extract(func(e float64) bool { return e > 0.5 }).IsEmpty() {
+		t.Error("Expected means near [10, 15, 25], got ", mean)
+	}
+}
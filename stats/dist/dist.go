@@ -0,0 +1,28 @@
+package dist
+
+import (
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Univariate probability distribution, every method works elementwise.
+//For discrete distributions PDF is the probability mass function.
+type Distribution interface {
+	PDF(x *nd.NdArray) *nd.NdArray
+	LogPDF(x *nd.NdArray) *nd.NdArray
+	CDF(x *nd.NdArray) *nd.NdArray
+	//Inverse of CDF, for discrete distributions the smallest x with CDF(x) >= p.
+	Quantile(p *nd.NdArray) *nd.NdArray
+	//Independent samples of the given shape.
+	Sample(g *random.Generator, shape ...int) *nd.NdArray
+}
+
+func sample(g *random.Generator, shape []int, f func(g *random.Generator) float64) *nd.NdArray {
+	tn := nd.Zeros(shape...)
+	values := tn.Values()
+	for i := range values {
+		values[i] = f(g)
+	}
+
+	return tn
+}
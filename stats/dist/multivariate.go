@@ -0,0 +1,79 @@
+package dist
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//Multivariate normal distribution with a mean vector of shape [d] and a covariance of shape [d, d].
+//It has no CDF nor Quantile: the last dimension of x in PDF and LogPDF holds the vectors,
+//and Sample adds a last dimension for them.
+type MultivariateNormal struct {
+	mean   []float64
+	chol   *nd.NdArray
+	logDet float64
+}
+
+//The covariance must be symmetric positive definite.
+func NewMultivariateNormal(mean, cov *nd.NdArray) *MultivariateNormal {
+	if mean.NDims() != 1 || cov.NDims() != 2 || cov.Shape()[0] != mean.Size() || cov.Shape()[1] != mean.Size() {
+		panic("shape error")
+	}
+
+	chol := cov.Cholesky()
+	logDet := 0.0
+	for _, v := range chol.Diag(0).Values() {
+		logDet += 2 * math.Log(v)
+	}
+
+	return &MultivariateNormal{
+		mean:   append([]float64{}, mean.Values()...),
+		chol:   chol,
+		logDet: logDet,
+	}
+}
+
+func (d *MultivariateNormal) logPDF(x []float64) float64 {
+	k := len(d.mean)
+	diff := nd.Zeros(k)
+	for i, v := range x {
+		diff.Values()[i] = v - d.mean[i]
+	}
+	//mahalanobis distance with the cholesky factor: |L^-1 (x - mean)|^2
+	z := d.chol.SolveTriangular(diff, true, false)
+	maha := z.Dot(z).Get(0)
+
+	return -0.5 * (maha + d.logDet + float64(k)*math.Log(2*math.Pi))
+}
+
+func (d *MultivariateNormal) LogPDF(x *nd.NdArray) *nd.NdArray {
+	return mapLastAxis(x, len(d.mean), d.logPDF)
+}
+
+func (d *MultivariateNormal) PDF(x *nd.NdArray) *nd.NdArray {
+	return d.LogPDF(x).Map(math.Exp)
+}
+
+func (d *MultivariateNormal) Sample(g *random.Generator, shape ...int) *nd.NdArray {
+	k := len(d.mean)
+	tn := nd.Zeros(append(append([]int{}, shape...), k)...)
+	values := tn.Values()
+	l := d.chol.Values()
+	z := make([]float64, k)
+	for s := 0; s < len(values); s += k {
+		for i := range z {
+			z[i] = g.NormFloat64()
+		}
+		for i := 0; i < k; i++ {
+			v := d.mean[i]
+			for j := 0; j <= i; j++ {
+				v += l[i*k+j] * z[j]
+			}
+			values[s+i] = v
+		}
+	}
+
+	return tn
+}
@@ -0,0 +1,45 @@
+package dist
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/stats"
+)
+
+func TestMultivariateNormalPDF(t *testing.T) {
+	d := NewMultivariateNormal(nd.Array(0, 0), nd.Array(4, 0, 0, 1).Reshape(2, 2))
+	x := nd.Array(0, 0, 2, 0).Reshape(2, 2)
+	expected := nd.Array(1/(4*math.Pi), math.Exp(-0.5)/(4*math.Pi))
+
+	if !d.PDF(x).Equals(expected) {
+		t.Error("Expected ", expected, ", got ", d.PDF(x))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	d.PDF(nd.Array(1, 2, 3))
+}
+
+func TestMultivariateNormalSample(t *testing.T) {
+	cov := nd.Array(2, 0.8, 0.8, 1).Reshape(2, 2)
+	d := NewMultivariateNormal(nd.Array(1, -1), cov)
+	s := d.Sample(random.NewGenerator(9), 20000)
+
+	mean := stats.Mean(s.T())
+	if math.Abs(mean.Get(0)-1) > 0.05 || math.Abs(mean.Get(1)+1) > 0.05 {
+		t.Error("Expected mean near [1, -1], got ", mean)
+	}
+
+	centered := s.Sub(mean.Reshape(1, 2))
+	c := centered.T().Dot(centered).Div(nd.Array(20000))
+	if diff := c.Sub(cov).Map(math.Abs); stats.Max(diff.Flat()).Get(0) > 0.05 {
+		t.Error("Expected covariance near ", cov, ", got ", c)
+	}
+}
@@ -0,0 +1,198 @@
+package dist
+
+import (
+	"math"
+)
+
+//Logarithm of the beta function.
+func LnBeta(a, b float64) float64 {
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	return la + lb - lab
+}
+
+//Regularized lower incomplete gamma function P(a, x).
+func RegIncGamma(a, x float64) float64 {
+	if a <= 0 || x < 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 {
+		return 0
+	}
+	if math.IsInf(x, 1) {
+		return 1
+	}
+
+	lga, _ := math.Lgamma(a)
+	prefix := math.Exp(a*math.Log(x) - x - lga)
+	if x < a+1 {
+		//series expansion
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-16 {
+				break
+			}
+		}
+		return sum * prefix
+	}
+
+	return 1 - prefix*gammaContinuedFraction(a, x)
+}
+
+//Regularized upper incomplete gamma function Q(a, x) = 1 - P(a, x).
+func RegIncGammaUpper(a, x float64) float64 {
+	if a <= 0 || x < 0 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x < a+1 {
+		return 1 - RegIncGamma(a, x)
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+
+	lga, _ := math.Lgamma(a)
+	return math.Exp(a*math.Log(x)-x-lga) * gammaContinuedFraction(a, x)
+}
+
+//continued fraction of Q(a, x), by the modified Lentz's method.
+func gammaContinuedFraction(a, x float64) float64 {
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-16 {
+			break
+		}
+	}
+
+	return h
+}
+
+//Regularized incomplete beta function I_x(a, b).
+func RegIncBeta(a, b, x float64) float64 {
+	if a <= 0 || b <= 0 || x < 0 || x > 1 || math.IsNaN(x) {
+		return math.NaN()
+	}
+	if x == 0 || x == 1 {
+		return x
+	}
+
+	prefix := math.Exp(a*math.Log(x) + b*math.Log1p(-x) - LnBeta(a, b))
+	//the continued fraction converges quickly for x < (a+1)/(a+b+2), use the symmetry otherwise
+	if x < (a+1)/(a+b+2) {
+		return prefix * betaContinuedFraction(a, b, x) / a
+	}
+
+	return 1 - prefix*betaContinuedFraction(b, a, 1-x)/b
+}
+
+//continued fraction of the incomplete beta function, by the modified Lentz's method.
+func betaContinuedFraction(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m < 1000; m++ {
+		fm := float64(m)
+		//even step
+		an := fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm))
+		d = 1 + an*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		//odd step
+		an = -(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1))
+		d = 1 + an*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-16 {
+			break
+		}
+	}
+
+	return h
+}
+
+//Find x in [lo, hi] with cdf(x) = p by bisection, cdf must be non-decreasing.
+//Infinite bounds are replaced by expanding a finite bracket around start.
+func invertCDF(cdf func(x float64) float64, p, lo, hi, start float64) float64 {
+	if math.IsNaN(p) || p < 0 || p > 1 {
+		return math.NaN()
+	}
+	if p == 0 {
+		return lo
+	}
+	if p == 1 {
+		return hi
+	}
+
+	step := 1.0
+	if math.IsInf(lo, -1) {
+		lo = start - step
+		for cdf(lo) > p {
+			step *= 2
+			lo = start - step
+		}
+	}
+	step = 1.0
+	if math.IsInf(hi, 1) {
+		hi = start + step
+		for cdf(hi) < p {
+			step *= 2
+			hi = start + step
+		}
+	}
+
+	//the width is relative, so that quantiles near 0 keep their precision, bisection reaches
+	//the smallest floats in about 1100 steps
+	for i := 0; i < 2000 && hi-lo > 1e-15*math.Max(math.Abs(lo), math.Abs(hi)); i++ {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			break
+		}
+		if cdf(mid) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return lo + (hi-lo)/2
+}
@@ -0,0 +1,35 @@
+package dist
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRegIncGamma(t *testing.T) {
+	for _, x := range []float64{0.1, 1, 3, 10} {
+		if v := RegIncGamma(1, x); math.Abs(v-(1-math.Exp(-x))) > 1e-12 {
+			t.Error("Expected ", 1-math.Exp(-x), ", got ", v)
+		}
+		if v := RegIncGamma(0.5, x); math.Abs(v-math.Erf(math.Sqrt(x))) > 1e-12 {
+			t.Error("Expected ", math.Erf(math.Sqrt(x)), ", got ", v)
+		}
+		if v := RegIncGamma(2.5, x) + RegIncGammaUpper(2.5, x); math.Abs(v-1) > 1e-12 {
+			t.Error("Expected 1, got ", v)
+		}
+	}
+}
+
+func TestRegIncBeta(t *testing.T) {
+	if v := RegIncBeta(1, 1, 0.3); math.Abs(v-0.3) > 1e-12 {
+		t.Error("Expected 0.3, got ", v)
+	}
+	if v := RegIncBeta(2, 3, 0.4); math.Abs(v-0.5248) > 1e-12 {
+		t.Error("Expected 0.5248, got ", v)
+	}
+	if v := RegIncBeta(3, 2, 0.6); math.Abs(v-(1-0.5248)) > 1e-12 {
+		t.Error("Expected 0.4752, got ", v)
+	}
+	if !math.IsNaN(RegIncBeta(1, 1, 2)) {
+		t.Error("Expected NaN, got ", RegIncBeta(1, 1, 2))
+	}
+}
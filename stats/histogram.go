@@ -0,0 +1,327 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//How the bin edges of a histogram are chosen, one of NumBins, BinEdges or a BinRule.
+type Bins interface {
+	//edges of the bins for values, covering the range [lo, hi].
+	binEdges(values []float64, lo, hi float64) []float64
+}
+
+//A number of bins of equal width.
+type NumBins int
+
+//Explicit bin edges, monotonically increasing.
+//Every bin is half open [e[i], e[i+1]) except the last one which includes its right edge.
+type BinEdges []float64
+
+//A rule choosing the width of equal bins from the data in the histogram range.
+type BinRule int
+
+const (
+	//log2(n) + 1 bins, fine for small, roughly normal data
+	Sturges BinRule = iota
+	//width 3.5 * std / n^(1/3), for roughly normal data
+	Scott
+	//Freedman-Diaconis, width 2 * IQR / n^(1/3), robust to outliers
+	FD
+	//the smaller width of Sturges and FD, or Sturges when the IQR is 0
+	Auto
+)
+
+func (n NumBins) binEdges(values []float64, lo, hi float64) []float64 {
+	if n < 1 {
+		panic(fmt.Errorf("bins: %v must be positive", int(n)))
+	}
+
+	return linspace(lo, hi, int(n)+1)
+}
+
+func (e BinEdges) binEdges(values []float64, lo, hi float64) []float64 {
+	if len(e) < 2 {
+		panic("bins: at least two edges are needed")
+	}
+	for i := 1; i < len(e); i++ {
+		if !(e[i] >= e[i-1]) {
+			panic("bins: edges must increase monotonically")
+		}
+	}
+
+	return append([]float64(nil), e...)
+}
+
+func (r BinRule) binEdges(values []float64, lo, hi float64) []float64 {
+	var inRange []float64
+	for _, v := range values {
+		if v >= lo && v <= hi {
+			inRange = append(inRange, v)
+		}
+	}
+	n := float64(len(inRange))
+
+	width := 0.0
+	if n > 0 {
+		sturges := (hi - lo) / (math.Log2(n) + 1)
+		switch r {
+		case Sturges:
+			width = sturges
+		case Scott:
+			width = math.Pow(24*math.Sqrt(math.Pi)/n, 1.0/3) * math.Sqrt(laneVar(inRange, nil, 0))
+		case FD, Auto:
+			q := make([]float64, 2)
+			quantiles(inRange, []float64{0.25, 0.75}, Linear, q)
+			width = 2 * (q[1] - q[0]) / math.Cbrt(n)
+			if r == Auto && (width == 0 || sturges < width) {
+				width = sturges
+			}
+		default:
+			panic(fmt.Errorf("unknown bin rule: %v", r))
+		}
+	}
+
+	nbins := 1
+	if width > 0 {
+		nbins = int(math.Ceil((hi - lo) / width))
+	}
+
+	return linspace(lo, hi, nbins+1)
+}
+
+//Histogram of the elements of a, returns the count in each bin and the bin edges.
+//rng is nil to use the minimum and maximum of a, otherwise [lo, hi], elements outside are ignored.
+//weights is nil or has the shape of a, each element then counts for its weight.
+//If density is true the counts are normalized so that the histogram integrates to 1.
+func Histogram(a *nd.NdArray, bins Bins, rng []float64, weights *nd.NdArray, density bool) (*nd.NdArray, *nd.NdArray) {
+	var r [][]float64
+	if rng != nil {
+		r = [][]float64{rng}
+	}
+	hist, edges := HistogramDD(a.Flat(), []Bins{bins}, r, flatWeights(a, weights), density)
+
+	return hist, edges[0]
+}
+
+//Histogram of the points (x[i], y[i]), returns the counts with shape [nx, ny] and the edges along x and y.
+//bins holds one Bins for both dimensions or one for each, rng is nil or holds [lo, hi] (or nil) for each dimension.
+//See Histogram for weights and density.
+func Histogram2D(x, y *nd.NdArray, bins []Bins, rng [][]float64, weights *nd.NdArray, density bool) (*nd.NdArray, *nd.NdArray, *nd.NdArray) {
+	if x.Size() != y.Size() {
+		panic("shape error")
+	}
+	sample := nd.VStack(x.Flat().Reshape(1, x.Size()), y.Flat().Reshape(1, y.Size())).T()
+	hist, edges := HistogramDD(sample, bins, rng, flatWeights(x, weights), density)
+
+	return hist, edges[0], edges[1]
+}
+
+//Multidimensional histogram of sample, an array [n, D] of n points in D dimensions, a 1d sample has D = 1.
+//Returns the counts with shape [bins along dimension 0, ..., bins along dimension D-1] and the edges of each dimension.
+//See Histogram2D for bins and rng, weights is nil or 1d with one weight per point.
+func HistogramDD(sample *nd.NdArray, bins []Bins, rng [][]float64, weights *nd.NdArray, density bool) (*nd.NdArray, []*nd.NdArray) {
+	if sample.NDims() == 1 {
+		sample = sample.Reshape(sample.Size(), 1)
+	}
+	if sample.NDims() != 2 {
+		panic("shape error")
+	}
+	n, dims := sample.Shape()[0], sample.Shape()[1]
+	if len(bins) != 1 && len(bins) != dims || rng != nil && len(rng) != dims {
+		panic("shape error")
+	}
+	if weights != nil && (weights.NDims() != 1 || weights.Size() != n) {
+		panic("shape error")
+	}
+
+	values := sample.Values()
+	edges := make([][]float64, dims)
+	shape := make([]int, dims)
+	for d := range edges {
+		col := make([]float64, n)
+		for i := range col {
+			col[i] = values[i*dims+d]
+		}
+		var r []float64
+		if rng != nil {
+			r = rng[d]
+		}
+		lo, hi := histRange(col, r)
+		b := bins[0]
+		if len(bins) > 1 {
+			b = bins[d]
+		}
+		edges[d] = b.binEdges(col, lo, hi)
+		shape[d] = len(edges[d]) - 1
+	}
+
+	hist := nd.Zeros(shape...)
+	counts := hist.Values()
+	for i := 0; i < n; i++ {
+		pos := 0
+		for d := 0; d < dims && pos >= 0; d++ {
+			k := binIndex(edges[d], values[i*dims+d])
+			if k < 0 {
+				pos = -1
+			} else {
+				pos = pos*shape[d] + k
+			}
+		}
+		if pos < 0 {
+			continue
+		}
+		if weights != nil {
+			counts[pos] += weights.Values()[i]
+		} else {
+			counts[pos]++
+		}
+	}
+
+	if density {
+		total := 0.0
+		for _, c := range counts {
+			total += c
+		}
+		for pos := range counts {
+			volume, p := 1.0, pos
+			for d := dims - 1; d >= 0; d-- {
+				k := p % shape[d]
+				volume *= edges[d][k+1] - edges[d][k]
+				p /= shape[d]
+			}
+			counts[pos] /= total * volume
+		}
+	}
+
+	res := make([]*nd.NdArray, dims)
+	for d, e := range edges {
+		res[d] = nd.Array(e...)
+	}
+
+	return hist, res
+}
+
+//Indices of the bins to which each element of x belongs, with the shape of x.
+//bins are edges increasing or decreasing monotonically. For increasing bins and right false,
+//i is returned when bins[i-1] <= x < bins[i], so 0 and len(bins) mark elements below and above the edges.
+//When right is true the intervals are closed on the right instead: bins[i-1] < x <= bins[i].
+func Digitize(x *nd.NdArray, bins *nd.NdArray, right bool) *nd.NdArray {
+	edges := bins.Values()
+	increasing, decreasing := true, true
+	for i := 1; i < len(edges); i++ {
+		increasing = increasing && edges[i] >= edges[i-1]
+		decreasing = decreasing && edges[i] <= edges[i-1]
+	}
+	if !increasing && !decreasing {
+		panic("bins: edges must be monotonic")
+	}
+
+	n := len(edges)
+	return x.Map(func(v float64) float64 {
+		if increasing {
+			return float64(sort.Search(n, func(i int) bool {
+				return edges[i] > v || right && edges[i] >= v
+			}))
+		}
+		//search the reversed edges, counting from the end
+		return float64(n - sort.Search(n, func(i int) bool {
+			e := edges[n-1-i]
+			return e > v || right && e >= v
+		}))
+	})
+}
+
+//Number of occurrences of each value in x, non negative integers, or the sum of their weights.
+//The result has max(x) + 1 elements, at least minlength.
+func Bincount(x *nd.NdArray, weights *nd.NdArray, minlength int) *nd.NdArray {
+	if x.NDims() != 1 || weights != nil && (weights.NDims() != 1 || weights.Size() != x.Size()) {
+		panic("shape error")
+	}
+
+	size := minlength
+	for _, v := range x.Values() {
+		if v < 0 || v != math.Trunc(v) {
+			panic(fmt.Errorf("bincount: %v is not a non negative integer", v))
+		}
+		if int(v)+1 > size {
+			size = int(v) + 1
+		}
+	}
+
+	counts := make([]float64, size)
+	for i, v := range x.Values() {
+		if weights != nil {
+			counts[int(v)] += weights.Values()[i]
+		} else {
+			counts[int(v)]++
+		}
+	}
+
+	return nd.Array(counts...)
+}
+
+//the range of a histogram dimension, from rng or else from the finite values.
+func histRange(values []float64, rng []float64) (float64, float64) {
+	if rng != nil {
+		if len(rng) != 2 || !(rng[0] <= rng[1]) {
+			panic(fmt.Errorf("range: %v is not [lo, hi]", rng))
+		}
+		return rng[0], rng[1]
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			continue
+		}
+		lo = math.Min(lo, v)
+		hi = math.Max(hi, v)
+	}
+	if lo > hi {
+		return 0, 1
+	}
+	if lo == hi {
+		return lo - 0.5, hi + 0.5
+	}
+
+	return lo, hi
+}
+
+//index of the bin containing v, -1 when v is outside the edges.
+func binIndex(edges []float64, v float64) int {
+	last := len(edges) - 1
+	if !(v >= edges[0] && v <= edges[last]) {
+		return -1
+	}
+	if v == edges[last] {
+		return last - 1
+	}
+
+	return sort.Search(len(edges), func(i int) bool { return edges[i] > v }) - 1
+}
+
+func flatWeights(a *nd.NdArray, weights *nd.NdArray) *nd.NdArray {
+	if weights == nil {
+		return nil
+	}
+	if weights.Size() != a.Size() {
+		panic("shape error")
+	}
+
+	return weights.Flat()
+}
+
+//n values evenly spaced from lo to hi.
+func linspace(lo, hi float64, n int) []float64 {
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+	}
+	s[n-1] = hi
+
+	return s
+}
@@ -0,0 +1,143 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestHistogram(t *testing.T) {
+	a := nd.Array(0, 1, 1, 2, 3, 4)
+
+	hist, edges := Histogram(a, NumBins(4), nil, nil, false)
+	if !hist.Equals(nd.Array(1, 2, 1, 2)) {
+		t.Error("Expected [1, 2, 1, 2], got ", hist)
+	}
+	if !edges.Equals(nd.Array(0, 1, 2, 3, 4)) {
+		t.Error("Expected [0, 1, 2, 3, 4], got ", edges)
+	}
+
+	//elements outside the range are ignored, the last bin is closed
+	hist, _ = Histogram(a, BinEdges{1, 2, 3}, nil, nil, false)
+	if !hist.Equals(nd.Array(2, 2)) {
+		t.Error("Expected [2, 2], got ", hist)
+	}
+	hist, _ = Histogram(a, NumBins(2), []float64{0, 2}, nd.Array(1, 2, 3, 4, 5, 6), false)
+	if !hist.Equals(nd.Array(1, 9)) {
+		t.Error("Expected [1, 9], got ", hist)
+	}
+
+	hist, _ = Histogram(a, NumBins(4), nil, nil, true)
+	//the bins have width 1
+	if math.Abs(hist.SumAll()-1) > 1e-12 {
+		t.Error("Expected a density integrating to 1, got ", hist)
+	}
+
+	//a constant array gets the range [v - 0.5, v + 0.5]
+	_, edges = Histogram(nd.Array(3, 3), NumBins(1), nil, nil, false)
+	if !edges.Equals(nd.Array(2.5, 3.5)) {
+		t.Error("Expected [2.5, 3.5], got ", edges)
+	}
+}
+
+func TestBinRules(t *testing.T) {
+	a := nd.Arange(0, 100)
+
+	_, edges := Histogram(a, Sturges, nil, nil, false)
+	//log2(100) + 1 = 7.64
+	if edges.Size() != 9 {
+		t.Error("Expected 8 bins, got ", edges.Size()-1)
+	}
+	_, edges = Histogram(a, FD, nil, nil, false)
+	//width 2 * 49.5 / 100^(1/3) = 21.3
+	if edges.Size() != 6 {
+		t.Error("Expected 5 bins, got ", edges.Size()-1)
+	}
+	_, edges = Histogram(a, Auto, nil, nil, false)
+	if edges.Size() != 9 {
+		t.Error("Expected 8 bins, got ", edges.Size()-1)
+	}
+	_, edges = Histogram(a, Scott, nil, nil, false)
+	//width 3.49 * 28.87 / 100^(1/3) = 21.7
+	if edges.Size() != 6 {
+		t.Error("Expected 5 bins, got ", edges.Size()-1)
+	}
+}
+
+func TestHistogram2D(t *testing.T) {
+	x := nd.Array(0, 0, 1, 2)
+	y := nd.Array(0, 2, 2, 2)
+
+	hist, xedges, yedges := Histogram2D(x, y, []Bins{NumBins(2)}, nil, nil, false)
+	if !hist.Equals(nd.Array(1, 1, 0, 2).Reshape(2, 2)) {
+		t.Error("Expected [[1, 1], [0, 2]], got ", hist)
+	}
+	if !xedges.Equals(nd.Array(0, 1, 2)) || !yedges.Equals(nd.Array(0, 1, 2)) {
+		t.Error("Expected edges [0, 1, 2], got ", xedges, yedges)
+	}
+
+	hist, _, _ = Histogram2D(x, y, []Bins{NumBins(1), BinEdges{0, 1, 2, 3}}, nil, nil, false)
+	if !hist.Equals(nd.Array(1, 0, 3).Reshape(1, 3)) {
+		t.Error("Expected [[1, 0, 3]], got ", hist)
+	}
+}
+
+func TestHistogramDD(t *testing.T) {
+	sample := nd.Array(0, 0, 0, 1, 1, 1, 1, 0, 1).Reshape(3, 3)
+
+	hist, edges := HistogramDD(sample, []Bins{NumBins(2)}, [][]float64{{0, 1}, {0, 1}, {0, 1}}, nil, true)
+	if len(edges) != 3 || hist.Size() != 8 || hist.NDims() != 3 {
+		t.Error("Expected a [2, 2, 2] histogram, got ", hist.Shape())
+	}
+	//each of the 3 occupied cells has volume 1/8
+	if hist.Get(0, 0, 0) != 8.0/3 || hist.Get(1, 1, 1) != 8.0/3 || hist.Get(1, 0, 1) != 8.0/3 {
+		t.Error("Expected 8/3 in the occupied cells, got ", hist)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	HistogramDD(sample, []Bins{NumBins(2), NumBins(2)}, nil, nil, false)
+}
+
+func TestDigitize(t *testing.T) {
+	x := nd.Array(-1, 0, 0.5, 1, 2.5, 4)
+	bins := nd.Array(0, 1, 2, 3)
+
+	if !Digitize(x, bins, false).Equals(nd.Array(0, 1, 1, 2, 3, 4)) {
+		t.Error("Expected [0, 1, 1, 2, 3, 4], got ", Digitize(x, bins, false))
+	}
+	if !Digitize(x, bins, true).Equals(nd.Array(0, 0, 1, 1, 3, 4)) {
+		t.Error("Expected [0, 0, 1, 1, 3, 4], got ", Digitize(x, bins, true))
+	}
+
+	reversed := nd.Array(3, 2, 1, 0)
+	if !Digitize(x, reversed, false).Equals(nd.Array(4, 3, 3, 2, 1, 0)) {
+		t.Error("Expected [4, 3, 3, 2, 1, 0], got ", Digitize(x, reversed, false))
+	}
+	if !Digitize(x, reversed, true).Equals(nd.Array(4, 4, 3, 3, 1, 0)) {
+		t.Error("Expected [4, 4, 3, 3, 1, 0], got ", Digitize(x, reversed, true))
+	}
+}
+
+func TestBincount(t *testing.T) {
+	x := nd.Array(0, 1, 1, 3)
+
+	if !Bincount(x, nil, 0).Equals(nd.Array(1, 2, 0, 1)) {
+		t.Error("Expected [1, 2, 0, 1], got ", Bincount(x, nil, 0))
+	}
+	if !Bincount(x, nd.Array(0.5, 1, 2, 1), 6).Equals(nd.Array(0.5, 3, 0, 1, 0, 0)) {
+		t.Error("Expected [0.5, 3, 0, 1, 0, 0], got ", Bincount(x, nd.Array(0.5, 1, 2, 1), 6))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for a negative value")
+		}
+	}()
+	Bincount(nd.Array(-1), nil, 0)
+}
@@ -0,0 +1,37 @@
+package hypothesis
+
+import (
+	"github.com/ledao/ndarray/nd"
+)
+
+//One way analysis of variance, of the null hypothesis that all groups have the same mean.
+//The statistic is F, the variance between the group means over the variance within the groups,
+//with k - 1 and N - k degrees of freedom for k groups of N elements in total.
+func ANOVAOneWay(groups ...*nd.NdArray) Result {
+	k := len(groups)
+	if k < 2 {
+		panic("at least two groups are needed")
+	}
+
+	sizes, means := make([]float64, k), make([]float64, k)
+	n, sum, within := 0.0, 0.0, 0.0
+	for i, g := range groups {
+		size, mean, v := describe(g)
+		sizes[i], means[i] = size, mean
+		n += size
+		sum += size * mean
+		if size > 1 {
+			within += (size - 1) * v
+		}
+	}
+	grand := sum / n
+
+	between := 0.0
+	for i := range groups {
+		between += sizes[i] * (means[i] - grand) * (means[i] - grand)
+	}
+
+	d1, d2 := float64(k-1), n-float64(k)
+	f := (between / d1) / (within / d2)
+	return Result{Statistic: f, PValue: fSurvival(f, d1, d2), DF: d1, DF2: d2}
+}
@@ -0,0 +1,20 @@
+package hypothesis
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestANOVAOneWay(t *testing.T) {
+	r := ANOVAOneWay(nd.Array(4.2, 4.8, 5.1, 4.5), nd.Array(5.9, 6.3, 5.5, 6.1, 5.8), nd.Array(4.9, 5.2, 5.6))
+	if !near(r.Statistic, 15.2914058, 1e-6) || r.DF != 2 || r.DF2 != 9 || !near(r.PValue, 0.0012744, 1e-6) {
+		t.Error("Expected F 15.2914058, df 2 and 9, p 0.0012744, got ", r)
+	}
+
+	//groups with equal means
+	r = ANOVAOneWay(nd.Array(1, 2, 3), nd.Array(3, 2, 1))
+	if r.Statistic != 0 || r.PValue != 1 {
+		t.Error("Expected F 0, p 1, got ", r)
+	}
+}
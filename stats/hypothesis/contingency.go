@@ -0,0 +1,56 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/stats/dist"
+)
+
+//Chi-square test of independence of the rows and columns of the 2d contingency table observed.
+//The expected frequencies are the products of the row and column sums over the total,
+//with (rows - 1)(columns - 1) degrees of freedom. If correction is true and there is one
+//degree of freedom, Yates' correction moves every observed frequency 1/2 towards its expected one.
+func ChiSquareContingency(observed *nd.NdArray, correction bool) Result {
+	if observed.NDims() != 2 {
+		panic("shape error")
+	}
+	rows, cols := observed.Shape()[0], observed.Shape()[1]
+
+	rowSums, colSums := make([]float64, rows), make([]float64, cols)
+	total := 0.0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			v := observed.Get(i, j)
+			if v < 0 {
+				panic("observed frequencies must be non negative")
+			}
+			rowSums[i] += v
+			colSums[j] += v
+			total += v
+		}
+	}
+
+	df := float64((rows - 1) * (cols - 1))
+	chi2 := 0.0
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			expected := rowSums[i] * colSums[j] / total
+			if expected == 0 {
+				panic("expected frequency of 0 in the contingency table")
+			}
+			diff := observed.Get(i, j) - expected
+			if correction && df == 1 {
+				diff = math.Copysign(math.Max(0, math.Abs(diff)-0.5), diff)
+			}
+			chi2 += diff * diff / expected
+		}
+	}
+
+	p := 1.0
+	if df > 0 {
+		p = dist.RegIncGammaUpper(df/2, chi2/2)
+	}
+
+	return Result{Statistic: chi2, PValue: p, DF: df}
+}
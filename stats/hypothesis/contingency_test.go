@@ -0,0 +1,26 @@
+package hypothesis
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestChiSquareContingency(t *testing.T) {
+	observed := nd.Array(10, 20, 30, 25).Reshape(2, 2)
+
+	r := ChiSquareContingency(observed, true)
+	if !near(r.Statistic, 2.7061553, 1e-6) || r.DF != 1 || !near(r.PValue, 0.0999616, 1e-6) {
+		t.Error("Expected chi2 2.7061553, df 1, p 0.0999616, got ", r)
+	}
+	r = ChiSquareContingency(observed, false)
+	if !near(r.Statistic, 3.5058923, 1e-6) || !near(r.PValue, 0.0611509, 1e-6) {
+		t.Error("Expected chi2 3.5058923, p 0.0611509, got ", r)
+	}
+
+	//the correction only applies to one degree of freedom
+	r = ChiSquareContingency(nd.Array(12, 5, 9, 7, 14, 6).Reshape(2, 3), true)
+	if !near(r.Statistic, 6.1622732, 1e-6) || r.DF != 2 || !near(r.PValue, 0.0459070, 1e-6) {
+		t.Error("Expected chi2 6.1622732, df 2, p 0.0459070, got ", r)
+	}
+}
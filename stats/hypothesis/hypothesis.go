@@ -0,0 +1,94 @@
+//Package hypothesis implements statistical hypothesis tests on samples given as *nd.NdArray.
+//Samples are flattened, every test returns a Result.
+package hypothesis
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/stats"
+	"github.com/ledao/ndarray/stats/dist"
+)
+
+//Outcome of a hypothesis test.
+type Result struct {
+	Statistic float64
+	PValue    float64
+	//degrees of freedom of the reference distribution, 0 when it has none
+	DF float64
+	//denominator degrees of freedom of an F statistic, otherwise 0
+	DF2 float64
+}
+
+//The alternative hypothesis, against the null hypothesis of no effect.
+type Alternative int
+
+const (
+	//the statistic differs from its null value in either direction
+	TwoSided Alternative = iota
+	//the first sample is stochastically smaller, or its mean is below the tested mean
+	Less
+	//the first sample is stochastically greater, or its mean is above the tested mean
+	Greater
+)
+
+//p-value of t for a Student's t distribution with df degrees of freedom.
+func tPValue(t, df float64, alt Alternative) float64 {
+	if math.IsNaN(t) {
+		return math.NaN()
+	}
+	//the probability of a value beyond |t| on one side
+	tail := 0.0
+	if !math.IsInf(t, 0) {
+		tail = 0.5 * dist.RegIncBeta(df/2, 0.5, df/(df+t*t))
+	}
+
+	return sidedPValue(t, tail, alt)
+}
+
+//p-value of z for the standard normal distribution.
+func zPValue(z float64, alt Alternative) float64 {
+	if math.IsNaN(z) {
+		return math.NaN()
+	}
+
+	return sidedPValue(z, 0.5*math.Erfc(math.Abs(z)/math.Sqrt2), alt)
+}
+
+//p-value of a statistic x symmetric around 0 whose one sided tail beyond |x| has probability tail.
+func sidedPValue(x, tail float64, alt Alternative) float64 {
+	switch alt {
+	case TwoSided:
+		return 2 * tail
+	case Less:
+		if x > 0 {
+			return 1 - tail
+		}
+		return tail
+	case Greater:
+		if x < 0 {
+			return 1 - tail
+		}
+		return tail
+	default:
+		panic(fmt.Errorf("unknown alternative: %v", alt))
+	}
+}
+
+//probability that an F(d1, d2) variable exceeds f.
+func fSurvival(f, d1, d2 float64) float64 {
+	if math.IsNaN(f) {
+		return math.NaN()
+	}
+	if f <= 0 {
+		return 1
+	}
+
+	return dist.RegIncBeta(d2/2, d1/2, d2/(d2+d1*f))
+}
+
+//size, mean and unbiased variance of the elements of a.
+func describe(a *nd.NdArray) (float64, float64, float64) {
+	return float64(a.Size()), stats.Average(a, nil, stats.AllAxes).Get(0), stats.VarDdof(a, stats.AllAxes, 1).Get(0)
+}
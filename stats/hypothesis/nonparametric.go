@@ -0,0 +1,233 @@
+package hypothesis
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/stats"
+)
+
+//Mann-Whitney U test of the null hypothesis that x and y come from the same distribution.
+//The statistic is the U of x: the number of pairs with the x element greater, ties counting 1/2.
+//Without ties and with both samples of at most 8 elements the p-value is exact,
+//otherwise it uses the normal approximation with tie and continuity corrections.
+func MannWhitneyU(x, y *nd.NdArray, alt Alternative) Result {
+	n1, n2 := x.Size(), y.Size()
+	pooled := append(append([]float64(nil), x.Values()...), y.Values()...)
+	ranks := stats.Rank(nd.Array(pooled...)).Values()
+	r1 := 0.0
+	for _, r := range ranks[:n1] {
+		r1 += r
+	}
+	u1 := r1 - float64(n1*(n1+1))/2
+
+	ties := tieTerm(ranks)
+	var p float64
+	if ties == 0 && n1 <= 8 && n2 <= 8 {
+		p = exactPValue(mannWhitneyCounts(n1, n2), int(u1), alt)
+	} else {
+		n := float64(n1 + n2)
+		mu := float64(n1*n2) / 2
+		sigma := math.Sqrt(float64(n1*n2) / 12 * (n + 1 - ties/(n*(n-1))))
+		//the continuity correction moves the statistic 1/2 towards the mean
+		d := u1 - mu
+		switch alt {
+		case Greater:
+			d -= 0.5
+		case Less:
+			d += 0.5
+		default:
+			d = math.Max(0, math.Abs(d)-0.5)
+		}
+		p = math.Min(1, zPValue(d/sigma, alt))
+	}
+
+	return Result{Statistic: u1, PValue: p}
+}
+
+//Wilcoxon signed-rank test of the null hypothesis that the differences x - y are symmetric around 0.
+//y is nil to test x itself. Zero differences are discarded.
+//The statistic is the sum of the ranks of the positive differences for a one sided test,
+//and the smaller of the positive and negative rank sums for a two sided test.
+//Without ties and with at most 50 differences the p-value is exact,
+//otherwise it uses the normal approximation with tie correction.
+func WilcoxonSignedRank(x, y *nd.NdArray, alt Alternative) Result {
+	d := x.Flat().Clone()
+	if y != nil {
+		if y.Size() != x.Size() {
+			panic("shape error")
+		}
+		d = d.Sub(y.Flat())
+	}
+	var diffs, abs []float64
+	for _, v := range d.Values() {
+		if v != 0 {
+			diffs = append(diffs, v)
+			abs = append(abs, math.Abs(v))
+		}
+	}
+
+	n := len(diffs)
+	ranks := stats.Rank(nd.Array(abs...)).Values()
+	wPlus := 0.0
+	for i, v := range diffs {
+		if v > 0 {
+			wPlus += ranks[i]
+		}
+	}
+	total := float64(n*(n+1)) / 2
+	statistic := wPlus
+	if alt == TwoSided {
+		statistic = math.Min(wPlus, total-wPlus)
+	}
+
+	ties := tieTerm(ranks)
+	var p float64
+	if ties == 0 && n <= 50 {
+		p = exactPValue(signedRankCounts(n), int(wPlus), alt)
+	} else {
+		mu := total / 2
+		sigma := math.Sqrt(float64(n*(n+1)*(2*n+1))/24 - ties/48)
+		p = zPValue((wPlus-mu)/sigma, alt)
+	}
+
+	return Result{Statistic: statistic, PValue: p}
+}
+
+//Two sample Kolmogorov-Smirnov test of the null hypothesis that a and b come from the same continuous distribution.
+//The statistic is the largest distance between the two empirical distribution functions.
+//The two sided p-value uses the asymptotic Kolmogorov distribution with Stephens' small sample correction.
+func KSTest2Samp(a, b *nd.NdArray) Result {
+	x := append([]float64(nil), a.Values()...)
+	y := append([]float64(nil), b.Values()...)
+	sort.Float64s(x)
+	sort.Float64s(y)
+
+	n1, n2 := float64(len(x)), float64(len(y))
+	d := 0.0
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		//step past every element equal to the smallest remaining one
+		v := math.Min(x[i], y[j])
+		for i < len(x) && x[i] == v {
+			i++
+		}
+		for j < len(y) && y[j] == v {
+			j++
+		}
+		d = math.Max(d, math.Abs(float64(i)/n1-float64(j)/n2))
+	}
+
+	en := math.Sqrt(n1 * n2 / (n1 + n2))
+	return Result{Statistic: d, PValue: kolmogorovSurvival((en + 0.12 + 0.11/en) * d)}
+}
+
+//probability that the Kolmogorov distribution exceeds lambda.
+func kolmogorovSurvival(lambda float64) float64 {
+	if lambda <= 0 {
+		return 1
+	}
+
+	sum := 0.0
+	if lambda < 1.18 {
+		//the Jacobi theta form converges quickly for small lambda
+		for j := 1; j <= 20; j++ {
+			k := float64(2*j - 1)
+			sum += math.Exp(-k * k * math.Pi * math.Pi / (8 * lambda * lambda))
+		}
+		return 1 - math.Sqrt(2*math.Pi)/lambda*sum
+	}
+	for j := 1; j <= 100; j++ {
+		sign := 1.0
+		if j%2 == 0 {
+			sign = -1
+		}
+		sum += sign * math.Exp(-2*float64(j*j)*lambda*lambda)
+	}
+
+	return math.Max(0, math.Min(1, 2*sum))
+}
+
+//sum of t^3 - t over the groups of t tied ranks.
+func tieTerm(ranks []float64) float64 {
+	sorted := append([]float64(nil), ranks...)
+	sort.Float64s(sorted)
+
+	sum := 0.0
+	for i := 0; i < len(sorted); {
+		j := i
+		for j < len(sorted) && sorted[j] == sorted[i] {
+			j++
+		}
+		t := float64(j - i)
+		sum += t*t*t - t
+		i = j
+	}
+
+	return sum
+}
+
+//p-value of the integer statistic s whose null distribution is given by counts, symmetric around its mean.
+func exactPValue(counts []float64, s int, alt Alternative) float64 {
+	total, below, above := 0.0, 0.0, 0.0
+	for v, c := range counts {
+		total += c
+		if v <= s {
+			below += c
+		}
+		if v >= s {
+			above += c
+		}
+	}
+
+	switch alt {
+	case Less:
+		return below / total
+	case Greater:
+		return above / total
+	default:
+		return math.Min(1, 2*math.Min(below, above)/total)
+	}
+}
+
+//counts[u] is the number of ways to interleave n1 and n2 distinct elements so that U = u.
+func mannWhitneyCounts(n1, n2 int) []float64 {
+	//prev[j] and cur[j] hold the counts for samples of sizes i-1 and i, and j.
+	//They are built by removing the largest element: if it is in the first sample
+	//it exceeds all j elements of the second, otherwise it adds nothing to U.
+	prev := make([][]float64, n2+1)
+	for j := range prev {
+		prev[j] = []float64{1}
+	}
+	for i := 1; i <= n1; i++ {
+		cur := make([][]float64, n2+1)
+		cur[0] = []float64{1}
+		for j := 1; j <= n2; j++ {
+			counts := make([]float64, i*j+1)
+			for u, c := range prev[j] {
+				counts[u+j] += c
+			}
+			for u, c := range cur[j-1] {
+				counts[u] += c
+			}
+			cur[j] = counts
+		}
+		prev = cur
+	}
+
+	return prev[n2]
+}
+
+//counts[w] is the number of subsets of the ranks 1..n summing to w.
+func signedRankCounts(n int) []float64 {
+	counts := make([]float64, n*(n+1)/2+1)
+	counts[0] = 1
+	for r := 1; r <= n; r++ {
+		for w := len(counts) - 1; w >= r; w-- {
+			counts[w] += counts[w-r]
+		}
+	}
+
+	return counts
+}
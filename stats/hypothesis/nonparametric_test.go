@@ -0,0 +1,84 @@
+package hypothesis
+
+import (
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestMannWhitneyU(t *testing.T) {
+	x := nd.Array(1.1, 3.4, 2.2, 5.9, 4.0)
+	y := nd.Array(6.1, 3.9, 7.2, 8.8, 5.0, 9.3)
+
+	//exact, the distribution of U enumerated
+	r := MannWhitneyU(x, y, TwoSided)
+	if r.Statistic != 3 || !near(r.PValue, 2.0/66, 1e-12) {
+		t.Error("Expected U 3, p 0.0303030, got ", r)
+	}
+	if r = MannWhitneyU(x, y, Less); !near(r.PValue, 1.0/66, 1e-12) {
+		t.Error("Expected p 0.0151515, got ", r.PValue)
+	}
+	if r = MannWhitneyU(x, y, Greater); !near(r.PValue, 458.0/462, 1e-12) {
+		t.Error("Expected p 0.9913420, got ", r.PValue)
+	}
+
+	//normal approximation with ties
+	x = nd.Array(1, 2, 2, 3, 4, 5, 5, 6, 7, 9)
+	y = nd.Array(3, 4, 5, 6, 8, 8, 9, 10, 11, 12)
+	r = MannWhitneyU(x, y, TwoSided)
+	if r.Statistic != 21 || !near(r.PValue, 0.0305764, 1e-6) {
+		t.Error("Expected U 21, p 0.0305764, got ", r)
+	}
+	if r = MannWhitneyU(x, y, Less); !near(r.PValue, 0.0152882, 1e-6) {
+		t.Error("Expected p 0.0152882, got ", r.PValue)
+	}
+
+	//a large sample uses the normal approximation even with a small one
+	x = nd.Array(0.5, 1000.5, 2000.5)
+	y = nd.Arange(5000)
+	r = MannWhitneyU(x, y, TwoSided)
+	if r.Statistic != 3003 || !near(r.PValue, 0.0721959, 1e-6) {
+		t.Error("Expected U 3003, p 0.0721959, got ", r)
+	}
+}
+
+func TestWilcoxonSignedRank(t *testing.T) {
+	d := nd.Array(1.5, -0.3, 2.8, 0.9, -1.2, 3.3, 2.1, 0.4)
+
+	r := WilcoxonSignedRank(d, nil, TwoSided)
+	if r.Statistic != 5 || !near(r.PValue, 0.078125, 1e-12) {
+		t.Error("Expected W 5, p 0.078125, got ", r)
+	}
+	r = WilcoxonSignedRank(d, nil, Greater)
+	if r.Statistic != 31 || !near(r.PValue, 0.0390625, 1e-12) {
+		t.Error("Expected W 31, p 0.0390625, got ", r)
+	}
+
+	//zero differences are discarded
+	x := nd.Array(1.5, -0.3, 2.8, 0.9, -1.2, 3.3, 2.1, 0.4, 7).Add(nd.Array(1))
+	y := nd.Ones(9).Add(nd.Array(0, 0, 0, 0, 0, 0, 0, 0, 7))
+	if r = WilcoxonSignedRank(x, y, TwoSided); r.Statistic != 5 {
+		t.Error("Expected W 5, got ", r.Statistic)
+	}
+}
+
+func TestKSTest2Samp(t *testing.T) {
+	a := nd.Array(0.61, 0.29, 0.06, 0.59, -1.73, -0.74, 0.51, -0.56, 0.39, 1.64, 0.05, -0.06, 0.64,
+		-0.82, 0.37, 1.77, 1.09, -1.28, 2.36, 1.31, 1.05, -0.32, -0.4, 1.06, -2.47)
+	b := nd.Array(2.2, 1.66, 1.38, 0.2, 0.36, 0, 0.96, 1.56, 0.44, 1.5, -0.3, 0.66, 2.31, 3.29,
+		-0.27, -0.37, 0.38, 0.7, 0.52, -0.71)
+
+	r := KSTest2Samp(a, b)
+	if !near(r.Statistic, 0.23, 1e-12) || !near(r.PValue, 0.5411246, 1e-6) {
+		t.Error("Expected D 0.23, p 0.5411246, got ", r)
+	}
+
+	//the 5% critical value of the Kolmogorov distribution
+	if !near(kolmogorovSurvival(1.3581), 0.05, 1e-4) {
+		t.Error("Expected 0.05, got ", kolmogorovSurvival(1.3581))
+	}
+	//the two series agree where they are switched
+	if !near(kolmogorovSurvival(1.18-1e-9), kolmogorovSurvival(1.18), 1e-8) {
+		t.Error("Expected a continuous survival function, got ", kolmogorovSurvival(1.18-1e-9), kolmogorovSurvival(1.18))
+	}
+}
@@ -0,0 +1,46 @@
+package hypothesis
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Two sample t-test of the null hypothesis that a and b have the same mean.
+//If equalVar is true it is Student's test with pooled variance, otherwise Welch's test
+//whose degrees of freedom come from the Welch-Satterthwaite equation.
+func TTestInd(a, b *nd.NdArray, equalVar bool, alt Alternative) Result {
+	n1, m1, v1 := describe(a)
+	n2, m2, v2 := describe(b)
+
+	var se2, df float64
+	if equalVar {
+		df = n1 + n2 - 2
+		pooled := ((n1-1)*v1 + (n2-1)*v2) / df
+		se2 = pooled * (1/n1 + 1/n2)
+	} else {
+		s1, s2 := v1/n1, v2/n2
+		se2 = s1 + s2
+		df = se2 * se2 / (s1*s1/(n1-1) + s2*s2/(n2-1))
+	}
+
+	t := (m1 - m2) / math.Sqrt(se2)
+	return Result{Statistic: t, PValue: tPValue(t, df, alt), DF: df}
+}
+
+//Paired t-test of the null hypothesis that the differences a - b have mean 0.
+func TTestRel(a, b *nd.NdArray, alt Alternative) Result {
+	if a.Size() != b.Size() {
+		panic("shape error")
+	}
+
+	return TTest1Samp(a.Flat().Sub(b.Flat()), 0, alt)
+}
+
+//One sample t-test of the null hypothesis that the mean of a is popmean.
+func TTest1Samp(a *nd.NdArray, popmean float64, alt Alternative) Result {
+	n, m, v := describe(a)
+	t := (m - popmean) / math.Sqrt(v/n)
+
+	return Result{Statistic: t, PValue: tPValue(t, n-1, alt), DF: n - 1}
+}
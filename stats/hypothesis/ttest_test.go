@@ -0,0 +1,65 @@
+package hypothesis
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+var (
+	sampleA = nd.Array(19.1, 21.3, 18.7, 22.4, 20.0, 23.5, 17.9, 21.1)
+	sampleB = nd.Array(22.0, 24.3, 21.8, 25.1, 23.9, 20.7, 26.2)
+)
+
+//near reports whether got is within tol of expected.
+func near(got, expected, tol float64) bool {
+	return math.Abs(got-expected) <= tol
+}
+
+func TestTTestInd(t *testing.T) {
+	r := TTestInd(sampleA, sampleB, true, TwoSided)
+	if !near(r.Statistic, -2.9023489, 1e-6) || r.DF != 13 || !near(r.PValue, 0.0123521, 1e-6) {
+		t.Error("Expected t -2.9023489, df 13, p 0.0123521, got ", r)
+	}
+	r = TTestInd(sampleA, sampleB, true, Less)
+	if !near(r.PValue, 0.0061760, 1e-6) {
+		t.Error("Expected p 0.0061760, got ", r.PValue)
+	}
+	r = TTestInd(sampleA, sampleB, true, Greater)
+	if !near(r.PValue, 1-0.0061760, 1e-6) {
+		t.Error("Expected p 0.9938240, got ", r.PValue)
+	}
+
+	r = TTestInd(sampleA, sampleB, false, TwoSided)
+	if !near(r.Statistic, -2.8956892, 1e-6) || !near(r.DF, 12.6098717, 1e-6) || !near(r.PValue, 0.0128504, 1e-6) {
+		t.Error("Expected t -2.8956892, df 12.6098717, p 0.0128504, got ", r)
+	}
+}
+
+func TestTTestRel(t *testing.T) {
+	a := nd.Array(sampleA.Values()[:7]...)
+	r := TTestRel(a, sampleB, TwoSided)
+	if !near(r.Statistic, -2.4680473, 1e-6) || r.DF != 6 || !near(r.PValue, 0.0485862, 1e-6) {
+		t.Error("Expected t -2.4680473, df 6, p 0.0485862, got ", r)
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	TTestRel(sampleA, sampleB, TwoSided)
+}
+
+func TestTTest1Samp(t *testing.T) {
+	r := TTest1Samp(sampleA, 20, TwoSided)
+	if !near(r.Statistic, 0.7363527, 1e-6) || r.DF != 7 || !near(r.PValue, 0.4854552, 1e-6) {
+		t.Error("Expected t 0.7363527, df 7, p 0.4854552, got ", r)
+	}
+	r = TTest1Samp(sampleA, 20, Greater)
+	if !near(r.PValue, 0.2427276, 1e-6) {
+		t.Error("Expected p 0.2427276, got ", r.PValue)
+	}
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Variance along axis, or of all elements when axis < 0, divided by N - ddof.
+//ddof = 0 gives the population variance, ddof = 1 the unbiased sample variance.
+//It is computed with the corrected two-pass algorithm, which is stable for data with a large mean.
+func VarDdof(a *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		out[0] = laneVar(lane, nil, float64(ddof))
+	})
+}
+
+//Standard deviation along axis, the square root of VarDdof.
+func StdDdof(a *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	return VarDdof(a, axis, ddof).Map(math.Sqrt)
+}
+
+//Weighted mean along axis, or of all elements when axis < 0.
+//weights is nil for equal weights, otherwise it has the shape of a,
+//or is a 1d array with one weight per element along axis.
+func Average(a *nd.NdArray, weights *nd.NdArray, axis int) *nd.NdArray {
+	if weights == nil {
+		return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+			out[0] = laneMean(lane, nil)
+		})
+	}
+
+	w := broadcastWeights(a, weights, axis)
+	return alongAxisOf([]*nd.NdArray{a, w}, axis, 1, func(lanes [][]float64, out []float64) {
+		out[0] = laneMean(lanes[0], lanes[1])
+	})
+}
+
+//Weighted variance along axis, or of all elements when axis < 0.
+//The weights are frequency weights, with the shapes accepted by Average:
+//the sum of w (x - Average(x))^2 is divided by sum(w) - ddof.
+func WeightedVar(a *nd.NdArray, weights *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	if weights == nil {
+		return VarDdof(a, axis, ddof)
+	}
+
+	w := broadcastWeights(a, weights, axis)
+	return alongAxisOf([]*nd.NdArray{a, w}, axis, 1, func(lanes [][]float64, out []float64) {
+		out[0] = laneVar(lanes[0], lanes[1], float64(ddof))
+	})
+}
+
+//Weighted standard deviation, the square root of WeightedVar.
+func WeightedStd(a *nd.NdArray, weights *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	return WeightedVar(a, weights, axis, ddof).Map(math.Sqrt)
+}
+
+//weights with the shape of a, 1d weights are repeated along axis.
+func broadcastWeights(a *nd.NdArray, weights *nd.NdArray, axis int) *nd.NdArray {
+	shape := a.Shape()
+	if weights.NDims() == len(shape) {
+		return weights
+	}
+	if weights.NDims() != 1 || axis < 0 || axis >= len(shape) || weights.Size() != shape[axis] {
+		panic("shape error")
+	}
+
+	w := nd.Zeros(shape...)
+	values, wv := w.Values(), weights.Values()
+	inner := 1
+	for _, s := range shape[axis+1:] {
+		inner *= s
+	}
+	for i := range values {
+		values[i] = wv[(i/inner)%shape[axis]]
+	}
+
+	return w
+}
+
+//weighted mean of lane, w is nil for equal weights. An empty lane without weights has mean NaN.
+func laneMean(lane []float64, w []float64) float64 {
+	if len(lane) == 0 && w == nil {
+		return math.NaN()
+	}
+	sum, wsum := 0.0, 0.0
+	for i, v := range lane {
+		if w == nil {
+			sum += v
+			wsum++
+		} else {
+			sum += w[i] * v
+			wsum += w[i]
+		}
+	}
+	if wsum == 0 {
+		panic("weights sum to zero")
+	}
+
+	return sum / wsum
+}
+
+//corrected two-pass weighted variance of lane, divided by sum(w) - ddof.
+func laneVar(lane []float64, w []float64, ddof float64) float64 {
+	mean := laneMean(lane, w)
+	ss, comp, wsum := 0.0, 0.0, 0.0
+	for i, v := range lane {
+		wi := 1.0
+		if w != nil {
+			wi = w[i]
+		}
+		d := v - mean
+		ss += wi * d * d
+		comp += wi * d
+		wsum += wi
+	}
+	if wsum-ddof <= 0 {
+		return math.NaN()
+	}
+
+	return (ss - comp*comp/wsum) / (wsum - ddof)
+}
@@ -0,0 +1,98 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestVarDdof(t *testing.T) {
+	a := nd.Array(2, 3, 1, 4)
+
+	if !VarDdof(a, 0, 0).Equals(nd.Array(1.25)) {
+		t.Error("Expected [1.25], got ", VarDdof(a, 0, 0))
+	}
+	if !VarDdof(a, 0, 1).Equals(nd.Array(5.0 / 3)) {
+		t.Error("Expected [1.6666667], got ", VarDdof(a, 0, 1))
+	}
+	if !StdDdof(a, 0, 1).Equals(nd.Array(math.Sqrt(5.0 / 3))) {
+		t.Error("Expected [1.2909944], got ", StdDdof(a, 0, 1))
+	}
+
+	m := nd.Array(1, 2, 3, 5, 7, 9).Reshape(2, 3)
+	if !VarDdof(m, 0, 1).Equals(nd.Array(8, 12.5, 18)) {
+		t.Error("Expected [8, 12.5, 18], got ", VarDdof(m, 0, 1))
+	}
+	if !VarDdof(m, AllAxes, 0).Equals(Var(m.Flat())) {
+		t.Error("Expected ", Var(m.Flat()), ", got ", VarDdof(m, AllAxes, 0))
+	}
+
+	if !math.IsNaN(VarDdof(nd.Array(1), 0, 1).Get(0)) {
+		t.Error("Expected NaN, got ", VarDdof(nd.Array(1), 0, 1))
+	}
+
+	//empty input without weights
+	empty := nd.Array()
+	for _, v := range []*nd.NdArray{Var(empty), Std(empty), VarDdof(empty, 0, 1), Average(empty, nil, 0)} {
+		if v.Size() != 1 || !math.IsNaN(v.Get(0)) {
+			t.Error("Expected [NaN], got ", v)
+		}
+	}
+}
+
+func TestVarStable(t *testing.T) {
+	//a naive sum of squares loses every digit here
+	a := nd.Array(1e9+4, 1e9+7, 1e9+13, 1e9+16)
+
+	if !VarDdof(a, 0, 1).Equals(nd.Array(30)) {
+		t.Error("Expected [30], got ", VarDdof(a, 0, 1))
+	}
+}
+
+func TestAverage(t *testing.T) {
+	a := nd.Array(1, 2, 3, 4).Reshape(2, 2)
+
+	if !Average(a, nil, 0).Equals(nd.Array(2, 3)) {
+		t.Error("Expected [2, 3], got ", Average(a, nil, 0))
+	}
+	if !Average(a, nd.Array(3, 1), 1).Equals(nd.Array(1.25, 3.25)) {
+		t.Error("Expected [1.25, 3.25], got ", Average(a, nd.Array(3, 1), 1))
+	}
+	if !Average(a, nd.Array(1, 0, 0, 1).Reshape(2, 2), AllAxes).Equals(nd.Array(2.5)) {
+		t.Error("Expected [2.5], got ", Average(a, nd.Array(1, 0, 0, 1).Reshape(2, 2), AllAxes))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "weights sum to zero" {
+			t.Error("Expected 'weights sum to zero', got ", p)
+		}
+	}()
+	Average(a, nd.Array(0, 0), 0)
+}
+
+func TestWeightedVar(t *testing.T) {
+	//frequency weights give the same result as repeated data
+	a := nd.Array(1, 2, 4)
+	w := nd.Array(2, 1, 3)
+	repeated := nd.Array(1, 1, 2, 4, 4, 4)
+
+	if !WeightedVar(a, w, 0, 1).Equals(VarDdof(repeated, 0, 1)) {
+		t.Error("Expected ", VarDdof(repeated, 0, 1), ", got ", WeightedVar(a, w, 0, 1))
+	}
+	if !WeightedStd(a, w, 0, 0).Equals(Std(repeated)) {
+		t.Error("Expected ", Std(repeated), ", got ", WeightedStd(a, w, 0, 0))
+	}
+	if !WeightedVar(a, nil, 0, 0).Equals(Var(a)) {
+		t.Error("Expected ", Var(a), ", got ", WeightedVar(a, nil, 0, 0))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	WeightedVar(a, nd.Array(1, 2), 0, 0)
+}
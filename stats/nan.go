@@ -0,0 +1,111 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The Nan functions reduce along axis, or over all elements when axis < 0, ignoring NaN elements.
+//A lane made only of NaN gives NaN, except for NanSum which gives 0.
+
+//Number of NaN elements along axis.
+func CountNaN(a *nd.NdArray, axis int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		out[0] = float64(len(lane) - len(dropNaN(lane)))
+	})
+}
+
+//Sum along axis, ignoring NaN.
+func NanSum(a *nd.NdArray, axis int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		sum := 0.0
+		for _, v := range dropNaN(lane) {
+			sum += v
+		}
+		out[0] = sum
+	})
+}
+
+//Mean along axis, ignoring NaN.
+func NanMean(a *nd.NdArray, axis int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		lane = dropNaN(lane)
+		if len(lane) == 0 {
+			out[0] = math.NaN()
+			return
+		}
+		out[0] = laneMean(lane, nil)
+	})
+}
+
+//Variance along axis divided by N - ddof, N being the number of non NaN elements.
+func NanVar(a *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		lane = dropNaN(lane)
+		if len(lane) == 0 {
+			out[0] = math.NaN()
+			return
+		}
+		out[0] = laneVar(lane, nil, float64(ddof))
+	})
+}
+
+//Standard deviation along axis, the square root of NanVar.
+func NanStd(a *nd.NdArray, axis int, ddof int) *nd.NdArray {
+	return NanVar(a, axis, ddof).Map(math.Sqrt)
+}
+
+//Maximum along axis, ignoring NaN.
+func NanMax(a *nd.NdArray, axis int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		lane = dropNaN(lane)
+		out[0] = math.NaN()
+		for i, v := range lane {
+			if i == 0 || v > out[0] {
+				out[0] = v
+			}
+		}
+	})
+}
+
+//Minimum along axis, ignoring NaN.
+func NanMin(a *nd.NdArray, axis int) *nd.NdArray {
+	return alongAxis(a, axis, 1, func(lane []float64, out []float64) {
+		lane = dropNaN(lane)
+		out[0] = math.NaN()
+		for i, v := range lane {
+			if i == 0 || v < out[0] {
+				out[0] = v
+			}
+		}
+	})
+}
+
+//Median along axis, ignoring NaN.
+func NanMedian(a *nd.NdArray, axis int) *nd.NdArray {
+	return NanQuantile(a, nd.Array(0.5), axis, Linear)
+}
+
+//Quantiles along axis ignoring NaN, see Quantile.
+func NanQuantile(a *nd.NdArray, q *nd.NdArray, axis int, method QuantileMethod) *nd.NdArray {
+	qs := q.Values()
+	checkQuantiles(qs)
+
+	return alongAxis(a, axis, len(qs), func(lane []float64, out []float64) {
+		quantiles(dropNaN(lane), qs, method, out)
+	})
+}
+
+//the elements of lane which are not NaN, moved to its beginning.
+func dropNaN(lane []float64) []float64 {
+	n := 0
+	for _, v := range lane {
+		if !math.IsNaN(v) {
+			lane[n] = v
+			n++
+		}
+	}
+
+	return lane[:n]
+}
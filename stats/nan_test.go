@@ -0,0 +1,76 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+var nan = math.NaN()
+
+func TestCountNaN(t *testing.T) {
+	a := nd.Array(1, nan, 3, nan, nan, 6).Reshape(2, 3)
+
+	if !CountNaN(a, 1).Equals(nd.Array(1, 2)) {
+		t.Error("Expected [1, 2], got ", CountNaN(a, 1))
+	}
+	if !CountNaN(a, AllAxes).Equals(nd.Array(3)) {
+		t.Error("Expected [3], got ", CountNaN(a, AllAxes))
+	}
+}
+
+func TestNanSumMean(t *testing.T) {
+	a := nd.Array(1, nan, 3, nan, nan, nan).Reshape(2, 3)
+
+	if !NanSum(a, 1).Equals(nd.Array(4, 0)) {
+		t.Error("Expected [4, 0], got ", NanSum(a, 1))
+	}
+
+	mean := NanMean(a, 1)
+	if mean.Get(0) != 2 || !math.IsNaN(mean.Get(1)) {
+		t.Error("Expected [2, NaN], got ", mean)
+	}
+	mean = NanMean(a, 0)
+	if mean.Get(0) != 1 || !math.IsNaN(mean.Get(1)) || mean.Get(2) != 3 {
+		t.Error("Expected [1, NaN, 3], got ", mean)
+	}
+}
+
+func TestNanVarStd(t *testing.T) {
+	a := nd.Array(2, nan, 3, 1, 4, nan)
+
+	if !NanVar(a, 0, 0).Equals(nd.Array(1.25)) {
+		t.Error("Expected [1.25], got ", NanVar(a, 0, 0))
+	}
+	if !NanStd(a, 0, 1).Equals(StdDdof(nd.Array(2, 3, 1, 4), 0, 1)) {
+		t.Error("Expected ", StdDdof(nd.Array(2, 3, 1, 4), 0, 1), ", got ", NanStd(a, 0, 1))
+	}
+}
+
+func TestNanMaxMin(t *testing.T) {
+	a := nd.Array(nan, 5, -1, nan, nan, nan).Reshape(2, 3)
+
+	max := NanMax(a, 1)
+	if max.Get(0) != 5 || !math.IsNaN(max.Get(1)) {
+		t.Error("Expected [5, NaN], got ", max)
+	}
+	min := NanMin(a, 1)
+	if min.Get(0) != -1 || !math.IsNaN(min.Get(1)) {
+		t.Error("Expected [-1, NaN], got ", min)
+	}
+}
+
+func TestNanMedianQuantile(t *testing.T) {
+	a := nd.Array(4, nan, 1, 3, nan, 2)
+
+	if !NanMedian(a, 0).Equals(nd.Array(2.5)) {
+		t.Error("Expected [2.5], got ", NanMedian(a, 0))
+	}
+	if !NanQuantile(a, nd.Array(0, 1), 0, Linear).Equals(nd.Array(1, 4)) {
+		t.Error("Expected [1, 4], got ", NanQuantile(a, nd.Array(0, 1), 0, Linear))
+	}
+	if !math.IsNaN(NanMedian(nd.Array(nan, nan), 0).Get(0)) {
+		t.Error("Expected NaN, got ", NanMedian(nd.Array(nan, nan), 0))
+	}
+}
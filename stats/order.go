@@ -0,0 +1,159 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//How a quantile falling between two data points i < j is computed.
+type QuantileMethod int
+
+const (
+	//i + (j - i) * fraction
+	Linear QuantileMethod = iota
+	//i
+	Lower
+	//j
+	Higher
+	//i or j, whichever is nearest, ties go to the even index
+	Nearest
+	//(i + j) / 2
+	Midpoint
+)
+
+//Median along axis, see Quantile.
+func Median(a *nd.NdArray, axis int) *nd.NdArray {
+	return Quantile(a, nd.Array(0.5), axis, Linear)
+}
+
+//Quantiles q, each in [0, 1], of the elements of a along axis, or of all elements when axis < 0.
+//The result has the shape of a without axis, prepended by q.Size() when there are several q.
+//A lane containing NaN gives NaN.
+//Lanes are not fully sorted, the order statistics are found by selection.
+func Quantile(a *nd.NdArray, q *nd.NdArray, axis int, method QuantileMethod) *nd.NdArray {
+	qs := q.Values()
+	checkQuantiles(qs)
+
+	return alongAxis(a, axis, len(qs), func(lane []float64, out []float64) {
+		quantiles(lane, qs, method, out)
+	})
+}
+
+//Percentiles p, each in [0, 100], see Quantile.
+func Percentile(a *nd.NdArray, p *nd.NdArray, axis int, method QuantileMethod) *nd.NdArray {
+	return Quantile(a, p.Div(nd.Array(100)), axis, method)
+}
+
+func checkQuantiles(qs []float64) {
+	for _, v := range qs {
+		if !(v >= 0 && v <= 1) {
+			panic(fmt.Errorf("quantile: %v out of [0, 1]", v))
+		}
+	}
+}
+
+//quantiles qs of lane written to out, lane is reordered.
+func quantiles(lane []float64, qs []float64, method QuantileMethod, out []float64) {
+	n := len(lane)
+	for _, v := range lane {
+		if math.IsNaN(v) {
+			for i := range out {
+				out[i] = math.NaN()
+			}
+			return
+		}
+	}
+	if n == 0 {
+		for i := range out {
+			out[i] = math.NaN()
+		}
+		return
+	}
+
+	for i, q := range qs {
+		pos := q * float64(n-1)
+		lo := int(math.Floor(pos))
+		hi := int(math.Ceil(pos))
+		frac := pos - float64(lo)
+
+		switch method {
+		case Lower:
+			out[i] = selectKth(lane, lo)
+		case Higher:
+			out[i] = selectKth(lane, hi)
+		case Nearest:
+			out[i] = selectKth(lane, int(math.RoundToEven(pos)))
+		case Linear, Midpoint:
+			vlo := selectKth(lane, lo)
+			vhi := vlo
+			if hi != lo {
+				//after selection the elements above lo are all >= lane[lo]
+				vhi = minOf(lane[lo+1:])
+			}
+			if method == Linear {
+				out[i] = vlo + (vhi-vlo)*frac
+			} else {
+				out[i] = (vlo + vhi) / 2
+			}
+		default:
+			panic(fmt.Errorf("unknown quantile method: %v", method))
+		}
+	}
+}
+
+//k-th smallest element of s, by quickselect with median of three pivots.
+//s is partially reordered: s[:k] <= s[k] <= s[k+1:].
+func selectKth(s []float64, k int) float64 {
+	lo, hi := 0, len(s)-1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		//order s[lo], s[mid], s[hi] and use the median as pivot
+		if s[mid] < s[lo] {
+			s[mid], s[lo] = s[lo], s[mid]
+		}
+		if s[hi] < s[lo] {
+			s[hi], s[lo] = s[lo], s[hi]
+		}
+		if s[hi] < s[mid] {
+			s[hi], s[mid] = s[mid], s[hi]
+		}
+		pivot := s[mid]
+
+		i, j := lo, hi
+		for i <= j {
+			for s[i] < pivot {
+				i++
+			}
+			for s[j] > pivot {
+				j--
+			}
+			if i <= j {
+				s[i], s[j] = s[j], s[i]
+				i++
+				j--
+			}
+		}
+		if k <= j {
+			hi = j
+		} else if k >= i {
+			lo = i
+		} else {
+			break
+		}
+	}
+
+	return s[k]
+}
+
+func minOf(s []float64) float64 {
+	min := math.Inf(1)
+	for _, v := range s {
+		if v < min {
+			min = v
+		}
+	}
+
+	return min
+}
@@ -0,0 +1,101 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+	"github.com/ledao/ndarray/util"
+)
+
+func TestMedian(t *testing.T) {
+	a := nd.Array(3, 1, 4, 1, 5)
+
+	if !Median(a, 0).Equals(nd.Array(3)) {
+		t.Error("Expected [3], got ", Median(a, 0))
+	}
+	if !Median(nd.Array(4, 1, 3, 2), AllAxes).Equals(nd.Array(2.5)) {
+		t.Error("Expected [2.5], got ", Median(nd.Array(4, 1, 3, 2), AllAxes))
+	}
+
+	m := nd.Array(1, 9, 2, 8, 3, 7).Reshape(2, 3)
+	if !Median(m, 1).Equals(nd.Array(2, 7)) {
+		t.Error("Expected [2, 7], got ", Median(m, 1))
+	}
+	if !Median(m, 0).Equals(nd.Array(4.5, 6, 4.5)) {
+		t.Error("Expected [4.5, 6, 4.5], got ", Median(m, 0))
+	}
+	if !m.Equals(nd.Array(1, 9, 2, 8, 3, 7).Reshape(2, 3)) {
+		t.Error("Expected the input to be kept, got ", m)
+	}
+
+	if !math.IsNaN(Median(nd.Array(1, math.NaN(), 2), 0).Get(0)) {
+		t.Error("Expected NaN, got ", Median(nd.Array(1, math.NaN(), 2), 0))
+	}
+}
+
+func TestQuantileMethods(t *testing.T) {
+	a := nd.Array(4, 1, 3, 2)
+	q := nd.Array(0, 0.4, 0.5, 1)
+
+	expected := map[QuantileMethod]*nd.NdArray{
+		Linear:   nd.Array(1, 2.2, 2.5, 4),
+		Lower:    nd.Array(1, 2, 2, 4),
+		Higher:   nd.Array(1, 3, 3, 4),
+		Nearest:  nd.Array(1, 2, 3, 4),
+		Midpoint: nd.Array(1, 2.5, 2.5, 4),
+	}
+	for method, e := range expected {
+		if got := Quantile(a, q, 0, method); !got.Equals(e) {
+			t.Error("Expected ", e, " for method ", method, ", got ", got)
+		}
+	}
+
+	if got := Quantile(nd.Array(1, 2, 3, 4, 5, 6), nd.Array(0.5), 0, Nearest); !got.Equals(nd.Array(3)) {
+		t.Error("Expected [3], got ", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for q out of [0, 1]")
+		}
+	}()
+	Quantile(a, nd.Array(1.5), 0, Linear)
+}
+
+func TestQuantileAxis(t *testing.T) {
+	a := nd.Arange(24).Reshape(2, 3, 4)
+	r := Quantile(a, nd.Array(0, 0.5, 1), 1, Linear)
+
+	if !util.EqualOfIntSlice(r.Shape(), []int{3, 2, 4}) {
+		t.Error("Expected [3 2 4], got ", r.Shape())
+	}
+	if !r.Ix(1).Equals(nd.Array(4, 5, 6, 7, 16, 17, 18, 19).Reshape(2, 4)) {
+		t.Error("Expected the medians along axis 1, got ", r.Ix(1))
+	}
+
+	p := Percentile(a, nd.Array(50), 2, Linear)
+	if !p.Equals(nd.Array(1.5, 5.5, 9.5, 13.5, 17.5, 21.5).Reshape(2, 3)) {
+		t.Error("Expected the medians along axis 2, got ", p)
+	}
+}
+
+func TestSelectKth(t *testing.T) {
+	g := random.NewGenerator(11)
+	for trial := 0; trial < 50; trial++ {
+		s := g.Integers(0, 20, 37).Values()
+		sorted := append([]float64{}, s...)
+		sort.Float64s(sorted)
+		k := trial % len(s)
+		if v := selectKth(s, k); v != sorted[k] {
+			t.Fatal("Expected ", sorted[k], ", got ", v)
+		}
+		for i := range s {
+			if (i < k && s[i] > s[k]) || (i > k && s[i] < s[k]) {
+				t.Fatal("Expected s to be partitioned around ", k, ", got ", s)
+			}
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package regression
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Maximum likelihood fit of the logistic model P(y = 1) = 1 / (1 + exp(-x Coef)).
+type LogisticResult struct {
+	Coef *nd.NdArray
+	//standard errors from the inverse Fisher information
+	StdErr *nd.NdArray
+	//Wald statistics Coef / StdErr, and their two sided p-values from the normal distribution
+	ZValues, PValues *nd.NdArray
+	LogLikelihood    float64
+	Iterations       int
+	//false when maxIter was reached, for instance because the classes are separable
+	Converged bool
+}
+
+//Fit a logistic regression of y, with elements in [0, 1], on x by iteratively reweighted least squares,
+//which are Newton steps on the log-likelihood. It stops when no coefficient changes by more than tol,
+//or after maxIter steps. Add a constant column to x for an intercept. x needs full column rank,
+//the steps panic with "singular matrix" otherwise. The standard errors are NaN when the information
+//at the final coefficients is numerically singular.
+func Logistic(x, y *nd.NdArray, maxIter int, tol float64) *LogisticResult {
+	x = design(x)
+	checkResponse(x, y)
+	for _, v := range y.Values() {
+		if !(v >= 0 && v <= 1) {
+			panic("responses must be in [0, 1]")
+		}
+	}
+	p := x.Shape()[1]
+
+	coef := nd.Zeros(p)
+	res := &LogisticResult{}
+	for res.Iterations < maxIter {
+		info, score, _ := fisher(x, y, coef)
+		step := info.Solve(score)
+		coef = coef.Add(step)
+		res.Iterations++
+
+		maxStep := 0.0
+		for _, v := range step.Values() {
+			maxStep = math.Max(maxStep, math.Abs(v))
+		}
+		if maxStep <= tol {
+			res.Converged = true
+			break
+		}
+	}
+
+	//standard errors at the final coefficients
+	_, _, logLikelihood := fisher(x, y, coef)
+	res.LogLikelihood = logLikelihood
+	cov := informationInverse(x, coef)
+
+	res.Coef = coef
+	res.StdErr, res.ZValues, res.PValues = nd.Zeros(p), nd.Zeros(p), nd.Zeros(p)
+	for j := 0; j < p; j++ {
+		se := math.Sqrt(cov.Get(j, j))
+		z := coef.Get(j) / se
+		res.StdErr.Set(se, j)
+		res.ZValues.Set(z, j)
+		res.PValues.Set(math.Erfc(math.Abs(z)/math.Sqrt2), j)
+	}
+
+	return res
+}
+
+//the Fisher information x.T w x, the score x.T (y - mu) and the log-likelihood at coef.
+func fisher(x, y, coef *nd.NdArray) (*nd.NdArray, *nd.NdArray, float64) {
+	n, p := x.Shape()[0], x.Shape()[1]
+	xs, ys := x.Values(), y.Values()
+	eta := nd.MatMul(x, coef).Values()
+
+	info, score := nd.Zeros(p, p), nd.Zeros(p)
+	is, ss := info.Values(), score.Values()
+	logLikelihood := 0.0
+	for i := 0; i < n; i++ {
+		mu := sigmoid(eta[i])
+		w := weight(mu)
+		row := xs[i*p : (i+1)*p]
+		for a := 0; a < p; a++ {
+			ss[a] += row[a] * (ys[i] - mu)
+			for b := 0; b < p; b++ {
+				is[a*p+b] += w * row[a] * row[b]
+			}
+		}
+		//log(mu) = -log(1 + exp(-eta)) and log(1 - mu) = -log(1 + exp(eta))
+		logLikelihood -= ys[i]*softplus(-eta[i]) + (1-ys[i])*softplus(eta[i])
+	}
+
+	return info, score, logLikelihood
+}
+
+//weight of an observation with probability mu in the information, kept positive.
+func weight(mu float64) float64 {
+	return math.Max(mu*(1-mu), 1e-12)
+}
+
+//the inverse of the Fisher information x.T w x at coef, from the QR decomposition of w^1/2 x,
+//all NaN when it is numerically singular.
+func informationInverse(x, coef *nd.NdArray) *nd.NdArray {
+	p := x.Shape()[1]
+	a := x.Clone()
+	as := a.Values()
+	for i, eta := range nd.MatMul(x, coef).Values() {
+		s := math.Sqrt(weight(sigmoid(eta)))
+		for j := 0; j < p; j++ {
+			as[i*p+j] *= s
+		}
+	}
+
+	return a.NormalInverse()
+}
+
+//Predicted probabilities that y = 1.
+func (r *LogisticResult) Predict(x *nd.NdArray) *nd.NdArray {
+	return nd.MatMul(design(x), r.Coef).Map(sigmoid)
+}
+
+func sigmoid(v float64) float64 {
+	if v >= 0 {
+		return 1 / (1 + math.Exp(-v))
+	}
+	e := math.Exp(v)
+
+	return e / (1 + e)
+}
+
+//log(1 + exp(v)) without overflow.
+func softplus(v float64) float64 {
+	if v > 0 {
+		return v + math.Log1p(math.Exp(-v))
+	}
+
+	return math.Log1p(math.Exp(v))
+}
@@ -0,0 +1,50 @@
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestLogistic(t *testing.T) {
+	//hours of study and passing an exam
+	hours := nd.Array(0.5, 0.75, 1, 1.25, 1.5, 1.75, 1.75, 2, 2.25, 2.5, 2.75, 3, 3.25, 3.5, 4, 4.25, 4.5, 4.75, 5, 5.5)
+	pass := nd.Array(0, 0, 0, 0, 0, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 1, 1, 1, 1, 1)
+
+	r := Logistic(AddConstant(hours), pass, 100, 1e-10)
+	if !r.Converged || r.Iterations > 10 {
+		t.Error("Expected convergence in a few iterations, got ", r.Iterations)
+	}
+	if math.Abs(r.Coef.Get(0)+4.0777) > 1e-4 || math.Abs(r.Coef.Get(1)-1.5046) > 1e-4 {
+		t.Error("Expected [-4.0777, 1.5046], got ", r.Coef)
+	}
+	if math.Abs(r.StdErr.Get(0)-1.7610) > 1e-4 || math.Abs(r.StdErr.Get(1)-0.6287) > 1e-4 {
+		t.Error("Expected standard errors [1.7610, 0.6287], got ", r.StdErr)
+	}
+	if math.Abs(r.PValues.Get(0)-0.0206) > 1e-4 || math.Abs(r.PValues.Get(1)-0.0167) > 1e-4 {
+		t.Error("Expected p-values [0.0206, 0.0167], got ", r.PValues)
+	}
+	if math.Abs(r.LogLikelihood+8.0299) > 1e-4 {
+		t.Error("Expected log-likelihood -8.0299, got ", r.LogLikelihood)
+	}
+	if p := r.Predict(AddConstant(nd.Array(2))); math.Abs(p.Get(0)-0.2557) > 1e-4 {
+		t.Error("Expected 0.2557, got ", p)
+	}
+
+	//separable classes do not converge
+	r = Logistic(AddConstant(nd.Array(1, 2, 3, 4)), nd.Array(0, 0, 1, 1), 20, 1e-10)
+	if r.Converged {
+		t.Error("Expected no convergence for separable classes")
+	}
+
+	//a duplicated column leaves the information singular
+	x := nd.Array(1, 1, -2, 1, 1, -1, 1, 1, 0.5, 1, 1, 1, 1, 1, 2, 1, 1, -0.5).Reshape(6, 3)
+	r = Logistic(x, nd.Array(0, 0, 1, 1, 1, 0), 0, 1e-10)
+	for _, se := range r.StdErr.Values() {
+		if !math.IsNaN(se) {
+			t.Error("Expected NaN standard errors, got ", r.StdErr)
+			break
+		}
+	}
+}
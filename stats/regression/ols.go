@@ -0,0 +1,132 @@
+//Package regression fits linear and logistic models.
+//The design matrix x is [n, p], one row per observation and one column per regressor,
+//a 1d x is a single regressor. The response y is 1d with n elements.
+package regression
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/stats/dist"
+)
+
+//Ordinary least squares fit of y = x Coef + residuals.
+type OLSResult struct {
+	//coefficients, one per column of x
+	Coef *nd.NdArray
+	//standard errors of the coefficients
+	StdErr *nd.NdArray
+	//t statistics Coef / StdErr, and their two sided p-values
+	TValues, PValues *nd.NdArray
+	Residuals        *nd.NdArray
+	//residual degrees of freedom n - p
+	DF float64
+	//coefficient of determination, centered when x has a constant column, and its adjustment for p
+	RSquared, AdjRSquared float64
+}
+
+//Prepend a column of ones to x, so that the first coefficient is an intercept.
+func AddConstant(x *nd.NdArray) *nd.NdArray {
+	x = design(x)
+	return nd.HStack(nd.Ones(x.Shape()[0]), x)
+}
+
+//Fit y = x b by ordinary least squares, with the QR decomposition of x by nd.LstsqCov.
+//x needs more rows than columns, add a constant column for an intercept. When x is rank deficient
+//the standard errors are NaN, and Coef is the basic solution of nd.LstsqCov.
+func OLS(x, y *nd.NdArray) *OLSResult {
+	x = design(x)
+	checkResponse(x, y)
+	n, p := x.Shape()[0], x.Shape()[1]
+	if n <= p {
+		panic("shape error")
+	}
+
+	coef, inv := x.LstsqCov(y)
+	residuals := y.Sub(nd.MatMul(x, coef))
+	rss := 0.0
+	for _, e := range residuals.Values() {
+		rss += e * e
+	}
+	df := float64(n - p)
+	sigma2 := rss / df
+
+	//the covariance of the coefficients is sigma2 (x.T x)^-1
+	stdErr, tValues, pValues := nd.Zeros(p), nd.Zeros(p), nd.Zeros(p)
+	for i := 0; i < p; i++ {
+		se := math.Sqrt(sigma2 * inv.Get(i, i))
+		t := coef.Get(i) / se
+		stdErr.Set(se, i)
+		tValues.Set(t, i)
+		pValues.Set(dist.RegIncBeta(df/2, 0.5, df/(df+t*t)), i)
+	}
+
+	//total sum of squares, around the mean when the model has an intercept
+	constant := hasConstant(x)
+	mean := 0.0
+	if constant {
+		for _, v := range y.Values() {
+			mean += v
+		}
+		mean /= float64(n)
+	}
+	tss := 0.0
+	for _, v := range y.Values() {
+		tss += (v - mean) * (v - mean)
+	}
+	r2 := 1 - rss/tss
+	k := 0.0
+	if constant {
+		k = 1
+	}
+
+	return &OLSResult{
+		Coef:        coef,
+		StdErr:      stdErr,
+		TValues:     tValues,
+		PValues:     pValues,
+		Residuals:   residuals,
+		DF:          df,
+		RSquared:    r2,
+		AdjRSquared: 1 - (1-r2)*(float64(n)-k)/df,
+	}
+}
+
+//Predicted responses x Coef.
+func (r *OLSResult) Predict(x *nd.NdArray) *nd.NdArray {
+	return nd.MatMul(design(x), r.Coef)
+}
+
+//x as a 2d design matrix.
+func design(x *nd.NdArray) *nd.NdArray {
+	if x.NDims() == 1 {
+		return x.Reshape(x.Size(), 1)
+	}
+	if x.NDims() != 2 {
+		panic("shape error")
+	}
+
+	return x
+}
+
+func checkResponse(x, y *nd.NdArray) {
+	if y.NDims() != 1 || y.Size() != x.Shape()[0] {
+		panic("shape error")
+	}
+}
+
+//whether a column of x is constant and not zero.
+func hasConstant(x *nd.NdArray) bool {
+	n, p := x.Shape()[0], x.Shape()[1]
+	for j := 0; j < p; j++ {
+		constant := x.Get(0, j) != 0
+		for i := 1; i < n && constant; i++ {
+			constant = x.Get(i, j) == x.Get(0, j)
+		}
+		if constant {
+			return true
+		}
+	}
+
+	return false
+}
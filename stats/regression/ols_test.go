@@ -0,0 +1,68 @@
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+var (
+	line  = nd.Arange(1, 11)
+	lineY = nd.Array(2.1, 3.9, 6.2, 7.8, 10.1, 12.2, 13.8, 16.1, 18.0, 20.3)
+)
+
+func TestOLS(t *testing.T) {
+	r := OLS(AddConstant(line), lineY)
+
+	if !r.Coef.Equals(nd.Array(-0.02, 2.0127273)) {
+		t.Error("Expected [-0.02, 2.0127273], got ", r.Coef)
+	}
+	if !r.StdErr.Equals(nd.Array(0.1211560, 0.0195261)) {
+		t.Error("Expected [0.1211560, 0.0195261], got ", r.StdErr)
+	}
+	if math.Abs(r.TValues.Get(1)-103.0790005) > 1e-6 || math.Abs(r.PValues.Get(0)-0.8729789) > 1e-6 || r.PValues.Get(1) > 1e-10 {
+		t.Error("Expected t 103.0790005 and p [0.8729789, < 1e-10], got ", r.TValues, r.PValues)
+	}
+	if r.DF != 8 || math.Abs(r.RSquared-0.9992476) > 1e-6 || math.Abs(r.AdjRSquared-0.9991536) > 1e-6 {
+		t.Error("Expected df 8, R2 0.9992476, adjusted 0.9991536, got ", r.DF, r.RSquared, r.AdjRSquared)
+	}
+	if !r.Predict(AddConstant(nd.Array(0, 20))).Equals(nd.Array(-0.02, 40.2345455)) {
+		t.Error("Expected [-0.02, 40.2345455], got ", r.Predict(AddConstant(nd.Array(0, 20))))
+	}
+
+	//the residuals are orthogonal to every regressor
+	x := nd.HStack(AddConstant(line), line.Map(math.Sqrt))
+	r = OLS(x, lineY)
+	if !nd.MatMul(x.T(), r.Residuals).Equals(nd.Zeros(3)) {
+		t.Error("Expected orthogonal residuals, got ", nd.MatMul(x.T(), r.Residuals))
+	}
+}
+
+func TestOLSWithoutConstant(t *testing.T) {
+	//R2 is not centered without a constant column
+	r := OLS(line, lineY)
+	rss, tss := 0.0, 0.0
+	for i, e := range r.Residuals.Values() {
+		rss += e * e
+		tss += lineY.Get(i) * lineY.Get(i)
+	}
+	if math.Abs(r.RSquared-(1-rss/tss)) > 1e-12 || r.DF != 9 {
+		t.Error("Expected R2 ", 1-rss/tss, " with df 9, got ", r.RSquared, r.DF)
+	}
+}
+
+func TestOLSRankDeficient(t *testing.T) {
+	//the regressor twice
+	x := nd.HStack(AddConstant(line), line.Reshape(line.Size(), 1))
+	r := OLS(x, lineY)
+	if !r.Coef.Equals(nd.Array(-0.02, 2.0127273, 0)) {
+		t.Error("Expected [-0.02, 2.0127273, 0], got ", r.Coef)
+	}
+	for _, se := range r.StdErr.Values() {
+		if !math.IsNaN(se) {
+			t.Error("Expected NaN standard errors, got ", r.StdErr)
+			break
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package regression
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//Linear model y = x Coef + Intercept.
+type LinearModel struct {
+	Coef      *nd.NdArray
+	Intercept float64
+}
+
+//Predicted responses x Coef + Intercept.
+func (m *LinearModel) Predict(x *nd.NdArray) *nd.NdArray {
+	return nd.MatMul(design(x), m.Coef).Map(func(v float64) float64 {
+		return v + m.Intercept
+	})
+}
+
+//Ridge regression, minimizing |y - x b - c|^2 + alpha |b|^2.
+//If fitIntercept is true the intercept c is fitted and not penalized, otherwise it is 0.
+func Ridge(x, y *nd.NdArray, alpha float64, fitIntercept bool) *LinearModel {
+	xc, yc, xMean, yMean := center(x, y, fitIntercept)
+	p := xc.Shape()[1]
+
+	gram := nd.MatMul(xc.T(), xc)
+	for j := 0; j < p; j++ {
+		gram.Set(gram.Get(j, j)+alpha, j, j)
+	}
+	coef := gram.Solve(nd.MatMul(xc.T(), yc))
+
+	return &LinearModel{Coef: coef, Intercept: intercept(coef, xMean, yMean)}
+}
+
+//Lasso regression, minimizing |y - x b - c|^2 / 2n + alpha |b|_1 by cyclic coordinate descent.
+//If fitIntercept is true the intercept c is fitted and not penalized, otherwise it is 0.
+//The sweeps over the coefficients stop when none changes by more than tol, or after maxIter sweeps.
+func Lasso(x, y *nd.NdArray, alpha float64, fitIntercept bool, maxIter int, tol float64) *LinearModel {
+	xc, yc, xMean, yMean := center(x, y, fitIntercept)
+	n, p := xc.Shape()[0], xc.Shape()[1]
+	xs := xc.Values()
+
+	norms := make([]float64, p)
+	for j := range norms {
+		for i := 0; i < n; i++ {
+			norms[j] += xs[i*p+j] * xs[i*p+j]
+		}
+	}
+
+	coef := make([]float64, p)
+	residual := append([]float64(nil), yc.Values()...)
+	for iter := 0; iter < maxIter; iter++ {
+		maxDelta := 0.0
+		for j := 0; j < p; j++ {
+			if norms[j] == 0 {
+				continue
+			}
+			//correlation of column j with the residual without its own contribution
+			rho := 0.0
+			for i := 0; i < n; i++ {
+				rho += xs[i*p+j] * (residual[i] + xs[i*p+j]*coef[j])
+			}
+			b := softThreshold(rho, float64(n)*alpha) / norms[j]
+			if delta := b - coef[j]; delta != 0 {
+				for i := 0; i < n; i++ {
+					residual[i] -= xs[i*p+j] * delta
+				}
+				coef[j] = b
+				maxDelta = math.Max(maxDelta, math.Abs(delta))
+			}
+		}
+		if maxDelta <= tol {
+			break
+		}
+	}
+
+	c := nd.Array(coef...)
+	return &LinearModel{Coef: c, Intercept: intercept(c, xMean, yMean)}
+}
+
+func softThreshold(v, t float64) float64 {
+	if v > t {
+		return v - t
+	}
+	if v < -t {
+		return v + t
+	}
+
+	return 0
+}
+
+//x and y minus their means when fitIntercept is true, and the means, which are otherwise 0.
+func center(x, y *nd.NdArray, fitIntercept bool) (*nd.NdArray, *nd.NdArray, []float64, float64) {
+	x = design(x)
+	checkResponse(x, y)
+	n, p := x.Shape()[0], x.Shape()[1]
+
+	xMean, yMean := make([]float64, p), 0.0
+	xc, yc := x.Clone(), y.Clone()
+	if !fitIntercept {
+		return xc, yc, xMean, yMean
+	}
+
+	xs, ys := xc.Values(), yc.Values()
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			xMean[j] += xs[i*p+j] / float64(n)
+		}
+		yMean += ys[i] / float64(n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < p; j++ {
+			xs[i*p+j] -= xMean[j]
+		}
+		ys[i] -= yMean
+	}
+
+	return xc, yc, xMean, yMean
+}
+
+//the intercept making the model go through the means.
+func intercept(coef *nd.NdArray, xMean []float64, yMean float64) float64 {
+	c := yMean
+	for j, m := range xMean {
+		c -= coef.Get(j) * m
+	}
+
+	return c
+}
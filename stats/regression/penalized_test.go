@@ -0,0 +1,49 @@
+package regression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestRidge(t *testing.T) {
+	m := Ridge(line, lineY, 5, true)
+	if !m.Coef.Equals(nd.Array(1.8977143)) || math.Abs(m.Intercept-0.6125714) > 1e-6 {
+		t.Error("Expected coef 1.8977143 and intercept 0.6125714, got ", m.Coef, m.Intercept)
+	}
+
+	//without penalty it is least squares
+	m = Ridge(line, lineY, 0, true)
+	if !m.Predict(nd.Array(0, 20)).Equals(nd.Array(-0.02, 40.2345455)) {
+		t.Error("Expected [-0.02, 40.2345455], got ", m.Predict(nd.Array(0, 20)))
+	}
+	if m = Ridge(line, lineY, 0, false); m.Intercept != 0 {
+		t.Error("Expected no intercept, got ", m.Intercept)
+	}
+}
+
+func TestLasso(t *testing.T) {
+	m := Lasso(line, lineY, 1, true, 100, 1e-10)
+	if !m.Coef.Equals(nd.Array(1.8915152)) || math.Abs(m.Intercept-0.6466667) > 1e-6 {
+		t.Error("Expected coef 1.8915152 and intercept 0.6466667, got ", m.Coef, m.Intercept)
+	}
+
+	//an irrelevant regressor is dropped, the optimality conditions hold
+	g := random.NewGenerator(1)
+	x := g.Normal(0, 1, 50, 3)
+	y := nd.MatMul(x, nd.Array(3, 0, -2)).Add(g.Normal(0, 0.1, 50))
+	alpha := 0.1
+	m = Lasso(x, y, alpha, false, 1000, 1e-12)
+	if m.Coef.Get(1) != 0 {
+		t.Error("Expected a zero coefficient, got ", m.Coef)
+	}
+	grad := nd.MatMul(x.T(), y.Sub(m.Predict(x))).Values()
+	for j, b := range m.Coef.Values() {
+		g := grad[j] / 50
+		if b != 0 && math.Abs(g-math.Copysign(alpha, b)) > 1e-8 || b == 0 && math.Abs(g) > alpha {
+			t.Error("Expected the optimality conditions to hold, got gradient ", g, " for coefficient ", b)
+		}
+	}
+}
@@ -0,0 +1,299 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//The Rolling functions compute a statistic over a moving window of window elements along axis,
+//or along the flattened array when axis < 0. The result has the shape of a.
+//The window ending at element i covers i - window + 1 to i, or is centered on i when center is true,
+//then it covers i - window / 2 to i + (window - 1) / 2. Windows are cut at the ends of the axis.
+//NaN elements are ignored, and the result is NaN where a window holds fewer than minPeriods
+//other elements. minPeriods <= 0 stands for window.
+//Windows are updated as elements enter and leave them, so the cost does not depend on window,
+//except for RollingMedian which keeps the window sorted.
+
+//Rolling sum, with compensated summation.
+func RollingSum(a *nd.NdArray, window, minPeriods int, center bool, axis int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &sumAgg{}
+	})
+}
+
+//Rolling mean.
+func RollingMean(a *nd.NdArray, window, minPeriods int, center bool, axis int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &meanAgg{}
+	})
+}
+
+//Rolling variance, divided by N - ddof for the N elements in each window.
+func RollingVar(a *nd.NdArray, window, minPeriods int, center bool, axis int, ddof int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &varAgg{ddof: float64(ddof)}
+	})
+}
+
+//Rolling standard deviation, the square root of RollingVar.
+func RollingStd(a *nd.NdArray, window, minPeriods int, center bool, axis int, ddof int) *nd.NdArray {
+	return RollingVar(a, window, minPeriods, center, axis, ddof).Map(math.Sqrt)
+}
+
+//Rolling minimum.
+func RollingMin(a *nd.NdArray, window, minPeriods int, center bool, axis int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &extremumAgg{before: func(x, y float64) bool { return x < y }}
+	})
+}
+
+//Rolling maximum.
+func RollingMax(a *nd.NdArray, window, minPeriods int, center bool, axis int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &extremumAgg{before: func(x, y float64) bool { return x > y }}
+	})
+}
+
+//Rolling median, each step takes O(window) time to keep the window sorted.
+func RollingMedian(a *nd.NdArray, window, minPeriods int, center bool, axis int) *nd.NdArray {
+	return rolling(a, window, minPeriods, center, axis, func() windowAgg {
+		return &medianAgg{}
+	})
+}
+
+//Exponentially weighted mean along axis, or along the flattened array when axis < 0.
+//The weight of the element k steps back is (1 - alpha)^k when adjust is true. When adjust is false
+//the mean follows m[i] = (1 - alpha) m[i-1] + alpha x[i], starting with m[0] = x[0].
+//NaN elements are ignored, the result there is the mean so far.
+func EWMMean(a *nd.NdArray, alpha float64, adjust bool, axis int) *nd.NdArray {
+	return ewm(a, alpha, adjust, axis, func(e *ewmState) float64 {
+		return e.mean
+	})
+}
+
+//Exponentially weighted variance, with the weights of EWMMean treated as reliability weights
+//so that it is unbiased: the weighted sum of squares is divided by sum(w) - sum(w^2) / sum(w).
+func EWMVar(a *nd.NdArray, alpha float64, adjust bool, axis int) *nd.NdArray {
+	return ewm(a, alpha, adjust, axis, func(e *ewmState) float64 {
+		d := e.sumW - e.sumW2/e.sumW
+		if !(d > 0) {
+			return math.NaN()
+		}
+		return math.Max(0, e.m2/d)
+	})
+}
+
+//an aggregate over a sliding window, elements enter at the right and leave at the left in the same order.
+type windowAgg interface {
+	push(v float64)
+	pop(v float64)
+	value() float64
+}
+
+func rolling(a *nd.NdArray, window, minPeriods int, center bool, axis int, newAgg func() windowAgg) *nd.NdArray {
+	if window < 1 {
+		panic(fmt.Errorf("window: %v must be positive", window))
+	}
+	if minPeriods <= 0 {
+		minPeriods = window
+	}
+	if minPeriods > window {
+		panic(fmt.Errorf("minPeriods: %v larger than window %v", minPeriods, window))
+	}
+
+	return mapAlongAxis(a, axis, func(lane []float64, out []float64) {
+		agg := newAgg()
+		//lane[lo:hi] is in the window, count of them are not NaN
+		lo, hi, count := 0, 0, 0
+		for i := range lane {
+			start := i - window + 1
+			if center {
+				start = i - window/2
+			}
+			for ; hi < len(lane) && hi < start+window; hi++ {
+				if !math.IsNaN(lane[hi]) {
+					agg.push(lane[hi])
+					count++
+				}
+			}
+			for ; lo < start; lo++ {
+				if !math.IsNaN(lane[lo]) {
+					agg.pop(lane[lo])
+					count--
+				}
+			}
+
+			out[i] = math.NaN()
+			if count >= minPeriods {
+				out[i] = agg.value()
+			}
+		}
+	})
+}
+
+//Neumaier's compensated sum.
+type sumAgg struct {
+	sum, c float64
+}
+
+func (s *sumAgg) push(v float64) {
+	t := s.sum + v
+	if math.Abs(s.sum) >= math.Abs(v) {
+		s.c += (s.sum - t) + v
+	} else {
+		s.c += (v - t) + s.sum
+	}
+	s.sum = t
+}
+
+func (s *sumAgg) pop(v float64) {
+	s.push(-v)
+}
+
+func (s *sumAgg) value() float64 {
+	return s.sum + s.c
+}
+
+type meanAgg struct {
+	sum sumAgg
+	n   float64
+}
+
+func (m *meanAgg) push(v float64) {
+	m.sum.push(v)
+	m.n++
+}
+
+func (m *meanAgg) pop(v float64) {
+	m.sum.pop(v)
+	m.n--
+}
+
+func (m *meanAgg) value() float64 {
+	return m.sum.value() / m.n
+}
+
+//Welford's update, run backwards to remove an element.
+type varAgg struct {
+	n, mean, m2, ddof float64
+}
+
+func (a *varAgg) push(v float64) {
+	a.n++
+	delta := v - a.mean
+	a.mean += delta / a.n
+	a.m2 += delta * (v - a.mean)
+}
+
+func (a *varAgg) pop(v float64) {
+	a.n--
+	if a.n == 0 {
+		a.mean, a.m2 = 0, 0
+		return
+	}
+	delta := v - a.mean
+	a.mean -= delta / a.n
+	a.m2 -= delta * (v - a.mean)
+}
+
+func (a *varAgg) value() float64 {
+	if a.n-a.ddof <= 0 {
+		return math.NaN()
+	}
+
+	return math.Max(0, a.m2) / (a.n - a.ddof)
+}
+
+//monotonic deque, the candidates for the extremum in window order.
+//An element is dropped once a later element comes before it, so the front is the extremum.
+type extremumAgg struct {
+	deque  []float64
+	before func(x, y float64) bool
+}
+
+func (e *extremumAgg) push(v float64) {
+	for len(e.deque) > 0 && e.before(v, e.deque[len(e.deque)-1]) {
+		e.deque = e.deque[:len(e.deque)-1]
+	}
+	e.deque = append(e.deque, v)
+}
+
+func (e *extremumAgg) pop(v float64) {
+	//equal elements are all kept, so v left the window only if it is still the front
+	if e.deque[0] == v {
+		e.deque = e.deque[1:]
+	}
+}
+
+func (e *extremumAgg) value() float64 {
+	return e.deque[0]
+}
+
+//the window kept sorted.
+type medianAgg struct {
+	sorted []float64
+}
+
+func (m *medianAgg) push(v float64) {
+	i := sort.SearchFloat64s(m.sorted, v)
+	m.sorted = append(m.sorted, 0)
+	copy(m.sorted[i+1:], m.sorted[i:])
+	m.sorted[i] = v
+}
+
+func (m *medianAgg) pop(v float64) {
+	i := sort.SearchFloat64s(m.sorted, v)
+	m.sorted = append(m.sorted[:i], m.sorted[i+1:]...)
+}
+
+func (m *medianAgg) value() float64 {
+	n := len(m.sorted)
+	if n%2 == 1 {
+		return m.sorted[n/2]
+	}
+
+	return (m.sorted[n/2-1] + m.sorted[n/2]) / 2
+}
+
+//weighted moments of the elements seen so far, the older weights decayed.
+type ewmState struct {
+	sumW, sumW2, mean, m2 float64
+}
+
+func ewm(a *nd.NdArray, alpha float64, adjust bool, axis int, value func(e *ewmState) float64) *nd.NdArray {
+	if !(alpha > 0 && alpha <= 1) {
+		panic(fmt.Errorf("alpha: %v out of (0, 1]", alpha))
+	}
+
+	decay := 1 - alpha
+	return mapAlongAxis(a, axis, func(lane []float64, out []float64) {
+		e := &ewmState{}
+		for i, v := range lane {
+			if !math.IsNaN(v) {
+				//older weights decay by 1 - alpha, the new one is 1, or alpha without adjust
+				//which keeps the total weight at 1 after the first element
+				w := 1.0
+				if !adjust && e.sumW > 0 {
+					w = alpha
+				}
+				e.sumW *= decay
+				e.sumW2 *= decay * decay
+				e.m2 *= decay
+				sumW := e.sumW + w
+				delta := v - e.mean
+				e.mean += delta * w / sumW
+				e.m2 += w * delta * (v - e.mean)
+				e.sumW = sumW
+				e.sumW2 += w * w
+			}
+
+			out[i] = math.NaN()
+			if e.sumW > 0 {
+				out[i] = value(e)
+			}
+		}
+	})
+}
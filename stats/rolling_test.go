@@ -0,0 +1,113 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+//equalNaN reports whether a and b have the same shape and elements, NaN matching NaN.
+func equalNaN(a, b *nd.NdArray) bool {
+	return a.NanToNum(-1e300, 0, 0).Equals(b.NanToNum(-1e300, 0, 0))
+}
+
+func TestRollingWindows(t *testing.T) {
+	a := nd.Array(1, 2, 3, 4, 5, 6)
+
+	if !equalNaN(RollingSum(a, 3, 0, false, 0), nd.Array(nan, nan, 6, 9, 12, 15)) {
+		t.Error("Expected [NaN, NaN, 6, 9, 12, 15], got ", RollingSum(a, 3, 0, false, 0))
+	}
+	if !equalNaN(RollingSum(a, 3, 1, false, 0), nd.Array(1, 3, 6, 9, 12, 15)) {
+		t.Error("Expected [1, 3, 6, 9, 12, 15], got ", RollingSum(a, 3, 1, false, 0))
+	}
+	if !equalNaN(RollingSum(a, 4, 0, true, 0), nd.Array(nan, nan, 10, 14, 18, nan)) {
+		t.Error("Expected [NaN, NaN, 10, 14, 18, NaN], got ", RollingSum(a, 4, 0, true, 0))
+	}
+	if !equalNaN(RollingMean(a, 3, 2, true, 0), nd.Array(1.5, 2, 3, 4, 5, 5.5)) {
+		t.Error("Expected [1.5, 2, 3, 4, 5, 5.5], got ", RollingMean(a, 3, 2, true, 0))
+	}
+
+	//NaN elements do not count towards minPeriods
+	b := nd.Array(1, nan, 3, 4)
+	if !equalNaN(RollingMean(b, 2, 1, false, 0), nd.Array(1, 1, 3, 3.5)) {
+		t.Error("Expected [1, 1, 3, 3.5], got ", RollingMean(b, 2, 1, false, 0))
+	}
+	if !equalNaN(RollingMax(b, 2, 2, false, 0), nd.Array(nan, nan, nan, 4)) {
+		t.Error("Expected [NaN, NaN, NaN, 4], got ", RollingMax(b, 2, 2, false, 0))
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected a panic for minPeriods larger than window")
+		}
+	}()
+	RollingSum(a, 2, 3, false, 0)
+}
+
+func TestRollingAgainstBatch(t *testing.T) {
+	g := random.NewGenerator(3)
+	//a large mean and many ties
+	a := g.Integers(0, 10, 200).Map(func(v float64) float64 { return v + 1e6 })
+	window := 7
+
+	mean := RollingMean(a, window, 0, false, 0)
+	std := RollingStd(a, window, 0, false, 0, 1)
+	min := RollingMin(a, window, 0, false, 0)
+	max := RollingMax(a, window, 0, false, 0)
+	median := RollingMedian(a, window, 0, false, 0)
+	for i := window - 1; i < a.Size(); i++ {
+		w := nd.Array(a.Values()[i-window+1 : i+1]...)
+		if math.Abs(mean.Get(i)-Average(w, nil, 0).Get(0)) > 1e-8 {
+			t.Error("Expected mean ", Average(w, nil, 0), ", got ", mean.Get(i))
+		}
+		if math.Abs(std.Get(i)-StdDdof(w, 0, 1).Get(0)) > 1e-6 {
+			t.Error("Expected std ", StdDdof(w, 0, 1), ", got ", std.Get(i))
+		}
+		if median.Get(i) != Median(w, 0).Get(0) {
+			t.Error("Expected median ", Median(w, 0), ", got ", median.Get(i))
+		}
+		sorted := w.Clone().Sort()
+		if min.Get(i) != sorted.Get(0) || max.Get(i) != sorted.Get(window-1) {
+			t.Error("Expected min and max ", sorted.Get(0), sorted.Get(window-1), ", got ", min.Get(i), max.Get(i))
+		}
+	}
+}
+
+func TestRollingAxis(t *testing.T) {
+	a := nd.Array(1, 5, 2, 4, 3, 6).Reshape(3, 2)
+
+	if !equalNaN(RollingMax(a, 2, 0, false, 0), nd.Array(nan, nan, 2, 5, 3, 6).Reshape(3, 2)) {
+		t.Error("Expected [[NaN, NaN], [2, 5], [3, 6]], got ", RollingMax(a, 2, 0, false, 0))
+	}
+	if !equalNaN(RollingMin(a, 2, 0, false, 1), nd.Array(nan, 1, nan, 2, nan, 3).Reshape(3, 2)) {
+		t.Error("Expected [[NaN, 1], [NaN, 2], [NaN, 3]], got ", RollingMin(a, 2, 0, false, 1))
+	}
+	if !equalNaN(RollingMedian(a, 3, 0, false, AllAxes), nd.Array(nan, nan, 2, 4, 3, 4).Reshape(3, 2)) {
+		t.Error("Expected [[NaN, NaN], [2, 4], [3, 4]], got ", RollingMedian(a, 3, 0, false, AllAxes))
+	}
+}
+
+func TestEWM(t *testing.T) {
+	a := nd.Array(1, 2, 3, 4, 2)
+
+	if !EWMMean(a, 0.5, true, 0).Equals(nd.Array(1, 1.666666667, 2.428571429, 3.266666667, 2.612903226)) {
+		t.Error("Expected [1, 1.667, 2.429, 3.267, 2.613], got ", EWMMean(a, 0.5, true, 0))
+	}
+	if !EWMMean(a, 0.5, false, 0).Equals(nd.Array(1, 1.5, 2.25, 3.125, 2.5625)) {
+		t.Error("Expected [1, 1.5, 2.25, 3.125, 2.5625], got ", EWMMean(a, 0.5, false, 0))
+	}
+	if !equalNaN(EWMVar(a, 0.5, true, 0), nd.Array(nan, 0.5, 0.928571429, 1.385714286, 1.267741935)) {
+		t.Error("Expected [NaN, 0.5, 0.929, 1.386, 1.268], got ", EWMVar(a, 0.5, true, 0))
+	}
+	if !equalNaN(EWMVar(a, 0.5, false, 0), nd.Array(nan, 0.5, 1.1, 1.69047619, 1.311764706)) {
+		t.Error("Expected [NaN, 0.5, 1.1, 1.690, 1.312], got ", EWMVar(a, 0.5, false, 0))
+	}
+
+	//NaN elements are skipped
+	b := nd.Array(nan, 1, nan, 2)
+	if !equalNaN(EWMMean(b, 0.5, false, 0), nd.Array(nan, 1, 1, 1.5)) {
+		t.Error("Expected [NaN, 1, 1, 1.5], got ", EWMMean(b, 0.5, false, 0))
+	}
+}
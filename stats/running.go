@@ -0,0 +1,324 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/util"
+)
+
+//The running accumulators take batches of observations along axis 0: a batch of shape [n, ...] holds n
+//observations of shape [...], a 1d batch holds n scalar observations. All batches must agree on that shape.
+//An accumulator is not safe for concurrent use, instead every goroutine fills its own and they are merged.
+
+//Running count, mean and variance of each element of the observations.
+type RunningMoments struct {
+	shape []int
+	n     float64
+	mean  []float64
+	m2    []float64
+}
+
+func NewRunningMoments() *RunningMoments {
+	return &RunningMoments{}
+}
+
+//Add the observations of batch.
+func (r *RunningMoments) Push(batch *nd.NdArray) {
+	n, shape := observations(batch, r.shape)
+	if n == 0 {
+		return
+	}
+
+	f := util.ProductOfIntSlice(shape)
+	values := batch.Values()
+	mean, m2 := make([]float64, f), make([]float64, f)
+	lane := make([]float64, n)
+	for j := 0; j < f; j++ {
+		for i := range lane {
+			lane[i] = values[i*f+j]
+		}
+		mean[j] = laneMean(lane, nil)
+		for _, v := range lane {
+			m2[j] += (v - mean[j]) * (v - mean[j])
+		}
+	}
+	r.merge(shape, float64(n), mean, m2)
+}
+
+//Add the observations accumulated by o, o is left unchanged.
+func (r *RunningMoments) Merge(o *RunningMoments) {
+	if o.n == 0 {
+		return
+	}
+	if r.shape != nil && !util.EqualOfIntSlice(r.shape, o.shape) {
+		panic("shape error")
+	}
+	r.merge(o.shape, o.n, o.mean, o.m2)
+}
+
+//combine with a group of n observations, by the pairwise update of Chan et al.
+func (r *RunningMoments) merge(shape []int, n float64, mean, m2 []float64) {
+	if r.n == 0 {
+		r.shape = shape
+		r.n = n
+		r.mean = append([]float64(nil), mean...)
+		r.m2 = append([]float64(nil), m2...)
+		return
+	}
+
+	total := r.n + n
+	for j := range r.mean {
+		delta := mean[j] - r.mean[j]
+		r.m2[j] += m2[j] + delta*delta*r.n*n/total
+		r.mean[j] += delta * n / total
+	}
+	r.n = total
+}
+
+//Number of observations.
+func (r *RunningMoments) Count() int {
+	return int(r.n)
+}
+
+//Mean of the observations, as Average along axis 0 of all the batches stacked would give.
+func (r *RunningMoments) Mean() *nd.NdArray {
+	return observationArray(r.shape, r.mean)
+}
+
+//Variance of the observations divided by N - ddof, NaN when N - ddof <= 0.
+func (r *RunningMoments) Var(ddof int) *nd.NdArray {
+	checkPushed(r.shape)
+	v := make([]float64, len(r.m2))
+	for j, m2 := range r.m2 {
+		v[j] = math.NaN()
+		if r.n-float64(ddof) > 0 {
+			v[j] = m2 / (r.n - float64(ddof))
+		}
+	}
+
+	return observationArray(r.shape, v)
+}
+
+//Standard deviation of the observations, the square root of Var.
+func (r *RunningMoments) Std(ddof int) *nd.NdArray {
+	return r.Var(ddof).Map(math.Sqrt)
+}
+
+//Running covariance of the variables of 1d observations, like Cov with rowvar false.
+type RunningCov struct {
+	shape []int
+	n     float64
+	mean  []float64
+	//co-moments, the sums of products of deviations from the mean
+	c []float64
+}
+
+func NewRunningCov() *RunningCov {
+	return &RunningCov{}
+}
+
+//Add the observations of batch, a [n, k] array of n observations of k variables.
+func (r *RunningCov) Push(batch *nd.NdArray) {
+	n, shape := observations(batch, r.shape)
+	if len(shape) != 1 {
+		panic("shape error")
+	}
+	if n == 0 {
+		return
+	}
+
+	k := shape[0]
+	values := batch.Values()
+	mean := make([]float64, k)
+	lane := make([]float64, n)
+	for j := range mean {
+		for i := range lane {
+			lane[i] = values[i*k+j]
+		}
+		mean[j] = laneMean(lane, nil)
+	}
+	c := make([]float64, k*k)
+	for i := 0; i < n; i++ {
+		obs := values[i*k : (i+1)*k]
+		for a := 0; a < k; a++ {
+			for b := 0; b < k; b++ {
+				c[a*k+b] += (obs[a] - mean[a]) * (obs[b] - mean[b])
+			}
+		}
+	}
+	r.merge(shape, float64(n), mean, c)
+}
+
+//Add the observations accumulated by o, o is left unchanged.
+func (r *RunningCov) Merge(o *RunningCov) {
+	if o.n == 0 {
+		return
+	}
+	if r.shape != nil && !util.EqualOfIntSlice(r.shape, o.shape) {
+		panic("shape error")
+	}
+	r.merge(o.shape, o.n, o.mean, o.c)
+}
+
+func (r *RunningCov) merge(shape []int, n float64, mean, c []float64) {
+	if r.n == 0 {
+		r.shape = shape
+		r.n = n
+		r.mean = append([]float64(nil), mean...)
+		r.c = append([]float64(nil), c...)
+		return
+	}
+
+	k := len(r.mean)
+	total := r.n + n
+	for a := 0; a < k; a++ {
+		for b := 0; b < k; b++ {
+			r.c[a*k+b] += c[a*k+b] + (mean[a]-r.mean[a])*(mean[b]-r.mean[b])*r.n*n/total
+		}
+	}
+	for a := range r.mean {
+		r.mean[a] += (mean[a] - r.mean[a]) * n / total
+	}
+	r.n = total
+}
+
+//Number of observations.
+func (r *RunningCov) Count() int {
+	return int(r.n)
+}
+
+//Mean of each variable.
+func (r *RunningCov) Mean() *nd.NdArray {
+	return observationArray(r.shape, r.mean)
+}
+
+//Covariance matrix [k, k] of the variables, the co-moments divided by N - ddof.
+func (r *RunningCov) Cov(ddof int) *nd.NdArray {
+	checkPushed(r.shape)
+	k := len(r.mean)
+	cov := nd.Zeros(k, k)
+	values := cov.Values()
+	for i, c := range r.c {
+		values[i] = math.NaN()
+		if r.n-float64(ddof) > 0 {
+			values[i] = c / (r.n - float64(ddof))
+		}
+	}
+
+	return cov
+}
+
+//Pearson correlation coefficients of the variables.
+func (r *RunningCov) Corrcoef() *nd.NdArray {
+	return covToCorr(r.Cov(0))
+}
+
+//Running minimum and maximum of each element of the observations.
+type RunningMinMax struct {
+	shape    []int
+	n        int
+	min, max []float64
+}
+
+func NewRunningMinMax() *RunningMinMax {
+	return &RunningMinMax{}
+}
+
+//Add the observations of batch.
+func (r *RunningMinMax) Push(batch *nd.NdArray) {
+	n, shape := observations(batch, r.shape)
+	if n == 0 {
+		return
+	}
+
+	f := util.ProductOfIntSlice(shape)
+	values := batch.Values()
+	min, max := make([]float64, f), make([]float64, f)
+	for j := range min {
+		min[j], max[j] = math.Inf(1), math.Inf(-1)
+	}
+	//comparisons with NaN are false, so NaN observations are skipped
+	for i := 0; i < n; i++ {
+		for j := range min {
+			if v := values[i*f+j]; v < min[j] {
+				min[j] = v
+			}
+			if v := values[i*f+j]; v > max[j] {
+				max[j] = v
+			}
+		}
+	}
+	r.merge(shape, n, min, max)
+}
+
+//Add the observations accumulated by o, o is left unchanged.
+func (r *RunningMinMax) Merge(o *RunningMinMax) {
+	if o.n == 0 {
+		return
+	}
+	if r.shape != nil && !util.EqualOfIntSlice(r.shape, o.shape) {
+		panic("shape error")
+	}
+	r.merge(o.shape, o.n, o.min, o.max)
+}
+
+func (r *RunningMinMax) merge(shape []int, n int, min, max []float64) {
+	if r.n == 0 {
+		r.shape = shape
+		r.n = n
+		r.min = append([]float64(nil), min...)
+		r.max = append([]float64(nil), max...)
+		return
+	}
+
+	for j := range r.min {
+		if min[j] < r.min[j] {
+			r.min[j] = min[j]
+		}
+		if max[j] > r.max[j] {
+			r.max[j] = max[j]
+		}
+	}
+	r.n += n
+}
+
+//Number of observations.
+func (r *RunningMinMax) Count() int {
+	return r.n
+}
+
+//Minimum of the observations, skipping NaN like Min, +Inf where all of them are NaN.
+func (r *RunningMinMax) Min() *nd.NdArray {
+	return observationArray(r.shape, r.min)
+}
+
+//Maximum of the observations, skipping NaN like Max, -Inf where all of them are NaN.
+func (r *RunningMinMax) Max() *nd.NdArray {
+	return observationArray(r.shape, r.max)
+}
+
+//the number of observations in batch and their shape, which must equal shape unless it is nil.
+func observations(batch *nd.NdArray, shape []int) (int, []int) {
+	bs := batch.Shape()
+	obs := []int{1}
+	if len(bs) > 1 {
+		obs = append([]int(nil), bs[1:]...)
+	}
+	if shape != nil && !util.EqualOfIntSlice(shape, obs) {
+		panic("shape error")
+	}
+
+	return bs[0], obs
+}
+
+func observationArray(shape []int, values []float64) *nd.NdArray {
+	checkPushed(shape)
+	return nd.Array(append([]float64(nil), values...)...).Reshape(shape...)
+}
+
+func checkPushed(shape []int) {
+	if shape == nil {
+		panic("no observations pushed")
+	}
+}
@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+func TestRunningMoments(t *testing.T) {
+	a := nd.Array(1e9+4, 2, 1e9+7, 4, 1e9+13, 6, 1e9+16, 8).Reshape(4, 2)
+
+	r := NewRunningMoments()
+	r.Push(a.GetRows(0))
+	r.Push(a.GetRows(1, 2, 3))
+	if r.Count() != 4 {
+		t.Error("Expected 4, got ", r.Count())
+	}
+
+	//NaN is skipped like in Min and Max
+	nan := math.NaN()
+	q := NewRunningMinMax()
+	q.Push(nd.Array(nan, nan, 2, nan).Reshape(2, 2))
+	q.Push(nd.Array(-1, nan).Reshape(1, 2))
+	if !q.Min().Equals(nd.Array(-1, math.Inf(1))) || !q.Max().Equals(nd.Array(2, math.Inf(-1))) {
+		t.Error("Expected [-1, +Inf] and [2, -Inf], got ", q.Min(), q.Max())
+	}
+	if batch := Min(nd.Array(nan, 2, -1)).Get(0); q.Min().Get(0) != batch {
+		t.Error("Expected the batch minimum ", batch, ", got ", q.Min())
+	}
+	if !r.Mean().Equals(Average(a, nil, 0)) {
+		t.Error("Expected ", Average(a, nil, 0), ", got ", r.Mean())
+	}
+	if !r.Var(1).Equals(nd.Array(30, 20.0/3)) {
+		t.Error("Expected [30, 6.667], got ", r.Var(1))
+	}
+	if !r.Std(0).Equals(StdDdof(a, 0, 0)) {
+		t.Error("Expected ", StdDdof(a, 0, 0), ", got ", r.Std(0))
+	}
+
+	defer func() {
+		p := recover()
+		if p != "shape error" {
+			t.Error("Expected 'shape error', got ", p)
+		}
+	}()
+	r.Push(nd.Array(1, 2, 3))
+}
+
+func TestRunningMomentsMerge(t *testing.T) {
+	a := nd.Arange(0, 1000).Map(func(v float64) float64 { return v * v / 7 })
+
+	//fill one accumulator per goroutine, then merge them
+	parts := make([]*RunningMoments, 4)
+	var wg sync.WaitGroup
+	for p := range parts {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			parts[p] = NewRunningMoments()
+			for i := p * 10; i < 1000; i += 40 {
+				parts[p].Push(nd.Array(a.Values()[i : i+10]...))
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	r := NewRunningMoments()
+	for _, part := range parts {
+		r.Merge(part)
+	}
+	r.Merge(NewRunningMoments())
+	if r.Count() != 1000 {
+		t.Error("Expected 1000, got ", r.Count())
+	}
+	if !r.Mean().Equals(Average(a, nil, 0)) {
+		t.Error("Expected ", Average(a, nil, 0), ", got ", r.Mean())
+	}
+	if math.Abs(r.Var(0).Get(0)/VarDdof(a, 0, 0).Get(0)-1) > 1e-12 {
+		t.Error("Expected ", VarDdof(a, 0, 0), ", got ", r.Var(0))
+	}
+}
+
+func TestRunningCov(t *testing.T) {
+	x := nd.Array(1, 2, 2, 4.5, 3, 5, 4, 9, 5, 11).Reshape(5, 2)
+
+	r, s := NewRunningCov(), NewRunningCov()
+	r.Push(x.GetRows(0, 1))
+	s.Push(x.GetRows(2, 3, 4))
+	r.Merge(s)
+	if !r.Cov(1).Equals(Cov(x, false, 1, nil)) {
+		t.Error("Expected ", Cov(x, false, 1, nil), ", got ", r.Cov(1))
+	}
+	if !r.Corrcoef().Equals(Corrcoef(x, false)) {
+		t.Error("Expected ", Corrcoef(x, false), ", got ", r.Corrcoef())
+	}
+	if !r.Mean().Equals(nd.Array(3, 6.3)) {
+		t.Error("Expected [3, 6.3], got ", r.Mean())
+	}
+}
+
+func TestRunningMinMax(t *testing.T) {
+	r, s := NewRunningMinMax(), NewRunningMinMax()
+	r.Push(nd.Array(3, -1, 4, 1).Reshape(2, 2))
+	s.Push(nd.Array(-5, 9, 2, 6).Reshape(2, 2))
+	r.Merge(s)
+
+	if !r.Min().Equals(nd.Array(-5, -1)) || !r.Max().Equals(nd.Array(4, 9)) {
+		t.Error("Expected [-5, -1] and [4, 9], got ", r.Min(), r.Max())
+	}
+	if r.Count() != 4 {
+		t.Error("Expected 4, got ", r.Count())
+	}
+
+	defer func() {
+		p := recover()
+		if p != "no observations pushed" {
+			t.Error("Expected 'no observations pushed', got ", p)
+		}
+	}()
+	NewRunningMinMax().Min()
+}
@@ -0,0 +1,173 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/ledao/ndarray/nd"
+)
+
+//A t-digest estimating the quantiles of all the elements pushed, in bounded memory.
+//Elements are summarized by centroids which are small near the extreme quantiles, so tails stay accurate.
+//Digests built separately, for instance in several goroutines, can be merged.
+//While fewer than compression / 2 elements are pushed no centroids are combined and the quantiles are exact.
+type TDigest struct {
+	compression float64
+	centroids   []centroid
+	buffer      []centroid
+	n           float64
+	min, max    float64
+}
+
+type centroid struct {
+	mean, weight float64
+}
+
+//A digest keeping about compression centroids, 100 is a common choice.
+func NewTDigest(compression float64) *TDigest {
+	if !(compression >= 1) {
+		panic("compression must be at least 1")
+	}
+
+	return &TDigest{compression: compression, min: math.Inf(1), max: math.Inf(-1)}
+}
+
+//Add all the elements of batch, NaN elements are ignored.
+func (t *TDigest) Push(batch *nd.NdArray) {
+	for _, v := range batch.Values() {
+		if math.IsNaN(v) {
+			continue
+		}
+		t.add(centroid{v, 1})
+		t.min = math.Min(t.min, v)
+		t.max = math.Max(t.max, v)
+	}
+}
+
+//Add the elements summarized by o, o is left unchanged.
+func (t *TDigest) Merge(o *TDigest) {
+	for _, c := range o.centroids {
+		t.add(c)
+	}
+	for _, c := range o.buffer {
+		t.add(c)
+	}
+	t.min = math.Min(t.min, o.min)
+	t.max = math.Max(t.max, o.max)
+}
+
+//Number of elements.
+func (t *TDigest) Count() int {
+	return int(t.n)
+}
+
+//Estimated quantiles q, each in [0, 1], with the Linear method of Quantile.
+//The result has shape [1] for one quantile, otherwise the shape of q. It is NaN when the digest is empty.
+func (t *TDigest) Quantile(q *nd.NdArray) *nd.NdArray {
+	qs := q.Values()
+	checkQuantiles(qs)
+	t.compress()
+
+	res := make([]float64, len(qs))
+	for i, v := range qs {
+		res[i] = t.quantile(v)
+	}
+	if len(res) == 1 {
+		return nd.Array(res...)
+	}
+
+	return nd.Array(res...).Reshape(q.Shape()...)
+}
+
+//interpolate linearly between the centroid means, each placed at the middle of its weight.
+//The position index = q (n - 1) + 1/2 makes singleton centroids give the exact Linear quantile.
+func (t *TDigest) quantile(q float64) float64 {
+	cs := t.centroids
+	if len(cs) == 0 {
+		return math.NaN()
+	}
+
+	index := q*(t.n-1) + 0.5
+	center := cs[0].weight / 2
+	if index <= center {
+		return interpolate(0.5, t.min, center, cs[0].mean, index)
+	}
+	for i := 1; i < len(cs); i++ {
+		next := center + (cs[i-1].weight+cs[i].weight)/2
+		if index <= next {
+			return interpolate(center, cs[i-1].mean, next, cs[i].mean, index)
+		}
+		center = next
+	}
+
+	return interpolate(center, cs[len(cs)-1].mean, t.n-0.5, t.max, index)
+}
+
+//value at x on the line through (x0, y0) and (x1, y1).
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+	if x1 <= x0 {
+		return y1
+	}
+
+	return y0 + (y1-y0)*(x-x0)/(x1-x0)
+}
+
+func (t *TDigest) add(c centroid) {
+	t.buffer = append(t.buffer, c)
+	t.n += c.weight
+	if float64(len(t.buffer)) > 5*t.compression {
+		t.compress()
+	}
+}
+
+//merge the buffer into the centroids. Neighbouring centroids are combined while the
+//scale function k(q) = compression / 2pi * asin(2q - 1) grows by less than 1 across them.
+func (t *TDigest) compress() {
+	if len(t.buffer) == 0 {
+		return
+	}
+
+	all := append(t.centroids, t.buffer...)
+	sort.Sort(centroidSorter(all))
+	t.buffer = nil
+
+	merged := []centroid{all[0]}
+	before := 0.0
+	limit := t.quantileLimit(0)
+	for _, c := range all[1:] {
+		cur := &merged[len(merged)-1]
+		if (before+cur.weight+c.weight)/t.n <= limit {
+			cur.mean += (c.mean - cur.mean) * c.weight / (cur.weight + c.weight)
+			cur.weight += c.weight
+			continue
+		}
+		before += cur.weight
+		limit = t.quantileLimit(before / t.n)
+		merged = append(merged, c)
+	}
+	t.centroids = merged
+}
+
+//the largest quantile a centroid starting at quantile q may reach.
+func (t *TDigest) quantileLimit(q float64) float64 {
+	k := t.compression/(2*math.Pi)*math.Asin(2*q-1) + 1
+	if k >= t.compression/4 {
+		return 1
+	}
+
+	return (math.Sin(2*math.Pi*k/t.compression) + 1) / 2
+}
+
+type centroidSorter []centroid
+
+func (s centroidSorter) Len() int {
+	return len(s)
+}
+
+func (s centroidSorter) Less(i, j int) bool {
+	return s[i].mean < s[j].mean
+}
+
+func (s centroidSorter) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
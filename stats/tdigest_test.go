@@ -0,0 +1,77 @@
+package stats
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/ledao/ndarray/nd"
+	"github.com/ledao/ndarray/nd/random"
+)
+
+func TestTDigestExact(t *testing.T) {
+	a := nd.Array(5, 1, 9, 3, 7, 2, 8, 4, 6, 10, 0)
+	q := nd.Array(0, 0.1, 0.25, 0.5, 0.9, 1)
+
+	d := NewTDigest(100)
+	d.Push(a)
+	if !d.Quantile(q).Equals(Quantile(a.Clone(), q, AllAxes, Linear)) {
+		t.Error("Expected ", Quantile(a.Clone(), q, AllAxes, Linear), ", got ", d.Quantile(q))
+	}
+	if d.Count() != 11 {
+		t.Error("Expected 11, got ", d.Count())
+	}
+	if !math.IsNaN(NewTDigest(100).Quantile(nd.Array(0.5)).Get(0)) {
+		t.Error("Expected NaN for an empty digest")
+	}
+}
+
+func TestTDigestMerge(t *testing.T) {
+	g := random.NewGenerator(7)
+	batches := make([]*nd.NdArray, 40)
+	for i := range batches {
+		batches[i] = g.Normal(0, 1, 1000)
+	}
+
+	//fill one digest per goroutine, then merge them
+	parts := make([]*TDigest, 4)
+	var wg sync.WaitGroup
+	for p := range parts {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			parts[p] = NewTDigest(100)
+			for i := p; i < len(batches); i += len(parts) {
+				parts[p].Push(batches[i])
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	d := NewTDigest(100)
+	for _, part := range parts {
+		d.Merge(part)
+	}
+	if d.Count() != 40000 {
+		t.Error("Expected 40000, got ", d.Count())
+	}
+	if len(d.centroids) > 200 {
+		t.Error("Expected at most 200 centroids, got ", len(d.centroids))
+	}
+
+	//the accuracy is measured by the fraction of the data below each estimate
+	all := nd.HStack(batches...).Values()
+	q := nd.Array(0.001, 0.01, 0.1, 0.5, 0.9, 0.99, 0.999)
+	got := d.Quantile(q)
+	for i, v := range q.Values() {
+		below := 0.0
+		for _, x := range all {
+			if x < got.Get(i) {
+				below++
+			}
+		}
+		if math.Abs(below/float64(len(all))-v) > 0.001+0.01*math.Sqrt(v*(1-v)) {
+			t.Error("Expected about ", v, " of the data below ", got.Get(i), ", got ", below/float64(len(all)))
+		}
+	}
+}